@@ -7,24 +7,37 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jkk290/budget-tui/internal/accounttype"
+	"github.com/jkk290/budget-tui/internal/currency"
 	"github.com/jkk290/budget-tui/internal/database"
 	"github.com/shopspring/decimal"
 )
 
+// Account balances only count transactions dated today or earlier.
+// Working is cleared plus uncleared; FutureAmount is everything dated
+// after today. Balances are in the account's currency, except
+// BaseWorkingBalance, which is the working balance in the user's base
+// currency at today's rate and null when there is no rate.
 type Account struct {
-	ID             uuid.UUID       `json:"id"`
-	AccountName    string          `json:"account_name"`
-	AccountType    string          `json:"account_type"`
-	CreatedAt      time.Time       `json:"created_at"`
-	UpdatedAt      time.Time       `json:"updated_at"`
-	UserID         uuid.UUID       `json:"user_id"`
-	AccountBalance decimal.Decimal `json:"account_balance"`
+	ID                 uuid.UUID           `json:"id"`
+	AccountName        string              `json:"account_name"`
+	AccountType        string              `json:"account_type"`
+	Currency           string              `json:"currency"`
+	CreatedAt          time.Time           `json:"created_at"`
+	UpdatedAt          time.Time           `json:"updated_at"`
+	UserID             uuid.UUID           `json:"user_id"`
+	ClearedBalance     decimal.Decimal     `json:"cleared_balance"`
+	UnclearedBalance   decimal.Decimal     `json:"uncleared_balance"`
+	WorkingBalance     decimal.Decimal     `json:"working_balance"`
+	FutureAmount       decimal.Decimal     `json:"future_amount"`
+	BaseWorkingBalance decimal.NullDecimal `json:"base_working_balance"`
 }
 
 func (cfg *apiConfig) addAccount(w http.ResponseWriter, req *http.Request) {
 	type parameters struct {
 		AccountName    string          `json:"account_name"`
 		AccountType    string          `json:"account_type"`
+		Currency       string          `json:"currency"`
 		InitialBalance decimal.Decimal `json:"initial_balance"`
 	}
 
@@ -50,13 +63,43 @@ func (cfg *apiConfig) addAccount(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	accountType, err := accounttype.Parse(params.AccountType)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	// The initial balance of a card or loan is what's owed on it, which
+	// is a negative balance.
+	initialBalance := params.InitialBalance
+	if accountType.IsLiability() {
+		initialBalance = initialBalance.Neg()
+	}
+
+	// Accounts are in the user's base currency unless they say otherwise.
+	accountCurrency := params.Currency
+	if accountCurrency == "" {
+		dbUser, err := cfg.db.GetUserByID(req.Context(), userID)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Couldn't get user", err)
+			return
+		}
+		accountCurrency = dbUser.BaseCurrency
+	}
+	accountCurrency, err = currency.Normalize(accountCurrency)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
 	account, err := cfg.db.AddAccount(req.Context(), database.AddAccountParams{
 		ID:          uuid.New(),
 		AccountName: params.AccountName,
-		AccountType: params.AccountType,
+		AccountType: string(accountType),
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 		UserID:      userID,
+		Currency:    accountCurrency,
 	})
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Couldn't add account", err)
@@ -64,8 +107,8 @@ func (cfg *apiConfig) addAccount(w http.ResponseWriter, req *http.Request) {
 	}
 	_, txErr := cfg.db.AddTransaction(req.Context(), database.AddTransactionParams{
 		ID:            uuid.New(),
-		Amount:        params.InitialBalance,
-		TxDescription: "Initial balance",
+		Amount:        initialBalance,
+		TxDescription: accounttype.InitialBalanceDescription,
 		TxDate:        time.Now(),
 		CreatedAt:     time.Now(),
 		UpdatedAt:     time.Now(),
@@ -82,6 +125,7 @@ func (cfg *apiConfig) addAccount(w http.ResponseWriter, req *http.Request) {
 			ID:          account.ID,
 			AccountName: account.AccountName,
 			AccountType: account.AccountType,
+			Currency:    account.Currency,
 			CreatedAt:   account.CreatedAt,
 			UpdatedAt:   account.UpdatedAt,
 			UserID:      account.UserID,
@@ -97,23 +141,48 @@ func (cfg *apiConfig) getAccounts(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	dbAccounts, err := cfg.db.GetUserAccountsBalances(req.Context(), userID)
+	// Anything dated today counts, whatever its time of day.
+	now := time.Now().UTC()
+	asOf := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+
+	dbAccounts, err := cfg.db.GetUserAccountsBalances(req.Context(), database.GetUserAccountsBalancesParams{
+		AsOf:   asOf,
+		UserID: userID,
+	})
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Couldn't retrieve accounts", err)
 		return
 	}
 
+	dbUser, err := cfg.db.GetUserByID(req.Context(), userID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get user", err)
+		return
+	}
+	rates, err := loadRateTable(req.Context(), cfg.db, userID, dbUser.BaseCurrency, asOf.AddDate(0, 0, -1))
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get exchange rates", err)
+		return
+	}
+
 	accounts := []Account{}
 	for _, account := range dbAccounts {
-		balance := decimal.NewFromInt(account.AccountBalanceCents).Div(decimal.NewFromInt(100))
+		workingBalance := account.ClearedBalance.Add(account.UnclearedBalance)
+		baseWorkingBalance, ok := rates.convert(workingBalance, account.Currency)
+
 		accounts = append(accounts, Account{
-			ID:             account.ID,
-			AccountName:    account.AccountName,
-			AccountType:    account.AccountType,
-			CreatedAt:      account.CreatedAt,
-			UpdatedAt:      account.UpdatedAt,
-			UserID:         account.UserID,
-			AccountBalance: balance,
+			ID:                 account.ID,
+			AccountName:        account.AccountName,
+			AccountType:        account.AccountType,
+			Currency:           account.Currency,
+			CreatedAt:          account.CreatedAt,
+			UpdatedAt:          account.UpdatedAt,
+			UserID:             account.UserID,
+			ClearedBalance:     account.ClearedBalance,
+			UnclearedBalance:   account.UnclearedBalance,
+			WorkingBalance:     workingBalance,
+			FutureAmount:       account.FutureAmount,
+			BaseWorkingBalance: decimal.NullDecimal{Decimal: baseWorkingBalance, Valid: ok},
 		})
 	}
 
@@ -178,6 +247,7 @@ func (cfg *apiConfig) updateAccountInfo(w http.ResponseWriter, req *http.Request
 			ID:          updatedAccount.ID,
 			AccountName: updatedAccount.AccountName,
 			AccountType: updatedAccount.AccountType,
+			Currency:    updatedAccount.Currency,
 			CreatedAt:   updatedAccount.CreatedAt,
 			UpdatedAt:   updatedAccount.UpdatedAt,
 			UserID:      updatedAccount.UserID,
@@ -209,10 +279,39 @@ func (cfg *apiConfig) deleteAccount(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	// Deleting the account deletes its transactions and their attachment
+	// rows, so note the files first.
+	attachmentIDs, err := cfg.db.GetAccountAttachmentIDs(req.Context(), dbAccount.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get attachments", err)
+		return
+	}
+
 	if err := cfg.db.DeleteAccount(req.Context(), dbAccount.ID); err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Couldn't delete account", err)
 		return
 	}
+	cfg.removeAttachmentFiles(attachmentIDs)
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+func (cfg *apiConfig) getOwnedAccount(w http.ResponseWriter, req *http.Request, userID uuid.UUID) (database.Account, bool) {
+	accountID, err := uuid.Parse(req.PathValue("accountID"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid account ID", err)
+		return database.Account{}, false
+	}
+
+	dbAccount, err := cfg.db.GetAccountByID(req.Context(), accountID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Couldn't get account", err)
+		return database.Account{}, false
+	}
+	if dbAccount.UserID != userID {
+		respondWithError(w, http.StatusForbidden, "Can't access account", errors.New("unauthorized"))
+		return database.Account{}, false
+	}
+
+	return dbAccount, true
+}
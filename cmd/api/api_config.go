@@ -1,10 +1,16 @@
 package main
 
 import (
+	"database/sql"
+
+	"github.com/jkk290/budget-tui/internal/attachments"
 	"github.com/jkk290/budget-tui/internal/database"
 )
 
 type apiConfig struct {
-	db        *database.Queries
-	jwtSecret string
+	db          *database.Queries
+	dbConn      *sql.DB
+	jwtSecret   string
+	matchWindow int
+	attachments *attachments.Store
 }
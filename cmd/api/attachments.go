@@ -0,0 +1,287 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jkk290/budget-tui/internal/attachments"
+	"github.com/jkk290/budget-tui/internal/database"
+)
+
+const maxAttachmentSize = 20 << 20
+
+type Attachment struct {
+	ID            uuid.UUID `json:"id"`
+	FileName      string    `json:"file_name"`
+	ContentType   string    `json:"content_type"`
+	SizeBytes     int64     `json:"size_bytes"`
+	Checksum      string    `json:"checksum"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+}
+
+func attachmentFromDB(dbAttachment database.TransactionAttachment) Attachment {
+	return Attachment{
+		ID:            dbAttachment.ID,
+		FileName:      dbAttachment.FileName,
+		ContentType:   dbAttachment.ContentType,
+		SizeBytes:     dbAttachment.SizeBytes,
+		Checksum:      dbAttachment.Checksum,
+		CreatedAt:     dbAttachment.CreatedAt,
+		UpdatedAt:     dbAttachment.UpdatedAt,
+		TransactionID: dbAttachment.TransactionID,
+	}
+}
+
+// attachmentCounts looks up how many files are attached to each of the
+// transactions.
+func attachmentCounts(ctx context.Context, q *database.Queries, transactionIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	dbCounts, err := q.GetAttachmentCountsForTransactions(ctx, transactionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]int64, len(dbCounts))
+	for _, count := range dbCounts {
+		counts[count.TransactionID] = count.AttachmentCount
+	}
+	return counts, nil
+}
+
+// removeAttachmentFiles deletes the files of attachments whose rows are
+// already gone. Failures are only logged, since the request has succeeded
+// by then and a leftover file is harmless.
+func (cfg *apiConfig) removeAttachmentFiles(ids []uuid.UUID) {
+	for _, id := range ids {
+		if err := cfg.attachments.Remove(id); err != nil {
+			log.Printf("Couldn't remove attachment file %s: %v", id, err)
+		}
+	}
+}
+
+func (cfg *apiConfig) getOwnedTransaction(w http.ResponseWriter, req *http.Request, userID uuid.UUID) (database.Transaction, bool) {
+	transactionID, err := uuid.Parse(req.PathValue("transactionID"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid transaction ID", err)
+		return database.Transaction{}, false
+	}
+
+	dbTransactionUserID, err := cfg.db.GetTransactionUserID(req.Context(), transactionID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Couldn't get transaction", err)
+		return database.Transaction{}, false
+	}
+	if dbTransactionUserID != userID {
+		respondWithError(w, http.StatusForbidden, "Can't access transaction", errors.New("unauthorized"))
+		return database.Transaction{}, false
+	}
+
+	dbTransaction, err := cfg.db.GetTransactionByID(req.Context(), transactionID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get transaction", err)
+		return database.Transaction{}, false
+	}
+
+	return dbTransaction, true
+}
+
+// getOwnedAttachment looks up the attachment in the path and makes sure it
+// belongs to the transaction in the path, which must be the user's.
+func (cfg *apiConfig) getOwnedAttachment(w http.ResponseWriter, req *http.Request, userID uuid.UUID) (database.TransactionAttachment, bool) {
+	dbTransaction, ok := cfg.getOwnedTransaction(w, req, userID)
+	if !ok {
+		return database.TransactionAttachment{}, false
+	}
+
+	attachmentID, err := uuid.Parse(req.PathValue("attachmentID"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid attachment ID", err)
+		return database.TransactionAttachment{}, false
+	}
+
+	dbAttachment, err := cfg.db.GetTransactionAttachmentByID(req.Context(), attachmentID)
+	if err != nil || dbAttachment.TransactionID != dbTransaction.ID {
+		respondWithError(w, http.StatusNotFound, "Couldn't get attachment", err)
+		return database.TransactionAttachment{}, false
+	}
+
+	return dbAttachment, true
+}
+
+func (cfg *apiConfig) getTransactionAttachments(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	dbTransaction, ok := cfg.getOwnedTransaction(w, req, userID)
+	if !ok {
+		return
+	}
+
+	dbAttachments, err := cfg.db.GetTransactionAttachments(req.Context(), dbTransaction.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get attachments", err)
+		return
+	}
+
+	attachmentList := []Attachment{}
+	for _, dbAttachment := range dbAttachments {
+		attachmentList = append(attachmentList, attachmentFromDB(dbAttachment))
+	}
+
+	respondWithJSON(w, http.StatusOK, attachmentList)
+}
+
+// uploadTransactionAttachment streams the multipart "file" field to disk.
+// The content type is sniffed from the file rather than taken from the
+// client.
+func (cfg *apiConfig) uploadTransactionAttachment(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	dbTransaction, ok := cfg.getOwnedTransaction(w, req, userID)
+	if !ok {
+		return
+	}
+
+	// Leave room for the multipart headers around the file.
+	req.Body = http.MaxBytesReader(w, req.Body, maxAttachmentSize+1<<20)
+	reader, err := req.MultipartReader()
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Couldn't parse multipart form", err)
+		return
+	}
+
+	var part io.ReadCloser
+	var fileName string
+	for {
+		p, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Couldn't parse multipart form", err)
+			return
+		}
+		if p.FormName() == "file" {
+			part = p
+			fileName = p.FileName()
+			break
+		}
+		p.Close()
+	}
+	if part == nil {
+		respondWithError(w, http.StatusBadRequest, "Missing file", errors.New("invalid parameters"))
+		return
+	}
+	defer part.Close()
+
+	fileName = strings.TrimSpace(filepath.Base(fileName))
+	if fileName == "" || fileName == "." || fileName == string(filepath.Separator) {
+		fileName = "attachment"
+	}
+
+	content := bufio.NewReaderSize(part, 512)
+	head, err := content.Peek(512)
+	if err != nil && err != io.EOF {
+		respondWithError(w, http.StatusBadRequest, "Couldn't read file", err)
+		return
+	}
+	if len(head) == 0 {
+		respondWithError(w, http.StatusBadRequest, "File is empty", errors.New("invalid parameters"))
+		return
+	}
+	contentType := http.DetectContentType(head)
+
+	attachmentID := uuid.New()
+	size, checksum, err := cfg.attachments.Save(attachmentID, content, maxAttachmentSize)
+	var maxBytesErr *http.MaxBytesError
+	if errors.Is(err, attachments.ErrTooLarge) || errors.As(err, &maxBytesErr) {
+		respondWithError(w, http.StatusRequestEntityTooLarge, "Attachments can be at most 20 MB", err)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't save attachment", err)
+		return
+	}
+
+	dbAttachment, err := cfg.db.CreateTransactionAttachment(req.Context(), database.CreateTransactionAttachmentParams{
+		ID:            attachmentID,
+		FileName:      fileName,
+		ContentType:   contentType,
+		SizeBytes:     size,
+		Checksum:      checksum,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+		TransactionID: dbTransaction.ID,
+	})
+	if err != nil {
+		cfg.removeAttachmentFiles([]uuid.UUID{attachmentID})
+		respondWithError(w, http.StatusInternalServerError, "Couldn't save attachment", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, attachmentFromDB(dbAttachment))
+}
+
+func (cfg *apiConfig) downloadTransactionAttachment(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	dbAttachment, ok := cfg.getOwnedAttachment(w, req, userID)
+	if !ok {
+		return
+	}
+
+	file, err := cfg.attachments.Open(dbAttachment.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't open attachment", err)
+		return
+	}
+	defer file.Close()
+
+	w.Header().Set("Content-Type", dbAttachment.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": dbAttachment.FileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("ETag", `"`+dbAttachment.Checksum+`"`)
+	http.ServeContent(w, req, "", dbAttachment.CreatedAt, file)
+}
+
+func (cfg *apiConfig) deleteTransactionAttachment(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	dbAttachment, ok := cfg.getOwnedAttachment(w, req, userID)
+	if !ok {
+		return
+	}
+
+	if err := cfg.db.DeleteTransactionAttachment(req.Context(), dbAttachment.ID); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't delete attachment", err)
+		return
+	}
+	cfg.removeAttachmentFiles([]uuid.UUID{dbAttachment.ID})
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,657 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jkk290/budget-tui/internal/backup"
+	"github.com/jkk290/budget-tui/internal/database"
+	"github.com/lib/pq"
+)
+
+const maxBackupFileSize = 100 << 20
+
+// errBudgetNotEmpty is returned when restoring over a user that already has
+// data.
+var errBudgetNotEmpty = errors.New("budget is not empty")
+
+func (cfg *apiConfig) buildBackup(ctx context.Context, userID uuid.UUID) (backup.Archive, error) {
+	archive := backup.Archive{
+		Format:                backup.FormatName,
+		SchemaVersion:         backup.SchemaVersion,
+		CreatedAt:             time.Now().UTC(),
+		Groups:                []backup.Group{},
+		Categories:            []backup.Category{},
+		CategoryBudgets:       []backup.CategoryBudget{},
+		Accounts:              []backup.Account{},
+		Reconciliations:       []backup.Reconciliation{},
+		Payees:                []backup.Payee{},
+		ScheduledTransactions: []backup.ScheduledTransaction{},
+		Transactions:          []backup.Transaction{},
+		TransactionSplits:     []backup.TransactionSplit{},
+		Rules:                 []backup.Rule{},
+		Tags:                  []backup.Tag{},
+		TransactionTags:       []backup.TransactionTag{},
+		ExchangeRates:         []backup.ExchangeRate{},
+	}
+
+	dbUser, err := cfg.db.GetUserByID(ctx, userID)
+	if err != nil {
+		return backup.Archive{}, fmt.Errorf("couldn't get user: %w", err)
+	}
+	archive.BaseCurrency = dbUser.BaseCurrency
+
+	dbGroups, err := cfg.db.GetGroupsByUser(ctx, userID)
+	if err != nil {
+		return backup.Archive{}, fmt.Errorf("couldn't get groups: %w", err)
+	}
+	for _, group := range dbGroups {
+		archive.Groups = append(archive.Groups, backup.Group{
+			ID:        group.ID,
+			GroupName: group.GroupName,
+			CreatedAt: group.CreatedAt,
+			UpdatedAt: group.UpdatedAt,
+		})
+	}
+
+	dbCategories, err := cfg.db.GetCategoriesByUser(ctx, userID)
+	if err != nil {
+		return backup.Archive{}, fmt.Errorf("couldn't get categories: %w", err)
+	}
+	for _, category := range dbCategories {
+		archive.Categories = append(archive.Categories, backup.Category{
+			ID:                   category.ID,
+			CategoryName:         category.CategoryName,
+			Budget:               category.Budget,
+			GroupID:              category.GroupID.UUID,
+			RolloverOverspending: category.RolloverOverspending,
+			IsIncome:             category.IsIncome,
+			CreatedAt:            category.CreatedAt,
+			UpdatedAt:            category.UpdatedAt,
+		})
+	}
+
+	dbBudgets, err := cfg.db.GetUserCategoryBudgets(ctx, userID)
+	if err != nil {
+		return backup.Archive{}, fmt.Errorf("couldn't get category budgets: %w", err)
+	}
+	for _, budget := range dbBudgets {
+		archive.CategoryBudgets = append(archive.CategoryBudgets, backup.CategoryBudget{
+			CategoryID: budget.CategoryID,
+			Month:      budget.Month,
+			Assigned:   budget.Assigned,
+			CreatedAt:  budget.CreatedAt,
+			UpdatedAt:  budget.UpdatedAt,
+		})
+	}
+
+	dbAccounts, err := cfg.db.GetAccountsByUserID(ctx, userID)
+	if err != nil {
+		return backup.Archive{}, fmt.Errorf("couldn't get accounts: %w", err)
+	}
+	for _, account := range dbAccounts {
+		archive.Accounts = append(archive.Accounts, backup.Account{
+			ID:          account.ID,
+			AccountName: account.AccountName,
+			AccountType: account.AccountType,
+			Currency:    account.Currency,
+			CreatedAt:   account.CreatedAt,
+			UpdatedAt:   account.UpdatedAt,
+		})
+	}
+
+	dbReconciliations, err := cfg.db.GetUserReconciliations(ctx, userID)
+	if err != nil {
+		return backup.Archive{}, fmt.Errorf("couldn't get reconciliations: %w", err)
+	}
+	for _, reconciliation := range dbReconciliations {
+		archive.Reconciliations = append(archive.Reconciliations, backup.Reconciliation{
+			ID:               reconciliation.ID,
+			AccountID:        reconciliation.AccountID,
+			StatementDate:    reconciliation.StatementDate,
+			StatementBalance: reconciliation.StatementBalance,
+			TransactionCount: reconciliation.TransactionCount,
+			CreatedAt:        reconciliation.CreatedAt,
+			UpdatedAt:        reconciliation.UpdatedAt,
+		})
+	}
+
+	dbPayees, err := cfg.db.GetUserPayees(ctx, userID)
+	if err != nil {
+		return backup.Archive{}, fmt.Errorf("couldn't get payees: %w", err)
+	}
+	for _, payee := range dbPayees {
+		archive.Payees = append(archive.Payees, backup.Payee{
+			ID:                payee.ID,
+			PayeeName:         payee.PayeeName,
+			DefaultCategoryID: payee.DefaultCategoryID.UUID,
+			CreatedAt:         payee.CreatedAt,
+			UpdatedAt:         payee.UpdatedAt,
+		})
+	}
+
+	dbScheduled, err := cfg.db.GetScheduledTransactionsByUser(ctx, userID)
+	if err != nil {
+		return backup.Archive{}, fmt.Errorf("couldn't get scheduled transactions: %w", err)
+	}
+	for _, s := range dbScheduled {
+		archive.ScheduledTransactions = append(archive.ScheduledTransactions, backup.ScheduledTransaction{
+			ID:              s.ID,
+			Amount:          s.Amount,
+			TxDescription:   s.TxDescription,
+			Frequency:       s.Frequency,
+			DayOfMonth:      s.DayOfMonth,
+			StartDate:       s.StartDate,
+			EndDate:         nullTimePtr(s.EndDate),
+			OccurrenceCount: s.OccurrenceCount,
+			LastDate:        nullTimePtr(s.LastDate),
+			AccountID:       s.AccountID,
+			CategoryID:      s.CategoryID.UUID,
+			CreatedAt:       s.CreatedAt,
+			UpdatedAt:       s.UpdatedAt,
+		})
+	}
+
+	params := database.GetUserTransactionsParams{
+		UserID:   userID,
+		RowLimit: maxPageSize,
+	}
+	for {
+		dbTransactions, err := cfg.db.GetUserTransactions(ctx, params)
+		if err != nil {
+			return backup.Archive{}, fmt.Errorf("couldn't get transactions: %w", err)
+		}
+		for _, tx := range dbTransactions {
+			archive.Transactions = append(archive.Transactions, backup.Transaction{
+				ID:               tx.ID,
+				Amount:           tx.Amount,
+				TxDescription:    tx.TxDescription,
+				Memo:             tx.Memo,
+				TxDate:           tx.TxDate,
+				Posted:           tx.Posted,
+				AccountID:        tx.AccountID,
+				CategoryID:       tx.CategoryID.UUID,
+				TransferID:       tx.TransferID.UUID,
+				ScheduledID:      tx.ScheduledID.UUID,
+				ScheduledDate:    nullTimePtr(tx.ScheduledDate),
+				PayeeID:          tx.PayeeID.UUID,
+				ReconciliationID: tx.ReconciliationID.UUID,
+				CreatedAt:        tx.CreatedAt,
+				UpdatedAt:        tx.UpdatedAt,
+			})
+		}
+
+		if len(dbTransactions) < int(params.RowLimit) {
+			break
+		}
+		last := dbTransactions[len(dbTransactions)-1]
+		params.CursorDate = sql.NullTime{Time: last.TxDate, Valid: true}
+		params.CursorID = uuid.NullUUID{UUID: last.ID, Valid: true}
+	}
+
+	dbSplits, err := cfg.db.GetUserTransactionSplits(ctx, userID)
+	if err != nil {
+		return backup.Archive{}, fmt.Errorf("couldn't get transaction splits: %w", err)
+	}
+	for _, split := range dbSplits {
+		archive.TransactionSplits = append(archive.TransactionSplits, backup.TransactionSplit{
+			ID:            split.ID,
+			TransactionID: split.TransactionID,
+			CategoryID:    split.CategoryID.UUID,
+			Amount:        split.Amount,
+			Memo:          split.Memo,
+			SortOrder:     split.SortOrder,
+			CreatedAt:     split.CreatedAt,
+			UpdatedAt:     split.UpdatedAt,
+		})
+	}
+
+	dbRules, err := cfg.db.GetUserRules(ctx, userID)
+	if err != nil {
+		return backup.Archive{}, fmt.Errorf("couldn't get rules: %w", err)
+	}
+	for _, rule := range dbRules {
+		archive.Rules = append(archive.Rules, backup.Rule{
+			ID:                  rule.ID,
+			RuleName:            rule.RuleName,
+			DescriptionContains: rule.DescriptionContains,
+			DescriptionRegex:    rule.DescriptionRegex,
+			MinAmount:           nullDecimalPtr(rule.MinAmount),
+			MaxAmount:           nullDecimalPtr(rule.MaxAmount),
+			AccountID:           rule.AccountID.UUID,
+			SetCategoryID:       rule.SetCategoryID.UUID,
+			SetDescription:      rule.SetDescription,
+			SetPosted:           nullBoolPtr(rule.SetPosted),
+			CreatedAt:           rule.CreatedAt,
+			UpdatedAt:           rule.UpdatedAt,
+		})
+	}
+
+	dbTags, err := cfg.db.GetUserTags(ctx, userID)
+	if err != nil {
+		return backup.Archive{}, fmt.Errorf("couldn't get tags: %w", err)
+	}
+	for _, tag := range dbTags {
+		archive.Tags = append(archive.Tags, backup.Tag{
+			ID:        tag.ID,
+			TagName:   tag.TagName,
+			CreatedAt: tag.CreatedAt,
+			UpdatedAt: tag.UpdatedAt,
+		})
+	}
+
+	dbTransactionTags, err := cfg.db.GetUserTransactionTags(ctx, userID)
+	if err != nil {
+		return backup.Archive{}, fmt.Errorf("couldn't get transaction tags: %w", err)
+	}
+	for _, link := range dbTransactionTags {
+		archive.TransactionTags = append(archive.TransactionTags, backup.TransactionTag{
+			TransactionID: link.TransactionID,
+			TagID:         link.TagID,
+		})
+	}
+
+	dbRates, err := cfg.db.GetExchangeRatesByUser(ctx, userID)
+	if err != nil {
+		return backup.Archive{}, fmt.Errorf("couldn't get exchange rates: %w", err)
+	}
+	for _, rate := range dbRates {
+		archive.ExchangeRates = append(archive.ExchangeRates, backup.ExchangeRate{
+			ID:           rate.ID,
+			FromCurrency: rate.FromCurrency,
+			ToCurrency:   rate.ToCurrency,
+			Rate:         rate.Rate,
+			RateDate:     rate.RateDate,
+			CreatedAt:    rate.CreatedAt,
+			UpdatedAt:    rate.UpdatedAt,
+		})
+	}
+
+	return archive, nil
+}
+
+// checkBudgetEmpty makes sure the user has nothing a restore could clash
+// with. Transactions, splits and budgets hang off accounts and categories,
+// so those don't need checking on their own.
+func checkBudgetEmpty(ctx context.Context, qtx *database.Queries, userID uuid.UUID) error {
+	accounts, err := qtx.GetAccountsByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	categories, err := qtx.GetCategoriesByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	groups, err := qtx.GetGroupsByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	payees, err := qtx.GetUserPayees(ctx, userID)
+	if err != nil {
+		return err
+	}
+	rules, err := qtx.GetUserRules(ctx, userID)
+	if err != nil {
+		return err
+	}
+	scheduled, err := qtx.GetScheduledTransactionsByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	tags, err := qtx.GetUserTags(ctx, userID)
+	if err != nil {
+		return err
+	}
+	rates, err := qtx.GetExchangeRatesByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if len(accounts)+len(categories)+len(groups)+len(payees)+len(rules)+len(scheduled)+len(tags)+len(rates) > 0 {
+		return errBudgetNotEmpty
+	}
+	return nil
+}
+
+// restoreArchive inserts every record in an order that satisfies the
+// foreign keys. The archive must already be validated.
+func restoreArchive(ctx context.Context, qtx *database.Queries, userID uuid.UUID, archive backup.Archive) error {
+	_, err := qtx.UpdateUserBaseCurrency(ctx, database.UpdateUserBaseCurrencyParams{
+		ID:           userID,
+		BaseCurrency: archive.BaseCurrency,
+	})
+	if err != nil {
+		return fmt.Errorf("base currency: %w", err)
+	}
+
+	for _, group := range archive.Groups {
+		_, err := qtx.CreateGroup(ctx, database.CreateGroupParams{
+			ID:        group.ID,
+			GroupName: group.GroupName,
+			CreatedAt: group.CreatedAt,
+			UpdatedAt: group.UpdatedAt,
+			UserID:    userID,
+		})
+		if err != nil {
+			return fmt.Errorf("group %s: %w", group.ID, err)
+		}
+	}
+
+	for _, category := range archive.Categories {
+		_, err := qtx.CreateCategory(ctx, database.CreateCategoryParams{
+			ID:                   category.ID,
+			CategoryName:         category.CategoryName,
+			CreatedAt:            category.CreatedAt,
+			UpdatedAt:            category.UpdatedAt,
+			Budget:               category.Budget,
+			UserID:               userID,
+			GroupID:              nullUUID(category.GroupID),
+			RolloverOverspending: category.RolloverOverspending,
+			IsIncome:             category.IsIncome,
+		})
+		if err != nil {
+			return fmt.Errorf("category %s: %w", category.ID, err)
+		}
+	}
+
+	for _, budget := range archive.CategoryBudgets {
+		_, err := qtx.SetCategoryBudget(ctx, database.SetCategoryBudgetParams{
+			CategoryID: budget.CategoryID,
+			Month:      budget.Month,
+			Assigned:   budget.Assigned,
+			CreatedAt:  budget.CreatedAt,
+			UpdatedAt:  budget.UpdatedAt,
+		})
+		if err != nil {
+			return fmt.Errorf("category budget %s: %w", budget.CategoryID, err)
+		}
+	}
+
+	for _, account := range archive.Accounts {
+		_, err := qtx.AddAccount(ctx, database.AddAccountParams{
+			ID:          account.ID,
+			AccountName: account.AccountName,
+			AccountType: account.AccountType,
+			CreatedAt:   account.CreatedAt,
+			UpdatedAt:   account.UpdatedAt,
+			UserID:      userID,
+			Currency:    account.Currency,
+		})
+		if err != nil {
+			return fmt.Errorf("account %s: %w", account.ID, err)
+		}
+	}
+
+	for _, reconciliation := range archive.Reconciliations {
+		_, err := qtx.CreateReconciliation(ctx, database.CreateReconciliationParams{
+			ID:               reconciliation.ID,
+			StatementDate:    reconciliation.StatementDate,
+			StatementBalance: reconciliation.StatementBalance,
+			TransactionCount: reconciliation.TransactionCount,
+			CreatedAt:        reconciliation.CreatedAt,
+			UpdatedAt:        reconciliation.UpdatedAt,
+			AccountID:        reconciliation.AccountID,
+		})
+		if err != nil {
+			return fmt.Errorf("reconciliation %s: %w", reconciliation.ID, err)
+		}
+	}
+
+	for _, payee := range archive.Payees {
+		_, err := qtx.CreatePayee(ctx, database.CreatePayeeParams{
+			ID:                payee.ID,
+			PayeeName:         payee.PayeeName,
+			CreatedAt:         payee.CreatedAt,
+			UpdatedAt:         payee.UpdatedAt,
+			UserID:            userID,
+			DefaultCategoryID: nullUUID(payee.DefaultCategoryID),
+		})
+		if err != nil {
+			return fmt.Errorf("payee %s: %w", payee.ID, err)
+		}
+	}
+
+	for _, s := range archive.ScheduledTransactions {
+		_, err := qtx.CreateScheduledTransaction(ctx, database.CreateScheduledTransactionParams{
+			ID:              s.ID,
+			Amount:          s.Amount,
+			TxDescription:   s.TxDescription,
+			Frequency:       s.Frequency,
+			DayOfMonth:      s.DayOfMonth,
+			StartDate:       s.StartDate,
+			EndDate:         nullTime(s.EndDate),
+			OccurrenceCount: s.OccurrenceCount,
+			CreatedAt:       s.CreatedAt,
+			UpdatedAt:       s.UpdatedAt,
+			UserID:          userID,
+			AccountID:       s.AccountID,
+			CategoryID:      nullUUID(s.CategoryID),
+		})
+		if err != nil {
+			return fmt.Errorf("scheduled transaction %s: %w", s.ID, err)
+		}
+		if s.LastDate != nil {
+			_, err := qtx.SetScheduledTransactionLastDate(ctx, database.SetScheduledTransactionLastDateParams{
+				ID:       s.ID,
+				LastDate: nullTime(s.LastDate),
+			})
+			if err != nil {
+				return fmt.Errorf("scheduled transaction %s: %w", s.ID, err)
+			}
+		}
+	}
+
+	for _, tx := range archive.Transactions {
+		err := qtx.RestoreTransaction(ctx, database.RestoreTransactionParams{
+			ID:               tx.ID,
+			Amount:           tx.Amount,
+			TxDescription:    tx.TxDescription,
+			Memo:             tx.Memo,
+			TxDate:           tx.TxDate,
+			CreatedAt:        tx.CreatedAt,
+			UpdatedAt:        tx.UpdatedAt,
+			Posted:           tx.Posted,
+			AccountID:        tx.AccountID,
+			CategoryID:       nullUUID(tx.CategoryID),
+			TransferID:       nullUUID(tx.TransferID),
+			ScheduledID:      nullUUID(tx.ScheduledID),
+			ScheduledDate:    nullTime(tx.ScheduledDate),
+			PayeeID:          nullUUID(tx.PayeeID),
+			ReconciliationID: nullUUID(tx.ReconciliationID),
+		})
+		if err != nil {
+			return fmt.Errorf("transaction %s: %w", tx.ID, err)
+		}
+	}
+
+	for _, split := range archive.TransactionSplits {
+		_, err := qtx.AddTransactionSplit(ctx, database.AddTransactionSplitParams{
+			ID:            split.ID,
+			TransactionID: split.TransactionID,
+			CategoryID:    nullUUID(split.CategoryID),
+			Amount:        split.Amount,
+			Memo:          split.Memo,
+			SortOrder:     split.SortOrder,
+			CreatedAt:     split.CreatedAt,
+			UpdatedAt:     split.UpdatedAt,
+		})
+		if err != nil {
+			return fmt.Errorf("transaction split %s: %w", split.ID, err)
+		}
+	}
+
+	// Rules are appended one after another, so they keep the archive's order.
+	for _, rule := range archive.Rules {
+		_, err := qtx.CreateRule(ctx, database.CreateRuleParams{
+			ID:                  rule.ID,
+			RuleName:            rule.RuleName,
+			DescriptionContains: rule.DescriptionContains,
+			DescriptionRegex:    rule.DescriptionRegex,
+			MinAmount:           nullDecimal(rule.MinAmount),
+			MaxAmount:           nullDecimal(rule.MaxAmount),
+			AccountID:           nullUUID(rule.AccountID),
+			SetCategoryID:       nullUUID(rule.SetCategoryID),
+			SetDescription:      rule.SetDescription,
+			SetPosted:           nullBool(rule.SetPosted),
+			CreatedAt:           rule.CreatedAt,
+			UpdatedAt:           rule.UpdatedAt,
+			UserID:              userID,
+		})
+		if err != nil {
+			return fmt.Errorf("rule %s: %w", rule.ID, err)
+		}
+	}
+
+	for _, tag := range archive.Tags {
+		_, err := qtx.CreateTag(ctx, database.CreateTagParams{
+			ID:        tag.ID,
+			TagName:   tag.TagName,
+			CreatedAt: tag.CreatedAt,
+			UpdatedAt: tag.UpdatedAt,
+			UserID:    userID,
+		})
+		if err != nil {
+			return fmt.Errorf("tag %s: %w", tag.ID, err)
+		}
+	}
+
+	for _, link := range archive.TransactionTags {
+		err := qtx.AddTransactionTag(ctx, database.AddTransactionTagParams{
+			TransactionID: link.TransactionID,
+			TagID:         link.TagID,
+		})
+		if err != nil {
+			return fmt.Errorf("transaction %s tag %s: %w", link.TransactionID, link.TagID, err)
+		}
+	}
+
+	for _, rate := range archive.ExchangeRates {
+		_, err := qtx.SaveExchangeRate(ctx, database.SaveExchangeRateParams{
+			ID:           rate.ID,
+			FromCurrency: rate.FromCurrency,
+			ToCurrency:   rate.ToCurrency,
+			Rate:         rate.Rate,
+			RateDate:     rate.RateDate,
+			CreatedAt:    rate.CreatedAt,
+			UpdatedAt:    rate.UpdatedAt,
+			UserID:       userID,
+		})
+		if err != nil {
+			return fmt.Errorf("exchange rate %s: %w", rate.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (cfg *apiConfig) getBackup(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	archive, err := cfg.buildBackup(req.Context(), userID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't build backup", err)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="budget-backup-%s.json"`, archive.CreatedAt.Format(time.DateOnly)))
+	respondWithJSON(w, http.StatusOK, archive)
+}
+
+func (cfg *apiConfig) restoreBackup(w http.ResponseWriter, req *http.Request) {
+	type response struct {
+		Groups                int `json:"groups"`
+		Categories            int `json:"categories"`
+		CategoryBudgets       int `json:"category_budgets"`
+		Accounts              int `json:"accounts"`
+		Reconciliations       int `json:"reconciliations"`
+		Payees                int `json:"payees"`
+		ScheduledTransactions int `json:"scheduled_transactions"`
+		Transactions          int `json:"transactions"`
+		TransactionSplits     int `json:"transaction_splits"`
+		Rules                 int `json:"rules"`
+		Tags                  int `json:"tags"`
+		TransactionTags       int `json:"transaction_tags"`
+		ExchangeRates         int `json:"exchange_rates"`
+	}
+
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	req.Body = http.MaxBytesReader(w, req.Body, maxBackupFileSize)
+	decoder := json.NewDecoder(req.Body)
+	archive := backup.Archive{}
+	if err := decoder.Decode(&archive); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Couldn't read backup", err)
+		return
+	}
+
+	if err := archive.Upgrade(); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	if err := archive.Validate(); err != nil {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("Invalid backup: %s", err), err)
+		return
+	}
+
+	tx, err := cfg.dbConn.BeginTx(req.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't restore backup", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	if err := checkBudgetEmpty(req.Context(), qtx, userID); err != nil {
+		if errors.Is(err, errBudgetNotEmpty) {
+			respondWithError(w, http.StatusConflict, "Backups can only be restored into an empty budget", err)
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "Couldn't restore backup", err)
+		return
+	}
+
+	if err := restoreArchive(req.Context(), qtx, userID, archive); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			respondWithError(w, http.StatusConflict, "Backup IDs already exist on this server", err)
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "Couldn't restore backup", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't restore backup", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, response{
+		Groups:                len(archive.Groups),
+		Categories:            len(archive.Categories),
+		CategoryBudgets:       len(archive.CategoryBudgets),
+		Accounts:              len(archive.Accounts),
+		Reconciliations:       len(archive.Reconciliations),
+		Payees:                len(archive.Payees),
+		ScheduledTransactions: len(archive.ScheduledTransactions),
+		Transactions:          len(archive.Transactions),
+		TransactionSplits:     len(archive.TransactionSplits),
+		Rules:                 len(archive.Rules),
+		Tags:                  len(archive.Tags),
+		TransactionTags:       len(archive.TransactionTags),
+		ExchangeRates:         len(archive.ExchangeRates),
+	})
+}
@@ -1,7 +1,10 @@
 package main
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,31 +13,52 @@ import (
 )
 
 type BudgetCategoryResponse struct {
-	CategoryID   uuid.UUID       `json:"category_id"`
-	CategoryName string          `json:"category_name"`
-	Budget       decimal.Decimal `json:"budget"`
-	TotalSpent   decimal.Decimal `json:"total_spent"`
-	Remaining    decimal.Decimal `json:"remaining"`
-	IsOverspent  bool            `json:"is_overspent"`
+	CategoryID           uuid.UUID       `json:"category_id"`
+	CategoryName         string          `json:"category_name"`
+	Budget               decimal.Decimal `json:"budget"`
+	TotalSpent           decimal.Decimal `json:"total_spent"`
+	Remaining            decimal.Decimal `json:"remaining"`
+	IsOverspent          bool            `json:"is_overspent"`
+	CarriedOver          decimal.Decimal `json:"carried_over"`
+	Assigned             decimal.Decimal `json:"assigned"`
+	Activity             decimal.Decimal `json:"activity"`
+	Available            decimal.Decimal `json:"available"`
+	RolloverOverspending bool            `json:"rollover_overspending"`
 }
 
 type BudgetGroupResponse struct {
-	GroupID        uuid.UUID                `json:"group_id"`
-	GroupName      string                   `json:"group_name"`
-	Categories     []BudgetCategoryResponse `json:"categories"`
-	TotalBudget    decimal.Decimal          `json:"total_budget"`
-	TotalSpent     decimal.Decimal          `json:"total_spent"`
-	TotalRemaining decimal.Decimal          `json:"total_remaining"`
+	GroupID          uuid.UUID                `json:"group_id"`
+	GroupName        string                   `json:"group_name"`
+	Categories       []BudgetCategoryResponse `json:"categories"`
+	TotalBudget      decimal.Decimal          `json:"total_budget"`
+	TotalSpent       decimal.Decimal          `json:"total_spent"`
+	TotalRemaining   decimal.Decimal          `json:"total_remaining"`
+	TotalCarriedOver decimal.Decimal          `json:"total_carried_over"`
+	TotalAssigned    decimal.Decimal          `json:"total_assigned"`
+	TotalActivity    decimal.Decimal          `json:"total_activity"`
+	TotalAvailable   decimal.Decimal          `json:"total_available"`
 }
 
+// Amounts in the overview are in BaseCurrency. Transactions in other
+// currencies are converted at the rate in effect on their date;
+// UnconvertedCurrencies lists currencies with transactions that had no rate
+// yet and were left out.
 type BudgetOverviewResponse struct {
-	StartDate           time.Time                `json:"start_date"`
-	EndDate             time.Time                `json:"end_date"`
-	Groups              []BudgetGroupResponse    `json:"groups"`
-	UngroupedCategories []BudgetCategoryResponse `json:"ungrouped_categories"`
-	GrandTotalBudget    decimal.Decimal          `json:"grand_total_budget"`
-	GrandTotalSpent     decimal.Decimal          `json:"grand_total_spent"`
-	GrandTotalRemaining decimal.Decimal          `json:"grand_total_remaining"`
+	StartDate             time.Time                `json:"start_date"`
+	EndDate               time.Time                `json:"end_date"`
+	BaseCurrency          string                   `json:"base_currency"`
+	UnconvertedCurrencies []string                 `json:"unconverted_currencies"`
+	TotalIncome           decimal.Decimal          `json:"total_income"`
+	ReadyToAssign         decimal.Decimal          `json:"ready_to_assign"`
+	Groups                []BudgetGroupResponse    `json:"groups"`
+	UngroupedCategories   []BudgetCategoryResponse `json:"ungrouped_categories"`
+	GrandTotalBudget      decimal.Decimal          `json:"grand_total_budget"`
+	GrandTotalSpent       decimal.Decimal          `json:"grand_total_spent"`
+	GrandTotalRemaining   decimal.Decimal          `json:"grand_total_remaining"`
+	GrandTotalCarriedOver decimal.Decimal          `json:"grand_total_carried_over"`
+	GrandTotalAssigned    decimal.Decimal          `json:"grand_total_assigned"`
+	GrandTotalActivity    decimal.Decimal          `json:"grand_total_activity"`
+	GrandTotalAvailable   decimal.Decimal          `json:"grand_total_available"`
 }
 
 func (cfg *apiConfig) handlerGetBudgetOverview(w http.ResponseWriter, req *http.Request) {
@@ -44,37 +68,106 @@ func (cfg *apiConfig) handlerGetBudgetOverview(w http.ResponseWriter, req *http.
 		return
 	}
 
-	now := time.Now()
-	year := now.Year()
-	month := now.Month()
-
-	startDate := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
-	endDate := startDate.AddDate(0, 1, 0)
+	startDate, endDate, err := budgetPeriodFromQuery(req.URL.Query(), time.Now())
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	startMonth := monthOf(startDate)
+	lastMonth := monthOf(endDate.Add(-time.Nanosecond))
 
 	rows, err := cfg.db.GetUserBudgetOverviewForMonth(req.Context(), database.GetUserBudgetOverviewForMonthParams{
-		UserID:   userID,
-		TxDate:   startDate,
-		TxDate_2: endDate,
+		StartDate: startDate,
+		EndDate:   endDate,
+		UserID:    userID,
 	})
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to get budget overview", err)
 		return
 	}
 
+	ledgers := make(map[uuid.UUID]*categoryLedger)
+	for _, row := range rows {
+		ledgers[row.CategoryID] = newCategoryLedger(row.Budget, row.CreatedAt, row.RolloverOverspending)
+	}
+
+	dbBudgets, err := cfg.db.GetUserCategoryBudgetsBefore(req.Context(), database.GetUserCategoryBudgetsBeforeParams{
+		UserID: userID,
+		Month:  lastMonth.AddDate(0, 1, 0),
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to get category budgets", err)
+		return
+	}
+	for _, budget := range dbBudgets {
+		if ledger, ok := ledgers[budget.CategoryID]; ok {
+			ledger.setAssigned(budget.Month, budget.Assigned)
+		}
+	}
+
+	activityRows, err := cfg.db.GetUserCategoryMonthlyActivity(req.Context(), database.GetUserCategoryMonthlyActivityParams{
+		UserID:  userID,
+		EndDate: endDate,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to get category activity", err)
+		return
+	}
+	for _, activity := range activityRows {
+		if ledger, ok := ledgers[activity.CategoryID]; ok {
+			ledger.addActivity(activity.Month, activity.Activity)
+		}
+	}
+
+	totalIncome, err := cfg.db.GetUserTotalIncome(req.Context(), database.GetUserTotalIncomeParams{
+		UserID:  userID,
+		EndDate: endDate,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to get total income", err)
+		return
+	}
+	readyToAssign := totalIncome
+
+	dbUser, err := cfg.db.GetUserByID(req.Context(), userID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to get user", err)
+		return
+	}
+
+	unconverted, err := cfg.db.GetUnconvertedCurrencies(req.Context(), database.GetUnconvertedCurrenciesParams{
+		UserID:  userID,
+		EndDate: endDate,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to check exchange rates", err)
+		return
+	}
+	if unconverted == nil {
+		unconverted = []string{}
+	}
+
 	groupsMap := make(map[uuid.UUID]*BudgetGroupResponse)
+	var groupOrder []uuid.UUID
 	var ungroupedCategories []BudgetCategoryResponse
 
 	for _, row := range rows {
-		remaining := row.Budget.Sub(row.TotalSpent)
-		isOverspent := remaining.IsNegative()
+		balance := ledgers[row.CategoryID].balance(startMonth, lastMonth)
+		remaining := balance.Assigned.Sub(row.TotalSpent)
+		readyToAssign = readyToAssign.Sub(balance.TotalAssigned).Add(balance.ResetOverspending)
 
 		category := BudgetCategoryResponse{
-			CategoryID:   row.CategoryID,
-			CategoryName: row.CategoryName,
-			Budget:       row.Budget,
-			TotalSpent:   row.TotalSpent,
-			Remaining:    remaining,
-			IsOverspent:  isOverspent,
+			CategoryID:           row.CategoryID,
+			CategoryName:         row.CategoryName,
+			Budget:               balance.Assigned,
+			TotalSpent:           row.TotalSpent,
+			Remaining:            remaining,
+			IsOverspent:          balance.Available.IsNegative(),
+			CarriedOver:          balance.CarriedOver,
+			Assigned:             balance.Assigned,
+			Activity:             balance.Activity,
+			Available:            balance.Available,
+			RolloverOverspending: row.RolloverOverspending,
 		}
 
 		if !row.GroupID.Valid {
@@ -84,51 +177,108 @@ func (cfg *apiConfig) handlerGetBudgetOverview(w http.ResponseWriter, req *http.
 
 		groupID := row.GroupID.UUID
 		if _, exists := groupsMap[groupID]; !exists {
+			groupOrder = append(groupOrder, groupID)
 			groupsMap[groupID] = &BudgetGroupResponse{
-				GroupID:        groupID,
-				GroupName:      row.GroupName.String,
-				Categories:     []BudgetCategoryResponse{},
-				TotalBudget:    decimal.Zero,
-				TotalSpent:     decimal.Zero,
-				TotalRemaining: decimal.Zero,
+				GroupID:          groupID,
+				GroupName:        row.GroupName.String,
+				Categories:       []BudgetCategoryResponse{},
+				TotalBudget:      decimal.Zero,
+				TotalSpent:       decimal.Zero,
+				TotalRemaining:   decimal.Zero,
+				TotalCarriedOver: decimal.Zero,
+				TotalAssigned:    decimal.Zero,
+				TotalActivity:    decimal.Zero,
+				TotalAvailable:   decimal.Zero,
 			}
 		}
 
 		group := groupsMap[groupID]
 		group.Categories = append(group.Categories, category)
-		group.TotalBudget = group.TotalBudget.Add(row.Budget)
-		group.TotalSpent = group.TotalSpent.Add(row.TotalSpent)
-		group.TotalRemaining = group.TotalRemaining.Add(remaining)
+		group.TotalBudget = group.TotalBudget.Add(category.Budget)
+		group.TotalSpent = group.TotalSpent.Add(category.TotalSpent)
+		group.TotalRemaining = group.TotalRemaining.Add(category.Remaining)
+		group.TotalCarriedOver = group.TotalCarriedOver.Add(category.CarriedOver)
+		group.TotalAssigned = group.TotalAssigned.Add(category.Assigned)
+		group.TotalActivity = group.TotalActivity.Add(category.Activity)
+		group.TotalAvailable = group.TotalAvailable.Add(category.Available)
 	}
 
-	groups := make([]BudgetGroupResponse, 0, len(groupsMap))
-	for _, group := range groupsMap {
-		groups = append(groups, *group)
+	groups := make([]BudgetGroupResponse, 0, len(groupOrder))
+	for _, groupID := range groupOrder {
+		groups = append(groups, *groupsMap[groupID])
 	}
 
-	grandTotalBudget := decimal.Zero
-	grandTotalSpent := decimal.Zero
+	response := BudgetOverviewResponse{
+		StartDate:             startDate,
+		EndDate:               endDate,
+		BaseCurrency:          dbUser.BaseCurrency,
+		UnconvertedCurrencies: unconverted,
+		TotalIncome:           totalIncome,
+		ReadyToAssign:         readyToAssign,
+		Groups:                groups,
+		UngroupedCategories:   ungroupedCategories,
+		GrandTotalBudget:      decimal.Zero,
+		GrandTotalSpent:       decimal.Zero,
+		GrandTotalCarriedOver: decimal.Zero,
+		GrandTotalAssigned:    decimal.Zero,
+		GrandTotalActivity:    decimal.Zero,
+		GrandTotalAvailable:   decimal.Zero,
+	}
 
 	for _, group := range groups {
-		grandTotalBudget = grandTotalBudget.Add(group.TotalBudget)
-		grandTotalSpent = grandTotalSpent.Add(group.TotalSpent)
+		response.GrandTotalBudget = response.GrandTotalBudget.Add(group.TotalBudget)
+		response.GrandTotalSpent = response.GrandTotalSpent.Add(group.TotalSpent)
+		response.GrandTotalCarriedOver = response.GrandTotalCarriedOver.Add(group.TotalCarriedOver)
+		response.GrandTotalAssigned = response.GrandTotalAssigned.Add(group.TotalAssigned)
+		response.GrandTotalActivity = response.GrandTotalActivity.Add(group.TotalActivity)
+		response.GrandTotalAvailable = response.GrandTotalAvailable.Add(group.TotalAvailable)
 	}
 
 	for _, cat := range ungroupedCategories {
-		grandTotalBudget = grandTotalBudget.Add(cat.Budget)
-		grandTotalSpent = grandTotalSpent.Add(cat.TotalSpent)
+		response.GrandTotalBudget = response.GrandTotalBudget.Add(cat.Budget)
+		response.GrandTotalSpent = response.GrandTotalSpent.Add(cat.TotalSpent)
+		response.GrandTotalCarriedOver = response.GrandTotalCarriedOver.Add(cat.CarriedOver)
+		response.GrandTotalAssigned = response.GrandTotalAssigned.Add(cat.Assigned)
+		response.GrandTotalActivity = response.GrandTotalActivity.Add(cat.Activity)
+		response.GrandTotalAvailable = response.GrandTotalAvailable.Add(cat.Available)
 	}
 
-	grandTotalRemaining := grandTotalBudget.Sub(grandTotalSpent)
+	response.GrandTotalRemaining = response.GrandTotalBudget.Sub(response.GrandTotalSpent)
 
-	response := BudgetOverviewResponse{
-		StartDate:           startDate,
-		EndDate:             endDate,
-		Groups:              groups,
-		UngroupedCategories: ungroupedCategories,
-		GrandTotalBudget:    grandTotalBudget,
-		GrandTotalSpent:     grandTotalSpent,
-		GrandTotalRemaining: grandTotalRemaining,
-	}
 	respondWithJSON(w, http.StatusOK, response)
 }
+
+func budgetPeriodFromQuery(query url.Values, now time.Time) (time.Time, time.Time, error) {
+	if monthStr := query.Get("month"); monthStr != "" {
+		month, err := parseMonth(monthStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		return month, month.AddDate(0, 1, 0), nil
+	}
+
+	startStr := query.Get("start")
+	endStr := query.Get("end")
+	if startStr == "" && endStr == "" {
+		startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return startDate, startDate.AddDate(0, 1, 0), nil
+	}
+	if startStr == "" || endStr == "" {
+		return time.Time{}, time.Time{}, errors.New("start and end must be provided together")
+	}
+
+	startDate, err := time.Parse(time.DateOnly, startStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start %q, expected YYYY-MM-DD", startStr)
+	}
+	endDate, err := time.Parse(time.DateOnly, endStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid end %q, expected YYYY-MM-DD", endStr)
+	}
+	endDate = endDate.AddDate(0, 0, 1)
+	if !endDate.After(startDate) {
+		return time.Time{}, time.Time{}, errors.New("end must not be before start")
+	}
+
+	return startDate, endDate, nil
+}
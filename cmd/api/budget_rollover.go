@@ -0,0 +1,102 @@
+package main
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type categoryLedger struct {
+	defaultBudget decimal.Decimal
+	firstMonth    time.Time
+	rollover      bool
+	assigned      map[time.Time]decimal.Decimal
+	activity      map[time.Time]decimal.Decimal
+}
+
+type categoryBalance struct {
+	CarriedOver       decimal.Decimal
+	Assigned          decimal.Decimal
+	Activity          decimal.Decimal
+	Available         decimal.Decimal
+	TotalAssigned     decimal.Decimal
+	ResetOverspending decimal.Decimal
+}
+
+func monthOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func newCategoryLedger(defaultBudget decimal.Decimal, createdAt time.Time, rollover bool) *categoryLedger {
+	return &categoryLedger{
+		defaultBudget: defaultBudget,
+		firstMonth:    monthOf(createdAt),
+		rollover:      rollover,
+		assigned:      make(map[time.Time]decimal.Decimal),
+		activity:      make(map[time.Time]decimal.Decimal),
+	}
+}
+
+func (l *categoryLedger) setAssigned(month time.Time, amount decimal.Decimal) {
+	l.assigned[monthOf(month)] = amount
+}
+
+func (l *categoryLedger) addActivity(month time.Time, amount decimal.Decimal) {
+	month = monthOf(month)
+	l.activity[month] = l.activity[month].Add(amount)
+}
+
+func (l *categoryLedger) assignedFor(month time.Time) decimal.Decimal {
+	if amount, ok := l.assigned[month]; ok {
+		return amount
+	}
+	if month.Before(l.firstMonth) {
+		return decimal.Zero
+	}
+	return l.defaultBudget
+}
+
+// balance walks every month from the category's first month through
+// lastMonth, carrying the available amount forward. Overspending is only
+// carried when the category rolls it over; otherwise it resets to zero and
+// is reported in ResetOverspending so it can come out of ready to assign.
+func (l *categoryLedger) balance(startMonth, lastMonth time.Time) categoryBalance {
+	first := l.firstMonth
+	for month := range l.assigned {
+		if month.Before(first) {
+			first = month
+		}
+	}
+	for month := range l.activity {
+		if month.Before(first) {
+			first = month
+		}
+	}
+
+	result := categoryBalance{}
+	carried := decimal.Zero
+	for month := first; !month.After(lastMonth); month = month.AddDate(0, 1, 0) {
+		if month.Equal(startMonth) {
+			result.CarriedOver = carried
+		}
+
+		assigned := l.assignedFor(month)
+		activity := l.activity[month]
+		available := carried.Add(assigned).Add(activity)
+
+		if !month.Before(startMonth) {
+			result.Assigned = result.Assigned.Add(assigned)
+			result.Activity = result.Activity.Add(activity)
+		}
+		result.Available = available
+		result.TotalAssigned = result.TotalAssigned.Add(assigned)
+
+		carried = available
+		if carried.IsNegative() && !l.rollover && month.Before(lastMonth) {
+			result.ResetOverspending = result.ResetOverspending.Add(carried)
+			carried = decimal.Zero
+		}
+	}
+
+	return result
+}
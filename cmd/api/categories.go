@@ -12,21 +12,25 @@ import (
 )
 
 type Category struct {
-	ID           uuid.UUID       `json:"id"`
-	CategoryName string          `json:"category_name"`
-	CreatedAt    time.Time       `json:"created_at"`
-	UpdatedAt    time.Time       `json:"updated_at"`
-	Budget       decimal.Decimal `json:"budget"`
-	UserID       uuid.UUID       `json:"user_id"`
-	GroupID      uuid.UUID       `json:"group_id"`
-	GroupName    string          `json:"group_name"`
+	ID                   uuid.UUID       `json:"id"`
+	CategoryName         string          `json:"category_name"`
+	CreatedAt            time.Time       `json:"created_at"`
+	UpdatedAt            time.Time       `json:"updated_at"`
+	Budget               decimal.Decimal `json:"budget"`
+	UserID               uuid.UUID       `json:"user_id"`
+	GroupID              uuid.UUID       `json:"group_id"`
+	GroupName            string          `json:"group_name"`
+	RolloverOverspending bool            `json:"rollover_overspending"`
+	IsIncome             bool            `json:"is_income"`
 }
 
 func (cfg *apiConfig) createCategory(w http.ResponseWriter, req *http.Request) {
 	type parameters struct {
-		CategoryName string          `json:"category_name"`
-		Budget       decimal.Decimal `json:"budget"`
-		GroupID      uuid.UUID       `json:"group_id"`
+		CategoryName         string          `json:"category_name"`
+		Budget               decimal.Decimal `json:"budget"`
+		GroupID              uuid.UUID       `json:"group_id"`
+		RolloverOverspending *bool           `json:"rollover_overspending"`
+		IsIncome             *bool           `json:"is_income"`
 	}
 
 	type response struct {
@@ -61,14 +65,26 @@ func (cfg *apiConfig) createCategory(w http.ResponseWriter, req *http.Request) {
 		categoryGroup.Valid = true
 	}
 
+	rolloverOverspending := true
+	if params.RolloverOverspending != nil {
+		rolloverOverspending = *params.RolloverOverspending
+	}
+
+	isIncome := false
+	if params.IsIncome != nil {
+		isIncome = *params.IsIncome
+	}
+
 	dbCategory, err := cfg.db.CreateCategory(req.Context(), database.CreateCategoryParams{
-		ID:           uuid.New(),
-		CategoryName: params.CategoryName,
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
-		Budget:       params.Budget,
-		UserID:       userID,
-		GroupID:      categoryGroup,
+		ID:                   uuid.New(),
+		CategoryName:         params.CategoryName,
+		CreatedAt:            time.Now(),
+		UpdatedAt:            time.Now(),
+		Budget:               params.Budget,
+		UserID:               userID,
+		GroupID:              categoryGroup,
+		RolloverOverspending: rolloverOverspending,
+		IsIncome:             isIncome,
 	})
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Couldn't create group", err)
@@ -77,13 +93,15 @@ func (cfg *apiConfig) createCategory(w http.ResponseWriter, req *http.Request) {
 
 	respondWithJSON(w, http.StatusCreated, response{
 		Category: Category{
-			ID:           dbCategory.ID,
-			CategoryName: dbCategory.CategoryName,
-			CreatedAt:    dbCategory.CreatedAt,
-			UpdatedAt:    dbCategory.UpdatedAt,
-			Budget:       dbCategory.Budget,
-			UserID:       dbCategory.UserID,
-			GroupID:      dbCategory.GroupID.UUID,
+			ID:                   dbCategory.ID,
+			CategoryName:         dbCategory.CategoryName,
+			CreatedAt:            dbCategory.CreatedAt,
+			UpdatedAt:            dbCategory.UpdatedAt,
+			Budget:               dbCategory.Budget,
+			UserID:               dbCategory.UserID,
+			GroupID:              dbCategory.GroupID.UUID,
+			RolloverOverspending: dbCategory.RolloverOverspending,
+			IsIncome:             dbCategory.IsIncome,
 		},
 	})
 
@@ -106,14 +124,16 @@ func (cfg *apiConfig) getCategories(w http.ResponseWriter, req *http.Request) {
 	for _, category := range dbCategories {
 
 		categories = append(categories, Category{
-			ID:           category.ID,
-			CategoryName: category.CategoryName,
-			CreatedAt:    category.CreatedAt,
-			UpdatedAt:    category.UpdatedAt,
-			Budget:       category.Budget,
-			UserID:       category.UserID,
-			GroupID:      category.GroupID.UUID,
-			GroupName:    category.GroupName.String,
+			ID:                   category.ID,
+			CategoryName:         category.CategoryName,
+			CreatedAt:            category.CreatedAt,
+			UpdatedAt:            category.UpdatedAt,
+			Budget:               category.Budget,
+			UserID:               category.UserID,
+			GroupID:              category.GroupID.UUID,
+			GroupName:            category.GroupName.String,
+			RolloverOverspending: category.RolloverOverspending,
+			IsIncome:             category.IsIncome,
 		})
 	}
 
@@ -122,9 +142,11 @@ func (cfg *apiConfig) getCategories(w http.ResponseWriter, req *http.Request) {
 
 func (cfg *apiConfig) updateCategory(w http.ResponseWriter, req *http.Request) {
 	type parameters struct {
-		CategoryName string          `json:"category_name"`
-		Budget       decimal.Decimal `json:"budget"`
-		GroupID      uuid.UUID       `json:"group_id"`
+		CategoryName         string          `json:"category_name"`
+		Budget               decimal.Decimal `json:"budget"`
+		GroupID              uuid.UUID       `json:"group_id"`
+		RolloverOverspending *bool           `json:"rollover_overspending"`
+		IsIncome             *bool           `json:"is_income"`
 	}
 
 	type response struct {
@@ -169,6 +191,8 @@ func (cfg *apiConfig) updateCategory(w http.ResponseWriter, req *http.Request) {
 	updatedName := dbCategory.CategoryName
 	updatedBudget := dbCategory.Budget
 	updatedGroup := dbCategory.GroupID
+	updatedRollover := dbCategory.RolloverOverspending
+	updatedIncome := dbCategory.IsIncome
 
 	if params.CategoryName != updatedName {
 		updatedName = params.CategoryName
@@ -183,11 +207,21 @@ func (cfg *apiConfig) updateCategory(w http.ResponseWriter, req *http.Request) {
 		updatedGroup.Valid = (params.GroupID != uuid.Nil)
 	}
 
+	if params.RolloverOverspending != nil {
+		updatedRollover = *params.RolloverOverspending
+	}
+
+	if params.IsIncome != nil {
+		updatedIncome = *params.IsIncome
+	}
+
 	updatedCategory, err := cfg.db.UpdateCategory(req.Context(), database.UpdateCategoryParams{
-		ID:           dbCategory.ID,
-		CategoryName: updatedName,
-		Budget:       updatedBudget,
-		GroupID:      updatedGroup,
+		ID:                   dbCategory.ID,
+		CategoryName:         updatedName,
+		Budget:               updatedBudget,
+		GroupID:              updatedGroup,
+		RolloverOverspending: updatedRollover,
+		IsIncome:             updatedIncome,
 	})
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Couldn't update category", err)
@@ -202,13 +236,15 @@ func (cfg *apiConfig) updateCategory(w http.ResponseWriter, req *http.Request) {
 
 	respondWithJSON(w, http.StatusOK, response{
 		Category: Category{
-			ID:           updatedCategory.ID,
-			CategoryName: updatedCategory.CategoryName,
-			CreatedAt:    updatedCategory.CreatedAt,
-			UpdatedAt:    updatedCategory.UpdatedAt,
-			Budget:       updatedCategory.Budget,
-			UserID:       updatedCategory.UserID,
-			GroupID:      updatedCategory.GroupID.UUID,
+			ID:                   updatedCategory.ID,
+			CategoryName:         updatedCategory.CategoryName,
+			CreatedAt:            updatedCategory.CreatedAt,
+			UpdatedAt:            updatedCategory.UpdatedAt,
+			Budget:               updatedCategory.Budget,
+			UserID:               updatedCategory.UserID,
+			GroupID:              updatedCategory.GroupID.UUID,
+			RolloverOverspending: updatedCategory.RolloverOverspending,
+			IsIncome:             updatedCategory.IsIncome,
 		},
 	})
 
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jkk290/budget-tui/internal/database"
+	"github.com/shopspring/decimal"
+)
+
+type CategoryBudget struct {
+	CategoryID uuid.UUID       `json:"category_id"`
+	Month      time.Time       `json:"month"`
+	Assigned   decimal.Decimal `json:"assigned"`
+	IsDefault  bool            `json:"is_default"`
+}
+
+func parseMonth(monthStr string) (time.Time, error) {
+	month, err := time.Parse("2006-01", monthStr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid month %q, expected YYYY-MM", monthStr)
+	}
+	return month, nil
+}
+
+func (cfg *apiConfig) getCategoryBudgets(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	categoryIDString := req.PathValue("categoryID")
+	categoryID, err := uuid.Parse(categoryIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid category ID", err)
+		return
+	}
+
+	dbCategory, err := cfg.db.GetCategoryByID(req.Context(), categoryID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get category", err)
+		return
+	}
+	if dbCategory.UserID != userID {
+		respondWithError(w, http.StatusForbidden, "Can't view category budgets", errors.New("unauthorized"))
+		return
+	}
+
+	dbBudgets, err := cfg.db.GetCategoryBudgets(req.Context(), dbCategory.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get category budgets", err)
+		return
+	}
+
+	budgets := []CategoryBudget{}
+	for _, budget := range dbBudgets {
+		budgets = append(budgets, CategoryBudget{
+			CategoryID: budget.CategoryID,
+			Month:      budget.Month,
+			Assigned:   budget.Assigned,
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, budgets)
+}
+
+func (cfg *apiConfig) getCategoryBudgetForMonth(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	categoryIDString := req.PathValue("categoryID")
+	categoryID, err := uuid.Parse(categoryIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid category ID", err)
+		return
+	}
+
+	month, err := parseMonth(req.PathValue("month"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	dbCategory, err := cfg.db.GetCategoryByID(req.Context(), categoryID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get category", err)
+		return
+	}
+	if dbCategory.UserID != userID {
+		respondWithError(w, http.StatusForbidden, "Can't view category budget", errors.New("unauthorized"))
+		return
+	}
+
+	dbBudget, err := cfg.db.GetCategoryBudgetForMonth(req.Context(), database.GetCategoryBudgetForMonthParams{
+		CategoryID: dbCategory.ID,
+		Month:      month,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		respondWithJSON(w, http.StatusOK, CategoryBudget{
+			CategoryID: dbCategory.ID,
+			Month:      month,
+			Assigned:   dbCategory.Budget,
+			IsDefault:  true,
+		})
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get category budget", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, CategoryBudget{
+		CategoryID: dbBudget.CategoryID,
+		Month:      dbBudget.Month,
+		Assigned:   dbBudget.Assigned,
+	})
+}
+
+func (cfg *apiConfig) setCategoryBudget(w http.ResponseWriter, req *http.Request) {
+	type parameters struct {
+		Assigned decimal.Decimal `json:"assigned"`
+	}
+
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	categoryIDString := req.PathValue("categoryID")
+	categoryID, err := uuid.Parse(categoryIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid category ID", err)
+		return
+	}
+
+	month, err := parseMonth(req.PathValue("month"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	dbCategory, err := cfg.db.GetCategoryByID(req.Context(), categoryID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get category", err)
+		return
+	}
+	if dbCategory.UserID != userID {
+		respondWithError(w, http.StatusForbidden, "Can't update category budget", errors.New("unauthorized"))
+		return
+	}
+
+	decoder := json.NewDecoder(req.Body)
+	params := parameters{}
+	if err := decoder.Decode(&params); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't decode parameters", err)
+		return
+	}
+
+	if dbCategory.IsIncome {
+		respondWithError(w, http.StatusBadRequest, "Can't assign a budget to an income category", errors.New("invalid parameters"))
+		return
+	}
+
+	if params.Assigned.IsNegative() {
+		respondWithError(w, http.StatusBadRequest, "Assigned amount can't be negative", errors.New("invalid parameters"))
+		return
+	}
+
+	dbBudget, err := cfg.db.SetCategoryBudget(req.Context(), database.SetCategoryBudgetParams{
+		CategoryID: dbCategory.ID,
+		Month:      month,
+		Assigned:   params.Assigned,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't set category budget", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, CategoryBudget{
+		CategoryID: dbBudget.CategoryID,
+		Month:      dbBudget.Month,
+		Assigned:   dbBudget.Assigned,
+	})
+}
+
+func (cfg *apiConfig) deleteCategoryBudget(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	categoryIDString := req.PathValue("categoryID")
+	categoryID, err := uuid.Parse(categoryIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid category ID", err)
+		return
+	}
+
+	month, err := parseMonth(req.PathValue("month"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	dbCategory, err := cfg.db.GetCategoryByID(req.Context(), categoryID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get category", err)
+		return
+	}
+	if dbCategory.UserID != userID {
+		respondWithError(w, http.StatusForbidden, "Can't delete category budget", errors.New("unauthorized"))
+		return
+	}
+
+	if err := cfg.db.DeleteCategoryBudget(req.Context(), database.DeleteCategoryBudgetParams{
+		CategoryID: dbCategory.ID,
+		Month:      month,
+	}); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't delete category budget", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/jkk290/budget-tui/internal/database"
+	"github.com/jkk290/budget-tui/internal/journal"
+)
+
+// runCommand runs one of the subcommands the server binary also offers,
+// for working with the database directly instead of over HTTP.
+func runCommand(args []string) error {
+	switch args[0] {
+	case "export-journal":
+		return runExportJournal(args[1:])
+	}
+	return fmt.Errorf("unknown command %q, expected export-journal", args[0])
+}
+
+// openCommandConfig connects to DB_URL for a subcommand.
+func openCommandConfig() (*apiConfig, func(), error) {
+	dbURL := os.Getenv("DB_URL")
+	if dbURL == "" {
+		return nil, nil, errors.New("DB_URL not set in .env")
+	}
+
+	db, err := sql.Open("postgres", dbURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cfg := &apiConfig{
+		db:     database.New(db),
+		dbConn: db,
+	}
+	return cfg, func() { db.Close() }, nil
+}
+
+func runExportJournal(args []string) error {
+	flags := flag.NewFlagSet("export-journal", flag.ContinueOnError)
+	username := flags.String("user", "", "username whose data to export (required)")
+	formatName := flags.String("format", string(journal.FormatLedger), "ledger, hledger or beancount")
+	output := flags.String("o", "", "file to write the journal to (default stdout)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *username == "" {
+		return errors.New("export-journal: -user is required")
+	}
+	format, err := journal.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+
+	cfg, closeDB, err := openCommandConfig()
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	ctx := context.Background()
+	user, err := cfg.db.GetUserByUsername(ctx, *username)
+	if err != nil {
+		return fmt.Errorf("couldn't find user %q: %w", *username, err)
+	}
+
+	out := os.Stdout
+	if *output != "" {
+		out, err = os.Create(*output)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = cfg.writeJournal(ctx, out, user.ID, format)
+	if out != os.Stdout {
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
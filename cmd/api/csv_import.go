@@ -0,0 +1,276 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jkk290/budget-tui/internal/csvimport"
+	"github.com/jkk290/budget-tui/internal/database"
+	"github.com/jkk290/budget-tui/internal/match"
+)
+
+const maxImportFileSize = 10 << 20
+
+type ImportMatch struct {
+	Match                match.Kind `json:"match"`
+	MatchedTransactionID uuid.UUID  `json:"matched_transaction_id"`
+}
+
+type CSVImportRow struct {
+	csvimport.Row
+	ImportMatch
+}
+
+type CSVImportResult struct {
+	Committed bool `json:"committed"`
+	ImportCounts
+	Mapping csvimport.Mapping `json:"mapping"`
+	Rows    []CSVImportRow    `json:"rows"`
+}
+
+func mappingFromDB(dbMapping database.CsvImportMapping) csvimport.Mapping {
+	return csvimport.Mapping{
+		SkipRows:          int(dbMapping.SkipRows),
+		DateColumn:        dbMapping.DateColumn,
+		DateFormat:        dbMapping.DateFormat,
+		AmountColumn:      dbMapping.AmountColumn,
+		DebitColumn:       dbMapping.DebitColumn,
+		CreditColumn:      dbMapping.CreditColumn,
+		DescriptionColumn: dbMapping.DescriptionColumn,
+		InvertAmounts:     dbMapping.InvertAmounts,
+	}
+}
+
+func (cfg *apiConfig) saveCSVMapping(w http.ResponseWriter, req *http.Request, accountID uuid.UUID, mapping csvimport.Mapping) (csvimport.Mapping, bool) {
+	if mapping.DateFormat == "" {
+		mapping.DateFormat = csvimport.DefaultDateFormat
+	}
+
+	dbMapping, err := cfg.db.SaveCSVImportMapping(req.Context(), database.SaveCSVImportMappingParams{
+		AccountID:         accountID,
+		SkipRows:          int32(mapping.SkipRows),
+		DateColumn:        mapping.DateColumn,
+		DateFormat:        mapping.DateFormat,
+		AmountColumn:      mapping.AmountColumn,
+		DebitColumn:       mapping.DebitColumn,
+		CreditColumn:      mapping.CreditColumn,
+		DescriptionColumn: mapping.DescriptionColumn,
+		InvertAmounts:     mapping.InvertAmounts,
+		CreatedAt:         time.Now(),
+		UpdatedAt:         time.Now(),
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't save CSV mapping", err)
+		return csvimport.Mapping{}, false
+	}
+
+	return mappingFromDB(dbMapping), true
+}
+
+func (cfg *apiConfig) getCSVImportMapping(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	dbAccount, ok := cfg.getOwnedAccount(w, req, userID)
+	if !ok {
+		return
+	}
+
+	dbMapping, err := cfg.db.GetCSVImportMapping(req.Context(), dbAccount.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		respondWithError(w, http.StatusNotFound, "No CSV mapping saved for account", err)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get CSV mapping", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, mappingFromDB(dbMapping))
+}
+
+func (cfg *apiConfig) setCSVImportMapping(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	dbAccount, ok := cfg.getOwnedAccount(w, req, userID)
+	if !ok {
+		return
+	}
+
+	decoder := json.NewDecoder(req.Body)
+	mapping := csvimport.Mapping{}
+	if err := decoder.Decode(&mapping); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't decode parameters", err)
+		return
+	}
+
+	if err := mapping.Validate(); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	saved, ok := cfg.saveCSVMapping(w, req, dbAccount.ID, mapping)
+	if !ok {
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, saved)
+}
+
+// importCSV parses an uploaded statement with the request's mapping, or the
+// account's saved one, and only writes transactions when commit=true so the
+// same call doubles as a preview.
+func (cfg *apiConfig) importCSV(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	dbAccount, ok := cfg.getOwnedAccount(w, req, userID)
+	if !ok {
+		return
+	}
+
+	req.Body = http.MaxBytesReader(w, req.Body, maxImportFileSize)
+	if err := req.ParseMultipartForm(maxImportFileSize); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Couldn't parse multipart form", err)
+		return
+	}
+
+	file, _, err := req.FormFile("file")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Missing CSV file", err)
+		return
+	}
+	defer file.Close()
+
+	var mapping csvimport.Mapping
+	if mappingString := req.FormValue("mapping"); mappingString != "" {
+		if err := json.Unmarshal([]byte(mappingString), &mapping); err != nil {
+			respondWithError(w, http.StatusBadRequest, "Couldn't decode mapping", err)
+			return
+		}
+	} else {
+		dbMapping, err := cfg.db.GetCSVImportMapping(req.Context(), dbAccount.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			respondWithError(w, http.StatusBadRequest, "Missing mapping and none saved for account", err)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Couldn't get CSV mapping", err)
+			return
+		}
+		mapping = mappingFromDB(dbMapping)
+	}
+
+	rows, err := csvimport.Parse(file, mapping)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	if req.FormValue("save_mapping") == "true" {
+		if mapping, ok = cfg.saveCSVMapping(w, req, dbAccount.ID, mapping); !ok {
+			return
+		}
+	}
+
+	windowDays, err := cfg.matchWindowDays(req)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	var dates []time.Time
+	for _, row := range rows {
+		if row.Error == "" {
+			dates = append(dates, row.Date)
+		}
+	}
+	matcher, err := cfg.newMatcher(req.Context(), dbAccount.ID, dates, windowDays)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get existing transactions", err)
+		return
+	}
+
+	result := CSVImportResult{
+		Mapping: mapping,
+		Rows:    []CSVImportRow{},
+	}
+	matches := make([]match.Result, len(rows))
+	for i, row := range rows {
+		importRow := CSVImportRow{Row: row}
+		if row.Error != "" {
+			result.Skipped++
+		} else {
+			matches[i] = matcher.Match(row.Date, row.Amount)
+			importRow.ImportMatch = ImportMatch{
+				Match:                matches[i].Kind,
+				MatchedTransactionID: matches[i].TransactionID,
+			}
+		}
+		result.Rows = append(result.Rows, importRow)
+	}
+
+	if req.FormValue("commit") != "true" {
+		respondWithJSON(w, http.StatusOK, result)
+		return
+	}
+
+	engine, err := cfg.loadRules(req.Context(), userID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't load rules", err)
+		return
+	}
+
+	tx, err := cfg.dbConn.BeginTx(req.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't import transactions", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	for i, row := range rows {
+		if row.Error != "" {
+			continue
+		}
+		entry := importEntry{
+			Params: database.AddTransactionParams{
+				ID:            uuid.New(),
+				Amount:        row.Amount,
+				TxDescription: row.Description,
+				TxDate:        row.Date,
+				CreatedAt:     time.Now(),
+				UpdatedAt:     time.Now(),
+				Posted:        true,
+				AccountID:     dbAccount.ID,
+			},
+		}
+		if err := commitImportEntry(req.Context(), qtx, engine, userID, dbAccount.ID, entry, matches[i]); err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Couldn't import transactions", err)
+			return
+		}
+		result.countMatch(matches[i].Kind)
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't import transactions", err)
+		return
+	}
+
+	result.Committed = true
+	respondWithJSON(w, http.StatusCreated, result)
+}
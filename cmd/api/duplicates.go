@@ -0,0 +1,295 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jkk290/budget-tui/internal/database"
+	"github.com/jkk290/budget-tui/internal/match"
+	"github.com/jkk290/budget-tui/internal/rules"
+	"github.com/shopspring/decimal"
+)
+
+type ImportDuplicate struct {
+	ID                   uuid.UUID       `json:"id"`
+	Amount               decimal.Decimal `json:"amount"`
+	TxDescription        string          `json:"tx_description"`
+	TxDate               time.Time       `json:"tx_date"`
+	FITID                string          `json:"fitid"`
+	CreatedAt            time.Time       `json:"created_at"`
+	AccountID            uuid.UUID       `json:"account_id"`
+	AccountName          string          `json:"account_name"`
+	MatchedTransactionID uuid.UUID       `json:"matched_transaction_id"`
+	MatchedDescription   string          `json:"matched_description"`
+	MatchedDate          *time.Time      `json:"matched_date"`
+	MatchedPosted        bool            `json:"matched_posted"`
+}
+
+type ImportCounts struct {
+	Imported   int `json:"imported"`
+	Matched    int `json:"matched"`
+	Duplicates int `json:"duplicates"`
+	Skipped    int `json:"skipped"`
+}
+
+func (c *ImportCounts) countMatch(kind match.Kind) {
+	switch kind {
+	case match.Auto:
+		c.Matched++
+	case match.Duplicate:
+		c.Duplicates++
+	default:
+		c.Imported++
+	}
+}
+
+// importEntry is one incoming statement line, whatever file format it came
+// from. FITID is empty for formats without bank transaction IDs.
+type importEntry struct {
+	Params database.AddTransactionParams
+	FITID  string
+}
+
+// matchWindowDays reads the optional match_window_days form value, falling
+// back to the server's MATCH_WINDOW_DAYS setting.
+func (cfg *apiConfig) matchWindowDays(req *http.Request) (int, error) {
+	windowString := req.FormValue("match_window_days")
+	if windowString == "" {
+		return cfg.matchWindow, nil
+	}
+	window, err := strconv.Atoi(windowString)
+	if err != nil || window < 0 || window > 31 {
+		return 0, errors.New("match_window_days must be between 0 and 31")
+	}
+	return window, nil
+}
+
+func (cfg *apiConfig) newMatcher(ctx context.Context, accountID uuid.UUID, dates []time.Time, windowDays int) (*match.Matcher, error) {
+	if len(dates) == 0 {
+		return match.New(nil, windowDays), nil
+	}
+
+	first, last := dates[0], dates[0]
+	for _, date := range dates {
+		if date.Before(first) {
+			first = date
+		}
+		if date.After(last) {
+			last = date
+		}
+	}
+	start, end := match.Window(first, last, windowDays)
+
+	dbTransactions, err := cfg.db.GetTransactionsByAccountBetween(ctx, database.GetTransactionsByAccountBetweenParams{
+		AccountID: accountID,
+		StartDate: start,
+		EndDate:   end,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]match.Candidate, len(dbTransactions))
+	for i, transaction := range dbTransactions {
+		candidates[i] = match.Candidate{
+			ID:     transaction.ID,
+			Date:   transaction.TxDate,
+			Amount: transaction.Amount,
+			Posted: transaction.Posted,
+		}
+	}
+	return match.New(candidates, windowDays), nil
+}
+
+// commitImportEntry writes one incoming line according to its match: an
+// auto-match posts the existing transaction, a probable duplicate is held
+// for review, and anything else becomes a new posted transaction after the
+// user's rules have run over it.
+func commitImportEntry(ctx context.Context, qtx *database.Queries, engine *rules.Engine, userID, accountID uuid.UUID, entry importEntry, result match.Result) error {
+	switch result.Kind {
+	case match.Auto:
+		if err := qtx.SetTransactionPosted(ctx, result.TransactionID); err != nil {
+			return err
+		}
+		return recordFITID(ctx, qtx, result.TransactionID, accountID, entry.FITID)
+
+	case match.Duplicate:
+		return qtx.AddImportDuplicate(ctx, database.AddImportDuplicateParams{
+			ID:                   uuid.New(),
+			Amount:               entry.Params.Amount,
+			TxDescription:        entry.Params.TxDescription,
+			TxDate:               entry.Params.TxDate,
+			Fitid:                sql.NullString{String: entry.FITID, Valid: entry.FITID != ""},
+			CreatedAt:            time.Now(),
+			UserID:               userID,
+			AccountID:            accountID,
+			MatchedTransactionID: uuid.NullUUID{UUID: result.TransactionID, Valid: true},
+		})
+	}
+
+	applyRules(engine, &entry.Params)
+	dbTransaction, err := qtx.AddTransaction(ctx, entry.Params)
+	if err != nil {
+		return err
+	}
+	return recordFITID(ctx, qtx, dbTransaction.ID, accountID, entry.FITID)
+}
+
+func recordFITID(ctx context.Context, qtx *database.Queries, transactionID, accountID uuid.UUID, fitID string) error {
+	if fitID == "" {
+		return nil
+	}
+	return qtx.AddTransactionImport(ctx, database.AddTransactionImportParams{
+		TransactionID: transactionID,
+		AccountID:     accountID,
+		Fitid:         fitID,
+		CreatedAt:     time.Now(),
+	})
+}
+
+func (cfg *apiConfig) getImportDuplicates(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	dbDuplicates, err := cfg.db.GetUserImportDuplicates(req.Context(), userID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get duplicates", err)
+		return
+	}
+
+	duplicates := []ImportDuplicate{}
+	for _, d := range dbDuplicates {
+		duplicate := ImportDuplicate{
+			ID:                   d.ID,
+			Amount:               d.Amount,
+			TxDescription:        d.TxDescription,
+			TxDate:               d.TxDate,
+			FITID:                d.Fitid.String,
+			CreatedAt:            d.CreatedAt,
+			AccountID:            d.AccountID,
+			AccountName:          d.AccountName,
+			MatchedTransactionID: d.MatchedTransactionID.UUID,
+			MatchedDescription:   d.MatchedDescription.String,
+			MatchedDate:          nullTimePtr(d.MatchedDate),
+			MatchedPosted:        d.MatchedPosted.Bool,
+		}
+		duplicates = append(duplicates, duplicate)
+	}
+
+	respondWithJSON(w, http.StatusOK, duplicates)
+}
+
+func (cfg *apiConfig) getOwnedDuplicate(w http.ResponseWriter, req *http.Request, userID uuid.UUID) (database.ImportDuplicate, bool) {
+	duplicateID, err := uuid.Parse(req.PathValue("duplicateID"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid duplicate ID", err)
+		return database.ImportDuplicate{}, false
+	}
+
+	dbDuplicate, err := cfg.db.GetImportDuplicateByID(req.Context(), duplicateID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Couldn't get duplicate", err)
+		return database.ImportDuplicate{}, false
+	}
+	if dbDuplicate.UserID != userID {
+		respondWithError(w, http.StatusForbidden, "Can't access duplicate", errors.New("unauthorized"))
+		return database.ImportDuplicate{}, false
+	}
+
+	return dbDuplicate, true
+}
+
+// matchImportDuplicate confirms the incoming line is the same as the
+// existing transaction: the existing one is posted and the line dropped.
+func (cfg *apiConfig) matchImportDuplicate(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	dbDuplicate, ok := cfg.getOwnedDuplicate(w, req, userID)
+	if !ok {
+		return
+	}
+	if !dbDuplicate.MatchedTransactionID.Valid {
+		respondWithError(w, http.StatusBadRequest, "Matched transaction no longer exists", errors.New("invalid parameters"))
+		return
+	}
+
+	cfg.resolveImportDuplicate(w, req, dbDuplicate, match.Result{
+		Kind:          match.Auto,
+		TransactionID: dbDuplicate.MatchedTransactionID.UUID,
+	})
+}
+
+// keepImportDuplicate confirms the incoming line is a separate transaction
+// and imports it.
+func (cfg *apiConfig) keepImportDuplicate(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	dbDuplicate, ok := cfg.getOwnedDuplicate(w, req, userID)
+	if !ok {
+		return
+	}
+
+	cfg.resolveImportDuplicate(w, req, dbDuplicate, match.Result{Kind: match.None})
+}
+
+func (cfg *apiConfig) resolveImportDuplicate(w http.ResponseWriter, req *http.Request, dbDuplicate database.ImportDuplicate, result match.Result) {
+	engine, err := cfg.loadRules(req.Context(), dbDuplicate.UserID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't load rules", err)
+		return
+	}
+
+	tx, err := cfg.dbConn.BeginTx(req.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't resolve duplicate", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	entry := importEntry{
+		Params: database.AddTransactionParams{
+			ID:            uuid.New(),
+			Amount:        dbDuplicate.Amount,
+			TxDescription: dbDuplicate.TxDescription,
+			TxDate:        dbDuplicate.TxDate,
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
+			Posted:        true,
+			AccountID:     dbDuplicate.AccountID,
+		},
+		FITID: dbDuplicate.Fitid.String,
+	}
+	if err := commitImportEntry(req.Context(), qtx, engine, dbDuplicate.UserID, dbDuplicate.AccountID, entry, result); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't resolve duplicate", err)
+		return
+	}
+
+	if err := qtx.DeleteImportDuplicate(req.Context(), dbDuplicate.ID); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't resolve duplicate", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't resolve duplicate", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jkk290/budget-tui/internal/currency"
+	"github.com/jkk290/budget-tui/internal/database"
+	"github.com/shopspring/decimal"
+)
+
+// ExchangeRate is one unit of FromCurrency expressed in ToCurrency. It
+// applies from RateDate until the next rate for the same pair.
+type ExchangeRate struct {
+	ID           uuid.UUID       `json:"id"`
+	FromCurrency string          `json:"from_currency"`
+	ToCurrency   string          `json:"to_currency"`
+	Rate         decimal.Decimal `json:"rate"`
+	RateDate     time.Time       `json:"rate_date"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+func exchangeRateFromDB(dbRate database.ExchangeRate) ExchangeRate {
+	return ExchangeRate{
+		ID:           dbRate.ID,
+		FromCurrency: dbRate.FromCurrency,
+		ToCurrency:   dbRate.ToCurrency,
+		Rate:         dbRate.Rate,
+		RateDate:     dbRate.RateDate,
+		CreatedAt:    dbRate.CreatedAt,
+		UpdatedAt:    dbRate.UpdatedAt,
+	}
+}
+
+// rateTable converts amounts into a user's base currency at the rates in
+// effect on one day.
+type rateTable struct {
+	base  string
+	rates map[string]decimal.Decimal
+}
+
+func loadRateTable(ctx context.Context, q *database.Queries, userID uuid.UUID, base string, asOf time.Time) (rateTable, error) {
+	dbRates, err := q.GetLatestExchangeRates(ctx, database.GetLatestExchangeRatesParams{
+		UserID:     userID,
+		ToCurrency: base,
+		AsOf:       asOf,
+	})
+	if err != nil {
+		return rateTable{}, err
+	}
+
+	table := rateTable{
+		base:  base,
+		rates: make(map[string]decimal.Decimal, len(dbRates)),
+	}
+	for _, dbRate := range dbRates {
+		table.rates[dbRate.FromCurrency] = dbRate.Rate
+	}
+	return table, nil
+}
+
+// convert reports false when there is no rate for the currency yet.
+func (t rateTable) convert(amount decimal.Decimal, currencyCode string) (decimal.Decimal, bool) {
+	if currencyCode == t.base {
+		return amount, true
+	}
+	rate, ok := t.rates[currencyCode]
+	if !ok {
+		return decimal.Zero, false
+	}
+	return amount.Mul(rate).Round(2), true
+}
+
+func saveExchangeRates(ctx context.Context, q *database.Queries, userID uuid.UUID, rates []currency.Rate) ([]ExchangeRate, error) {
+	saved := make([]ExchangeRate, 0, len(rates))
+	for _, rate := range rates {
+		dbRate, err := q.SaveExchangeRate(ctx, database.SaveExchangeRateParams{
+			ID:           uuid.New(),
+			FromCurrency: rate.From,
+			ToCurrency:   rate.To,
+			Rate:         rate.Rate,
+			RateDate:     rate.Date,
+			CreatedAt:    time.Now(),
+			UpdatedAt:    time.Now(),
+			UserID:       userID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		saved = append(saved, exchangeRateFromDB(dbRate))
+	}
+	return saved, nil
+}
+
+func (cfg *apiConfig) getExchangeRates(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	dbRates, err := cfg.db.GetExchangeRatesByUser(req.Context(), userID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get exchange rates", err)
+		return
+	}
+
+	rates := []ExchangeRate{}
+	for _, dbRate := range dbRates {
+		rates = append(rates, exchangeRateFromDB(dbRate))
+	}
+
+	respondWithJSON(w, http.StatusOK, rates)
+}
+
+// createExchangeRate saves one rate. A rate for the same pair and day
+// replaces the old one, so correcting a typo is just posting it again.
+func (cfg *apiConfig) createExchangeRate(w http.ResponseWriter, req *http.Request) {
+	type parameters struct {
+		FromCurrency string          `json:"from_currency"`
+		ToCurrency   string          `json:"to_currency"`
+		Rate         decimal.Decimal `json:"rate"`
+		RateDate     string          `json:"rate_date"`
+	}
+
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	decoder := json.NewDecoder(req.Body)
+	params := parameters{}
+	if err := decoder.Decode(&params); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't decode parameters", err)
+		return
+	}
+
+	rateDate, err := time.Parse(time.DateOnly, params.RateDate)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("invalid rate_date %q, expected YYYY-MM-DD", params.RateDate), err)
+		return
+	}
+
+	if params.ToCurrency == "" {
+		dbUser, err := cfg.db.GetUserByID(req.Context(), userID)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Couldn't get user", err)
+			return
+		}
+		params.ToCurrency = dbUser.BaseCurrency
+	}
+
+	rate := currency.Rate{
+		Date: rateDate,
+		From: params.FromCurrency,
+		To:   params.ToCurrency,
+		Rate: params.Rate,
+	}
+	if err := rate.Check(); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	saved, err := saveExchangeRates(req.Context(), cfg.db, userID, []currency.Rate{rate})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't save exchange rate", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, saved[0])
+}
+
+// importExchangeRates loads a CSV of rates in one database transaction.
+// Rates for a pair and day that already exist are replaced.
+func (cfg *apiConfig) importExchangeRates(w http.ResponseWriter, req *http.Request) {
+	type response struct {
+		Imported int `json:"imported"`
+	}
+
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	req.Body = http.MaxBytesReader(w, req.Body, maxImportFileSize)
+	if err := req.ParseMultipartForm(maxImportFileSize); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Couldn't parse multipart form", err)
+		return
+	}
+
+	file, _, err := req.FormFile("file")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Missing CSV file", err)
+		return
+	}
+	defer file.Close()
+
+	rates, err := currency.ParseRates(file)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	tx, err := cfg.dbConn.BeginTx(req.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't import exchange rates", err)
+		return
+	}
+	defer tx.Rollback()
+
+	if _, err := saveExchangeRates(req.Context(), cfg.db.WithTx(tx), userID, rates); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't import exchange rates", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't import exchange rates", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, response{
+		Imported: len(rates),
+	})
+}
+
+func (cfg *apiConfig) deleteExchangeRate(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	rateID, err := uuid.Parse(req.PathValue("rateID"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid exchange rate ID", err)
+		return
+	}
+
+	dbRate, err := cfg.db.GetExchangeRateByID(req.Context(), rateID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Couldn't get exchange rate", err)
+		return
+	}
+	if dbRate.UserID != userID {
+		respondWithError(w, http.StatusForbidden, "Can't delete exchange rate", errors.New("unauthorized"))
+		return
+	}
+
+	if err := cfg.db.DeleteExchangeRate(req.Context(), dbRate.ID); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't delete exchange rate", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,350 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jkk290/budget-tui/internal/database"
+	"github.com/shopspring/decimal"
+)
+
+// exportBatchSize is how many transactions are read from the database at a
+// time while streaming an export.
+const exportBatchSize = 500
+
+type ExportTransaction struct {
+	ID            uuid.UUID          `json:"id"`
+	TxDate        time.Time          `json:"tx_date"`
+	TxDescription string             `json:"tx_description"`
+	Memo          string             `json:"memo,omitempty"`
+	Amount        decimal.Decimal    `json:"amount"`
+	Posted        bool               `json:"posted"`
+	AccountID     uuid.UUID          `json:"account_id"`
+	AccountName   string             `json:"account_name"`
+	CategoryID    uuid.UUID          `json:"category_id"`
+	CategoryName  string             `json:"category_name"`
+	PayeeID       uuid.UUID          `json:"payee_id"`
+	PayeeName     string             `json:"payee_name"`
+	TransferID    uuid.UUID          `json:"transfer_id"`
+	Splits        []TransactionSplit `json:"splits,omitempty"`
+}
+
+type exportFilter struct {
+	StartDate  sql.NullTime
+	EndDate    sql.NullTime
+	AccountID  uuid.NullUUID
+	CategoryID uuid.NullUUID
+}
+
+// dateRangeFromQuery reads the optional start and end query parameters.
+// Dates are YYYY-MM-DD and end is inclusive, so the returned end is the
+// day after it.
+func dateRangeFromQuery(query url.Values) (sql.NullTime, sql.NullTime, error) {
+	var start, end sql.NullTime
+
+	if startStr := query.Get("start"); startStr != "" {
+		startDate, err := time.Parse(time.DateOnly, startStr)
+		if err != nil {
+			return sql.NullTime{}, sql.NullTime{}, fmt.Errorf("invalid start %q, expected YYYY-MM-DD", startStr)
+		}
+		start = sql.NullTime{Time: startDate, Valid: true}
+	}
+
+	if endStr := query.Get("end"); endStr != "" {
+		endDate, err := time.Parse(time.DateOnly, endStr)
+		if err != nil {
+			return sql.NullTime{}, sql.NullTime{}, fmt.Errorf("invalid end %q, expected YYYY-MM-DD", endStr)
+		}
+		end = sql.NullTime{Time: endDate.AddDate(0, 0, 1), Valid: true}
+	}
+
+	if start.Valid && end.Valid && !end.Time.After(start.Time) {
+		return sql.NullTime{}, sql.NullTime{}, errors.New("end must not be before start")
+	}
+
+	return start, end, nil
+}
+
+// exportFilterFromQuery reads the optional start, end, account_id and
+// category_id query parameters.
+func exportFilterFromQuery(query url.Values) (exportFilter, error) {
+	filter := exportFilter{}
+
+	startDate, endDate, err := dateRangeFromQuery(query)
+	if err != nil {
+		return exportFilter{}, err
+	}
+	filter.StartDate = startDate
+	filter.EndDate = endDate
+
+	if accountStr := query.Get("account_id"); accountStr != "" {
+		accountID, err := uuid.Parse(accountStr)
+		if err != nil {
+			return exportFilter{}, errors.New("invalid account_id")
+		}
+		filter.AccountID = uuid.NullUUID{UUID: accountID, Valid: true}
+	}
+
+	if categoryStr := query.Get("category_id"); categoryStr != "" {
+		categoryID, err := uuid.Parse(categoryStr)
+		if err != nil {
+			return exportFilter{}, errors.New("invalid category_id")
+		}
+		filter.CategoryID = uuid.NullUUID{UUID: categoryID, Valid: true}
+	}
+
+	return filter, nil
+}
+
+// checkExportFilter makes sure the account and category being filtered on
+// belong to the user, responding with an error if not.
+func (cfg *apiConfig) checkExportFilter(w http.ResponseWriter, req *http.Request, userID uuid.UUID, filter exportFilter) bool {
+	if filter.AccountID.Valid {
+		dbAccount, err := cfg.db.GetAccountByID(req.Context(), filter.AccountID.UUID)
+		if err != nil {
+			respondWithError(w, http.StatusNotFound, "Couldn't get account", err)
+			return false
+		}
+		if dbAccount.UserID != userID {
+			respondWithError(w, http.StatusForbidden, "Can't access account", errors.New("unauthorized"))
+			return false
+		}
+	}
+
+	if filter.CategoryID.Valid {
+		dbCategory, err := cfg.db.GetCategoryByID(req.Context(), filter.CategoryID.UUID)
+		if err != nil {
+			respondWithError(w, http.StatusNotFound, "Couldn't get category", err)
+			return false
+		}
+		if dbCategory.UserID != userID {
+			respondWithError(w, http.StatusForbidden, "Can't access category", errors.New("unauthorized"))
+			return false
+		}
+	}
+
+	return true
+}
+
+// eachExportTransaction calls fn for every transaction matching the filter,
+// oldest first, reading them in batches so exports never hold the whole
+// history in memory.
+func (cfg *apiConfig) eachExportTransaction(ctx context.Context, userID uuid.UUID, filter exportFilter, fn func(ExportTransaction) error) error {
+	params := database.GetUserTransactionsExportPageParams{
+		UserID:     userID,
+		StartDate:  filter.StartDate,
+		EndDate:    filter.EndDate,
+		AccountID:  filter.AccountID,
+		CategoryID: filter.CategoryID,
+		RowLimit:   exportBatchSize,
+	}
+
+	for {
+		dbTransactions, err := cfg.db.GetUserTransactionsExportPage(ctx, params)
+		if err != nil {
+			return err
+		}
+		if len(dbTransactions) == 0 {
+			return nil
+		}
+
+		transactionIDs := make([]uuid.UUID, len(dbTransactions))
+		for i, tx := range dbTransactions {
+			transactionIDs[i] = tx.ID
+		}
+
+		dbSplits, err := cfg.db.GetTransactionSplitsForTransactions(ctx, transactionIDs)
+		if err != nil {
+			return err
+		}
+
+		splitsByTransaction := make(map[uuid.UUID][]TransactionSplit)
+		for _, split := range dbSplits {
+			splitsByTransaction[split.TransactionID] = append(splitsByTransaction[split.TransactionID], TransactionSplit{
+				ID:           split.ID,
+				CategoryID:   split.CategoryID.UUID,
+				CategoryName: split.CategoryName.String,
+				Amount:       split.Amount,
+				Memo:         split.Memo,
+			})
+		}
+
+		for _, tx := range dbTransactions {
+			err := fn(ExportTransaction{
+				ID:            tx.ID,
+				TxDate:        tx.TxDate,
+				TxDescription: tx.TxDescription,
+				Memo:          tx.Memo,
+				Amount:        tx.Amount,
+				Posted:        tx.Posted,
+				AccountID:     tx.AccountID,
+				AccountName:   tx.AccountName,
+				CategoryID:    tx.CategoryID.UUID,
+				CategoryName:  tx.CategoryName.String,
+				PayeeID:       tx.PayeeID.UUID,
+				PayeeName:     tx.PayeeName.String,
+				TransferID:    tx.TransferID.UUID,
+				Splits:        splitsByTransaction[tx.ID],
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(dbTransactions) < exportBatchSize {
+			return nil
+		}
+		last := dbTransactions[len(dbTransactions)-1]
+		params.AfterDate = last.TxDate
+		params.AfterID = last.ID
+	}
+}
+
+func (cfg *apiConfig) exportTransactions(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	format := req.URL.Query().Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "json" {
+		respondWithError(w, http.StatusBadRequest, "format must be csv or json", errors.New("invalid parameters"))
+		return
+	}
+
+	filter, err := exportFilterFromQuery(req.URL.Query())
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	if !cfg.checkExportFilter(w, req, userID, filter) {
+		return
+	}
+
+	// Once the first row is written the status can't change, so errors
+	// past this point can only be logged and end the download early.
+	switch format {
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", `attachment; filename="transactions.csv"`)
+		err = cfg.writeTransactionsCSV(req.Context(), w, userID, filter)
+	case "json":
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", `attachment; filename="transactions.json"`)
+		err = cfg.writeTransactionsJSON(req.Context(), w, userID, filter)
+	}
+	if err != nil {
+		log.Printf("Error exporting transactions: %s", err)
+	}
+}
+
+var exportCSVHeader = []string{"transaction_id", "date", "account", "payee", "description", "category", "amount", "memo", "posted", "transfer_id"}
+
+// writeTransactionsCSV writes one row per transaction, or one row per split
+// for split transactions, so the amount column always sums to the total.
+func (cfg *apiConfig) writeTransactionsCSV(ctx context.Context, w http.ResponseWriter, userID uuid.UUID, filter exportFilter) error {
+	flusher, _ := w.(http.Flusher)
+	csvWriter := csv.NewWriter(w)
+	if err := csvWriter.Write(exportCSVHeader); err != nil {
+		return err
+	}
+
+	rows := 0
+	err := cfg.eachExportTransaction(ctx, userID, filter, func(tx ExportTransaction) error {
+		transferID := ""
+		if tx.TransferID != uuid.Nil {
+			transferID = tx.TransferID.String()
+		}
+
+		record := func(category string, amount decimal.Decimal, memo string) []string {
+			return []string{
+				tx.ID.String(),
+				tx.TxDate.Format(time.DateOnly),
+				tx.AccountName,
+				tx.PayeeName,
+				tx.TxDescription,
+				category,
+				amount.StringFixed(2),
+				memo,
+				fmt.Sprint(tx.Posted),
+				transferID,
+			}
+		}
+
+		if len(tx.Splits) == 0 {
+			if err := csvWriter.Write(record(tx.CategoryName, tx.Amount, tx.Memo)); err != nil {
+				return err
+			}
+		}
+		for _, split := range tx.Splits {
+			if err := csvWriter.Write(record(split.CategoryName, split.Amount, split.Memo)); err != nil {
+				return err
+			}
+		}
+
+		rows++
+		if rows%exportBatchSize == 0 {
+			csvWriter.Flush()
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		return csvWriter.Error()
+	})
+	if err != nil {
+		return err
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// writeTransactionsJSON writes a JSON array one transaction at a time.
+func (cfg *apiConfig) writeTransactionsJSON(ctx context.Context, w http.ResponseWriter, userID uuid.UUID, filter exportFilter) error {
+	flusher, _ := w.(http.Flusher)
+	if _, err := w.Write([]byte("[")); err != nil {
+		return err
+	}
+
+	rows := 0
+	err := cfg.eachExportTransaction(ctx, userID, filter, func(tx ExportTransaction) error {
+		data, err := json.Marshal(tx)
+		if err != nil {
+			return err
+		}
+		if rows > 0 {
+			if _, err := w.Write([]byte(",")); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+
+		rows++
+		if rows%exportBatchSize == 0 && flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write([]byte("]"))
+	return err
+}
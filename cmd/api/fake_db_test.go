@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jkk290/budget-tui/internal/database"
+)
+
+// fakeDB stands in for Postgres in handler tests. Queries are told apart by
+// their sqlc name: each one answers with the rows set for it, or none, and
+// every statement run is recorded so a test can check what a handler did.
+type fakeDB struct {
+	results map[string][][]driver.Value
+	ran     []string
+	args    map[string][]driver.NamedValue
+}
+
+var queryNamePattern = regexp.MustCompile(`-- name: (\w+)`)
+
+func newFakeDB(t *testing.T) (*fakeDB, *apiConfig) {
+	t.Helper()
+	fake := &fakeDB{
+		results: map[string][][]driver.Value{},
+		args:    map[string][]driver.NamedValue{},
+	}
+	db := sql.OpenDB(fake)
+	t.Cleanup(func() { db.Close() })
+
+	return fake, &apiConfig{
+		db:          database.New(db),
+		dbConn:      db,
+		jwtSecret:   "test-secret",
+		matchWindow: 3,
+	}
+}
+
+// set makes the named query answer with rows.
+func (d *fakeDB) set(name string, rows ...[]driver.Value) {
+	d.results[name] = rows
+}
+
+// didRun reports whether the named statement ran.
+func (d *fakeDB) didRun(name string) bool {
+	return slices.Contains(d.ran, name)
+}
+
+func (d *fakeDB) record(query string, args []driver.NamedValue) string {
+	name := query
+	if match := queryNamePattern.FindStringSubmatch(query); match != nil {
+		name = match[1]
+	}
+	d.ran = append(d.ran, name)
+	d.args[name] = args
+	return name
+}
+
+func (d *fakeDB) Connect(context.Context) (driver.Conn, error) { return d, nil }
+func (d *fakeDB) Driver() driver.Driver                        { return nil }
+func (d *fakeDB) Close() error                                 { return nil }
+
+func (d *fakeDB) Prepare(query string) (driver.Stmt, error) {
+	return nil, driver.ErrSkip
+}
+
+func (d *fakeDB) Begin() (driver.Tx, error) {
+	d.ran = append(d.ran, "BEGIN")
+	return fakeTx{d}, nil
+}
+
+func (d *fakeDB) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	d.record(query, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (d *fakeDB) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	name := d.record(query, args)
+	return &fakeRows{rows: d.results[name]}, nil
+}
+
+type fakeTx struct {
+	d *fakeDB
+}
+
+func (tx fakeTx) Commit() error {
+	tx.d.ran = append(tx.d.ran, "COMMIT")
+	return nil
+}
+
+func (tx fakeTx) Rollback() error {
+	tx.d.ran = append(tx.d.ran, "ROLLBACK")
+	return nil
+}
+
+type fakeRows struct {
+	rows [][]driver.Value
+	next int
+}
+
+func (r *fakeRows) Columns() []string {
+	if len(r.rows) == 0 {
+		return nil
+	}
+	return make([]string, len(r.rows[0]))
+}
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.next])
+	r.next++
+	return nil
+}
+
+func nullUUIDValue(id uuid.NullUUID) driver.Value {
+	if !id.Valid {
+		return nil
+	}
+	return id.UUID.String()
+}
+
+func nullTimeValue(t sql.NullTime) driver.Value {
+	if !t.Valid {
+		return nil
+	}
+	return t.Time
+}
+
+func accountRow(a database.Account) []driver.Value {
+	return []driver.Value{a.ID.String(), a.AccountName, a.AccountType, a.CreatedAt, a.UpdatedAt, a.UserID.String(), a.Currency}
+}
+
+func transactionRow(tx database.Transaction) []driver.Value {
+	return []driver.Value{
+		tx.ID.String(),
+		tx.Amount.String(),
+		tx.TxDescription,
+		tx.TxDate,
+		tx.CreatedAt,
+		tx.UpdatedAt,
+		tx.Posted,
+		tx.AccountID.String(),
+		nullUUIDValue(tx.CategoryID),
+		nullUUIDValue(tx.TransferID),
+		nullUUIDValue(tx.ScheduledID),
+		nullTimeValue(tx.ScheduledDate),
+		nullUUIDValue(tx.PayeeID),
+		nullUUIDValue(tx.ReconciliationID),
+		tx.Memo,
+	}
+}
+
+func splitRow(split database.TransactionSplit) []driver.Value {
+	return []driver.Value{
+		split.ID.String(),
+		split.TransactionID.String(),
+		nullUUIDValue(split.CategoryID),
+		split.Amount.String(),
+		split.Memo,
+		int64(split.SortOrder),
+		split.CreatedAt,
+		split.UpdatedAt,
+	}
+}
+
+func reconciliationRow(r database.Reconciliation) []driver.Value {
+	return []driver.Value{
+		r.ID.String(),
+		r.StatementDate,
+		r.StatementBalance.String(),
+		int64(r.TransactionCount),
+		r.CreatedAt,
+		r.UpdatedAt,
+		r.AccountID.String(),
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jkk290/budget-tui/internal/accounttype"
+	"github.com/jkk290/budget-tui/internal/journal"
+	"github.com/shopspring/decimal"
+)
+
+var journalExtensions = map[journal.Format]string{
+	journal.FormatLedger:    "ledger",
+	journal.FormatHledger:   "journal",
+	journal.FormatBeancount: "beancount",
+}
+
+// journalBook maps the user's accounts and categories to journal accounts.
+type journalBook struct {
+	accounts   map[uuid.UUID]journal.Account
+	currencies map[uuid.UUID]string
+	categories map[uuid.UUID]journal.Account
+}
+
+func (b journalBook) account(accountID uuid.UUID, accountName string) journal.Account {
+	if account, ok := b.accounts[accountID]; ok {
+		return account
+	}
+	return journal.AssetAccount(accountName, accounttype.OtherAsset)
+}
+
+// category picks the other side of a posting of amount to a bank account.
+func (b journalBook) category(categoryID uuid.UUID, amount decimal.Decimal) journal.Account {
+	if account, ok := b.categories[categoryID]; ok {
+		return account
+	}
+	if amount.IsPositive() {
+		return journal.UncategorizedIncome
+	}
+	return journal.UncategorizedExpenses
+}
+
+// posting puts amount in the bank account's own currency. Accounts that
+// are gone fall back to the journal's currency.
+func (b journalBook) posting(account journal.Account, accountID uuid.UUID, amount decimal.Decimal) journal.Posting {
+	return journal.Posting{Account: account, Amount: amount, Currency: b.currencies[accountID]}
+}
+
+func (b journalBook) transaction(tx ExportTransaction) journal.Transaction {
+	account := b.account(tx.AccountID, tx.AccountName)
+	entry := journal.Transaction{
+		ID:          tx.ID.String(),
+		Date:        tx.TxDate,
+		Cleared:     tx.Posted,
+		Payee:       tx.PayeeName,
+		Description: tx.TxDescription,
+		Postings: []journal.Posting{
+			b.posting(account, tx.AccountID, tx.Amount),
+		},
+	}
+
+	switch {
+	case len(tx.Splits) > 0:
+		for _, split := range tx.Splits {
+			entry.Postings = append(entry.Postings, b.posting(b.category(split.CategoryID, split.Amount), tx.AccountID, split.Amount.Neg()))
+		}
+	case tx.CategoryID == uuid.Nil && tx.TxDescription == accounttype.InitialBalanceDescription:
+		entry.Postings = append(entry.Postings, b.posting(journal.OpeningBalances, tx.AccountID, tx.Amount.Neg()))
+	default:
+		entry.Postings = append(entry.Postings, b.posting(b.category(tx.CategoryID, tx.Amount), tx.AccountID, tx.Amount.Neg()))
+	}
+
+	return entry
+}
+
+// transfer joins both legs of a transfer into one entry.
+func (b journalBook) transfer(from, to ExportTransaction) journal.Transaction {
+	return journal.Transaction{
+		ID:          from.TransferID.String(),
+		Date:        from.TxDate,
+		Cleared:     from.Posted && to.Posted,
+		Payee:       from.PayeeName,
+		Description: from.TxDescription,
+		Postings: []journal.Posting{
+			b.posting(b.account(from.AccountID, from.AccountName), from.AccountID, from.Amount),
+			b.posting(b.account(to.AccountID, to.AccountName), to.AccountID, to.Amount),
+		},
+	}
+}
+
+// unmatchedTransfer writes a transfer leg whose other side is gone.
+func (b journalBook) unmatchedTransfer(leg ExportTransaction) journal.Transaction {
+	return journal.Transaction{
+		ID:          leg.ID.String(),
+		Date:        leg.TxDate,
+		Cleared:     leg.Posted,
+		Payee:       leg.PayeeName,
+		Description: leg.TxDescription,
+		Postings: []journal.Posting{
+			b.posting(b.account(leg.AccountID, leg.AccountName), leg.AccountID, leg.Amount),
+			b.posting(journal.UnmatchedTransfers, leg.AccountID, leg.Amount.Neg()),
+		},
+	}
+}
+
+// writeJournal writes the user's whole history as a ledger, hledger or
+// beancount journal.
+func (cfg *apiConfig) writeJournal(ctx context.Context, w io.Writer, userID uuid.UUID, format journal.Format) error {
+	dbUser, err := cfg.db.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("couldn't get user: %w", err)
+	}
+
+	dbAccounts, err := cfg.db.GetAccountsByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("couldn't get accounts: %w", err)
+	}
+
+	dbCategories, err := cfg.db.GetUserCategoriesDetailed(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("couldn't get categories: %w", err)
+	}
+
+	firstDate, err := cfg.db.GetUserFirstTransactionDate(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("couldn't get first transaction date: %w", err)
+	}
+
+	book := journalBook{
+		accounts:   make(map[uuid.UUID]journal.Account),
+		currencies: make(map[uuid.UUID]string),
+		categories: make(map[uuid.UUID]journal.Account),
+	}
+	opened := []journal.Account{
+		journal.OpeningBalances,
+		journal.UnmatchedTransfers,
+		journal.UncategorizedExpenses,
+		journal.UncategorizedIncome,
+	}
+	for _, dbAccount := range dbAccounts {
+		account := journal.AssetAccount(dbAccount.AccountName, accounttype.Type(dbAccount.AccountType))
+		book.accounts[dbAccount.ID] = account
+		book.currencies[dbAccount.ID] = dbAccount.Currency
+		opened = append(opened, account)
+	}
+	for _, dbCategory := range dbCategories {
+		account := journal.CategoryAccount(dbCategory.GroupName.String, dbCategory.CategoryName, dbCategory.IsIncome)
+		book.categories[dbCategory.ID] = account
+		opened = append(opened, account)
+	}
+
+	slices.SortFunc(opened, func(a, b journal.Account) int {
+		return strings.Compare(strings.Join(a, ":"), strings.Join(b, ":"))
+	})
+	opened = slices.CompactFunc(opened, slices.Equal)
+
+	jw := journal.NewWriter(w, format, dbUser.BaseCurrency)
+	if err := jw.Open(opened, firstDate); err != nil {
+		return err
+	}
+
+	// Both legs of a transfer share a transfer ID and date, so hold the
+	// first leg until its partner comes along.
+	pendingTransfers := make(map[uuid.UUID]ExportTransaction)
+	err = cfg.eachExportTransaction(ctx, userID, exportFilter{}, func(tx ExportTransaction) error {
+		if tx.TransferID == uuid.Nil {
+			return jw.Transaction(book.transaction(tx))
+		}
+
+		other, ok := pendingTransfers[tx.TransferID]
+		if !ok {
+			pendingTransfers[tx.TransferID] = tx
+			return nil
+		}
+		delete(pendingTransfers, tx.TransferID)
+		if other.Amount.IsNegative() {
+			return jw.Transaction(book.transfer(other, tx))
+		}
+		return jw.Transaction(book.transfer(tx, other))
+	})
+	if err != nil {
+		return err
+	}
+
+	leftovers := make([]ExportTransaction, 0, len(pendingTransfers))
+	for _, leg := range pendingTransfers {
+		leftovers = append(leftovers, leg)
+	}
+	slices.SortFunc(leftovers, func(a, b ExportTransaction) int {
+		return a.TxDate.Compare(b.TxDate)
+	})
+	for _, leg := range leftovers {
+		if err := jw.Transaction(book.unmatchedTransfer(leg)); err != nil {
+			return err
+		}
+	}
+
+	return jw.Flush()
+}
+
+func (cfg *apiConfig) exportJournal(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	formatName := req.URL.Query().Get("format")
+	if formatName == "" {
+		formatName = string(journal.FormatLedger)
+	}
+	format, err := journal.ParseFormat(formatName)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), errors.New("invalid parameters"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="budget.%s"`, journalExtensions[format]))
+	if err := cfg.writeJournal(req.Context(), w, userID, format); err != nil {
+		log.Printf("Error exporting journal: %s", err)
+	}
+}
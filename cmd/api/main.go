@@ -1,12 +1,17 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"time"
 
+	"github.com/jkk290/budget-tui/internal/attachments"
 	"github.com/jkk290/budget-tui/internal/database"
+	"github.com/jkk290/budget-tui/internal/match"
 	"github.com/joho/godotenv"
 
 	_ "github.com/lib/pq"
@@ -14,6 +19,14 @@ import (
 
 func main() {
 	godotenv.Load()
+
+	if len(os.Args) > 1 {
+		if err := runCommand(os.Args[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	port := os.Getenv("PORT")
 	if port == "" {
 		log.Fatal("PORT not set in .env")
@@ -29,6 +42,33 @@ func main() {
 		log.Fatal("TOKEN_SECRET not set in .env")
 	}
 
+	schedulerInterval := time.Hour
+	if intervalString := os.Getenv("SCHEDULER_INTERVAL"); intervalString != "" {
+		interval, err := time.ParseDuration(intervalString)
+		if err != nil || interval <= 0 {
+			log.Fatalf("Invalid SCHEDULER_INTERVAL: %q", intervalString)
+		}
+		schedulerInterval = interval
+	}
+
+	matchWindow := match.DefaultWindowDays
+	if windowString := os.Getenv("MATCH_WINDOW_DAYS"); windowString != "" {
+		window, err := strconv.Atoi(windowString)
+		if err != nil || window < 0 {
+			log.Fatalf("Invalid MATCH_WINDOW_DAYS: %q", windowString)
+		}
+		matchWindow = window
+	}
+
+	attachmentsDir := os.Getenv("ATTACHMENTS_DIR")
+	if attachmentsDir == "" {
+		attachmentsDir = "attachments"
+	}
+	attachmentStore, err := attachments.NewStore(attachmentsDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	db, err := sql.Open("postgres", dbURL)
 	if err != nil {
 		log.Printf("Error connecting to postgres database: %v", err)
@@ -36,22 +76,37 @@ func main() {
 	defer db.Close()
 
 	cfg := &apiConfig{
-		db:        database.New(db),
-		jwtSecret: tokenSecret,
+		db:          database.New(db),
+		dbConn:      db,
+		jwtSecret:   tokenSecret,
+		matchWindow: matchWindow,
+		attachments: attachmentStore,
 	}
 
+	go cfg.runScheduler(context.Background(), schedulerInterval)
+
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("GET /api/v1/hello", handlerHello)
 
 	mux.HandleFunc("POST /api/v1/users", cfg.createUser)
 	mux.HandleFunc("POST /api/v1/login", cfg.handlerLogin)
+	mux.HandleFunc("GET /api/v1/users/me", cfg.getCurrentUser)
+	mux.HandleFunc("PUT /api/v1/users/me", cfg.updateCurrentUser)
 
 	mux.HandleFunc("GET /api/v1/accounts", cfg.getAccounts)
 	mux.HandleFunc("POST /api/v1/accounts", cfg.addAccount)
 	mux.HandleFunc("PUT /api/v1/accounts/{accountID}", cfg.updateAccountInfo)
 	mux.HandleFunc("DELETE /api/v1/accounts/{accountID}", cfg.deleteAccount)
 	mux.HandleFunc("GET /api/v1/accounts/{accountID}/transactions", cfg.getAccountTransactions)
+	mux.HandleFunc("GET /api/v1/accounts/{accountID}/register", cfg.getAccountRegister)
+	mux.HandleFunc("POST /api/v1/accounts/{accountID}/import/csv", cfg.importCSV)
+	mux.HandleFunc("GET /api/v1/accounts/{accountID}/import/csv/mapping", cfg.getCSVImportMapping)
+	mux.HandleFunc("PUT /api/v1/accounts/{accountID}/import/csv/mapping", cfg.setCSVImportMapping)
+	mux.HandleFunc("POST /api/v1/accounts/{accountID}/import/ofx", cfg.importOFX)
+	mux.HandleFunc("GET /api/v1/accounts/{accountID}/reconciliations", cfg.getAccountReconciliations)
+	mux.HandleFunc("POST /api/v1/accounts/{accountID}/reconciliations", cfg.createReconciliation)
+	mux.HandleFunc("DELETE /api/v1/accounts/{accountID}/reconciliations/{reconciliationID}", cfg.deleteReconciliation)
 
 	mux.HandleFunc("GET /api/v1/groups", cfg.getGroups)
 	mux.HandleFunc("POST /api/v1/groups", cfg.createGroup)
@@ -63,14 +118,71 @@ func main() {
 	mux.HandleFunc("PUT /api/v1/categories/{categoryID}", cfg.updateCategory)
 	mux.HandleFunc("DELETE /api/v1/categories/{categoryID}", cfg.deleteCategory)
 	mux.HandleFunc("GET /api/v1/categories/{categoryID}/transactions", cfg.getCategoryTransactions)
+	mux.HandleFunc("GET /api/v1/categories/{categoryID}/budgets", cfg.getCategoryBudgets)
+	mux.HandleFunc("GET /api/v1/categories/{categoryID}/budgets/{month}", cfg.getCategoryBudgetForMonth)
+	mux.HandleFunc("PUT /api/v1/categories/{categoryID}/budgets/{month}", cfg.setCategoryBudget)
+	mux.HandleFunc("DELETE /api/v1/categories/{categoryID}/budgets/{month}", cfg.deleteCategoryBudget)
+
+	mux.HandleFunc("GET /api/v1/rules", cfg.getRules)
+	mux.HandleFunc("POST /api/v1/rules", cfg.createRule)
+	mux.HandleFunc("PUT /api/v1/rules/order", cfg.reorderRules)
+	mux.HandleFunc("POST /api/v1/rules/apply", cfg.applyRulesRetroactively)
+	mux.HandleFunc("PUT /api/v1/rules/{ruleID}", cfg.updateRule)
+	mux.HandleFunc("DELETE /api/v1/rules/{ruleID}", cfg.deleteRule)
+
+	mux.HandleFunc("GET /api/v1/payees", cfg.getPayees)
+	mux.HandleFunc("POST /api/v1/payees", cfg.createPayee)
+	mux.HandleFunc("PUT /api/v1/payees/{payeeID}", cfg.updatePayee)
+	mux.HandleFunc("DELETE /api/v1/payees/{payeeID}", cfg.deletePayee)
+	mux.HandleFunc("GET /api/v1/payees/{payeeID}/transactions", cfg.getPayeeTransactions)
+
+	mux.HandleFunc("GET /api/v1/tags", cfg.getTags)
+	mux.HandleFunc("POST /api/v1/tags", cfg.createTag)
+	mux.HandleFunc("GET /api/v1/tags/report", cfg.getTagReport)
+	mux.HandleFunc("PUT /api/v1/tags/{tagID}", cfg.updateTag)
+	mux.HandleFunc("DELETE /api/v1/tags/{tagID}", cfg.deleteTag)
+	mux.HandleFunc("POST /api/v1/tags/{tagID}/merge", cfg.mergeTag)
 
 	mux.HandleFunc("GET /api/v1/transactions", cfg.getUserTransactions)
 	mux.HandleFunc("POST /api/v1/transactions", cfg.addTransaction)
 	mux.HandleFunc("PUT /api/v1/transactions/{transactionID}", cfg.updateTransaction)
 	mux.HandleFunc("DELETE /api/v1/transactions/{transactionID}", cfg.deleteTransaction)
+	mux.HandleFunc("POST /api/v1/transactions/tags", cfg.tagTransactions)
+	mux.HandleFunc("PUT /api/v1/transactions/{transactionID}/posted", cfg.updateTransactionPosted)
+	mux.HandleFunc("GET /api/v1/transactions/{transactionID}/attachments", cfg.getTransactionAttachments)
+	mux.HandleFunc("POST /api/v1/transactions/{transactionID}/attachments", cfg.uploadTransactionAttachment)
+	mux.HandleFunc("GET /api/v1/transactions/{transactionID}/attachments/{attachmentID}", cfg.downloadTransactionAttachment)
+	mux.HandleFunc("DELETE /api/v1/transactions/{transactionID}/attachments/{attachmentID}", cfg.deleteTransactionAttachment)
+
+	mux.HandleFunc("POST /api/v1/transfers", cfg.createTransfer)
+
+	mux.HandleFunc("GET /api/v1/export/transactions", cfg.exportTransactions)
+	mux.HandleFunc("GET /api/v1/export/journal", cfg.exportJournal)
+
+	mux.HandleFunc("GET /api/v1/backup", cfg.getBackup)
+	mux.HandleFunc("POST /api/v1/restore", cfg.restoreBackup)
+
+	mux.HandleFunc("GET /api/v1/duplicates", cfg.getImportDuplicates)
+	mux.HandleFunc("POST /api/v1/duplicates/{duplicateID}/match", cfg.matchImportDuplicate)
+	mux.HandleFunc("POST /api/v1/duplicates/{duplicateID}/keep", cfg.keepImportDuplicate)
+
+	mux.HandleFunc("GET /api/v1/scheduled", cfg.getScheduledTransactions)
+	mux.HandleFunc("POST /api/v1/scheduled", cfg.createScheduledTransaction)
+	mux.HandleFunc("GET /api/v1/scheduled/upcoming", cfg.getUpcomingScheduled)
+	mux.HandleFunc("PUT /api/v1/scheduled/{scheduledID}", cfg.updateScheduledTransaction)
+	mux.HandleFunc("DELETE /api/v1/scheduled/{scheduledID}", cfg.deleteScheduledTransaction)
+	mux.HandleFunc("POST /api/v1/scheduled/{scheduledID}/enter", cfg.enterScheduledTransaction)
+	mux.HandleFunc("POST /api/v1/scheduled/{scheduledID}/skip", cfg.skipScheduledTransaction)
 
 	mux.HandleFunc("GET /api/v1/budget", cfg.handlerGetBudgetOverview)
 
+	mux.HandleFunc("GET /api/v1/reports/net-worth", cfg.getNetWorthReport)
+
+	mux.HandleFunc("GET /api/v1/exchange-rates", cfg.getExchangeRates)
+	mux.HandleFunc("POST /api/v1/exchange-rates", cfg.createExchangeRate)
+	mux.HandleFunc("POST /api/v1/exchange-rates/import", cfg.importExchangeRates)
+	mux.HandleFunc("DELETE /api/v1/exchange-rates/{rateID}", cfg.deleteExchangeRate)
+
 	srv := &http.Server{
 		Handler: mux,
 		Addr:    ":" + port,
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jkk290/budget-tui/internal/accounttype"
+	"github.com/jkk290/budget-tui/internal/database"
+	"github.com/shopspring/decimal"
+)
+
+// netWorthIntervals maps the report's intervals, named as Postgres's
+// date_trunc names them, to their length in months.
+var netWorthIntervals = map[string]int{
+	"month":   1,
+	"quarter": 3,
+	"year":    12,
+}
+
+// maxNetWorthPeriods keeps a report to twenty years of months.
+const maxNetWorthPeriods = 240
+
+// NetWorthAccount is an account's balance at the end of a period, in its
+// own currency and in the base currency. BaseBalance is null when there
+// was no exchange rate yet.
+type NetWorthAccount struct {
+	AccountID   uuid.UUID           `json:"account_id"`
+	AccountName string              `json:"account_name"`
+	AccountType string              `json:"account_type"`
+	Currency    string              `json:"currency"`
+	Balance     decimal.Decimal     `json:"balance"`
+	BaseBalance decimal.NullDecimal `json:"base_balance"`
+}
+
+// NetWorthPeriod totals are in the base currency. TotalLiabilities is what
+// is owed, so it is positive and NetWorth is assets minus liabilities.
+type NetWorthPeriod struct {
+	StartDate             time.Time         `json:"start_date"`
+	EndDate               time.Time         `json:"end_date"`
+	Assets                []NetWorthAccount `json:"assets"`
+	Liabilities           []NetWorthAccount `json:"liabilities"`
+	TotalAssets           decimal.Decimal   `json:"total_assets"`
+	TotalLiabilities      decimal.Decimal   `json:"total_liabilities"`
+	NetWorth              decimal.Decimal   `json:"net_worth"`
+	UnconvertedCurrencies []string          `json:"unconverted_currencies"`
+}
+
+type NetWorthReport struct {
+	From         time.Time        `json:"from"`
+	To           time.Time        `json:"to"`
+	Interval     string           `json:"interval"`
+	BaseCurrency string           `json:"base_currency"`
+	Periods      []NetWorthPeriod `json:"periods"`
+}
+
+// periodStart is the first day of the period of the given length in
+// months that t falls in. Periods line up with the calendar, so quarters
+// start in January, April, July and October.
+func periodStart(t time.Time, months int) time.Time {
+	month := t.Month() - (t.Month()-1)%time.Month(months)
+	return time.Date(t.Year(), month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// getNetWorthReport works out every account's balance at the end of each
+// period between from and to, by adding each period's transactions to the
+// balance before from.
+func (cfg *apiConfig) getNetWorthReport(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	query := req.URL.Query()
+	interval := query.Get("interval")
+	if interval == "" {
+		interval = "month"
+	}
+	months, ok := netWorthIntervals[interval]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("invalid interval %q, expected month, quarter or year", interval), errors.New("invalid parameters"))
+		return
+	}
+
+	now := time.Now().UTC()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if toStr := query.Get("to"); toStr != "" {
+		to, err = time.Parse(time.DateOnly, toStr)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, fmt.Sprintf("invalid to %q, expected YYYY-MM-DD", toStr), err)
+			return
+		}
+	}
+	// Without a from, show a year of months, or the same number of
+	// longer periods.
+	from := periodStart(to, months).AddDate(0, -11*months, 0)
+	if fromStr := query.Get("from"); fromStr != "" {
+		from, err = time.Parse(time.DateOnly, fromStr)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, fmt.Sprintf("invalid from %q, expected YYYY-MM-DD", fromStr), err)
+			return
+		}
+	}
+	if to.Before(from) {
+		respondWithError(w, http.StatusBadRequest, "to must not be before from", errors.New("invalid parameters"))
+		return
+	}
+
+	first, last := periodStart(from, months), periodStart(to, months)
+	periodCount := ((last.Year()-first.Year())*12+int(last.Month()-first.Month()))/months + 1
+	if periodCount > maxNetWorthPeriods {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("the report can cover at most %d periods", maxNetWorthPeriods), errors.New("invalid parameters"))
+		return
+	}
+
+	dbUser, err := cfg.db.GetUserByID(req.Context(), userID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get user", err)
+		return
+	}
+
+	dbAccounts, err := cfg.db.GetUserAccountsBalances(req.Context(), database.GetUserAccountsBalancesParams{
+		AsOf:   from,
+		UserID: userID,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't retrieve accounts", err)
+		return
+	}
+
+	dbTotals, err := cfg.db.GetAccountPeriodTotals(req.Context(), database.GetAccountPeriodTotalsParams{
+		Interval:  interval,
+		UserID:    userID,
+		StartDate: from,
+		EndDate:   to.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get account totals", err)
+		return
+	}
+
+	balances := make(map[uuid.UUID]decimal.Decimal, len(dbAccounts))
+	for _, account := range dbAccounts {
+		balances[account.ID] = account.ClearedBalance.Add(account.UnclearedBalance)
+	}
+
+	report := NetWorthReport{
+		From:         from,
+		To:           to,
+		Interval:     interval,
+		BaseCurrency: dbUser.BaseCurrency,
+		Periods:      []NetWorthPeriod{},
+	}
+	for start := from; !start.After(to); {
+		key := periodStart(start, months)
+		next := key.AddDate(0, months, 0)
+		end := next.AddDate(0, 0, -1)
+		if end.After(to) {
+			end = to
+		}
+
+		// Totals come ordered by period, so each period's are at the front.
+		for len(dbTotals) > 0 && dbTotals[0].PeriodStart.Equal(key) {
+			balances[dbTotals[0].AccountID] = balances[dbTotals[0].AccountID].Add(dbTotals[0].Amount)
+			dbTotals = dbTotals[1:]
+		}
+
+		rates, err := loadRateTable(req.Context(), cfg.db, userID, dbUser.BaseCurrency, end)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Couldn't get exchange rates", err)
+			return
+		}
+
+		period := NetWorthPeriod{
+			StartDate:             start,
+			EndDate:               end,
+			Assets:                []NetWorthAccount{},
+			Liabilities:           []NetWorthAccount{},
+			TotalAssets:           decimal.Zero,
+			TotalLiabilities:      decimal.Zero,
+			UnconvertedCurrencies: []string{},
+		}
+		for _, account := range dbAccounts {
+			balance := balances[account.ID]
+			baseBalance, converted := rates.convert(balance, account.Currency)
+			// An empty account is worth nothing in any currency.
+			if !converted && balance.IsZero() {
+				baseBalance, converted = decimal.Zero, true
+			}
+			if !converted && !slices.Contains(period.UnconvertedCurrencies, account.Currency) {
+				period.UnconvertedCurrencies = append(period.UnconvertedCurrencies, account.Currency)
+			}
+
+			entry := NetWorthAccount{
+				AccountID:   account.ID,
+				AccountName: account.AccountName,
+				AccountType: account.AccountType,
+				Currency:    account.Currency,
+				Balance:     balance,
+				BaseBalance: decimal.NullDecimal{Decimal: baseBalance, Valid: converted},
+			}
+			if accounttype.Type(account.AccountType).IsLiability() {
+				period.Liabilities = append(period.Liabilities, entry)
+				period.TotalLiabilities = period.TotalLiabilities.Sub(baseBalance)
+			} else {
+				period.Assets = append(period.Assets, entry)
+				period.TotalAssets = period.TotalAssets.Add(baseBalance)
+			}
+		}
+		period.NetWorth = period.TotalAssets.Sub(period.TotalLiabilities)
+		slices.Sort(period.UnconvertedCurrencies)
+
+		report.Periods = append(report.Periods, period)
+		start = next
+	}
+
+	respondWithJSON(w, http.StatusOK, report)
+}
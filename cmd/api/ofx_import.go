@@ -0,0 +1,148 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/jkk290/budget-tui/internal/match"
+	"github.com/jkk290/budget-tui/internal/ofx"
+)
+
+type OFXImportRow struct {
+	ofx.Transaction
+	AlreadyImported bool `json:"already_imported"`
+	ImportMatch
+}
+
+type OFXImportResult struct {
+	Committed bool `json:"committed"`
+	ImportCounts
+	Rows []OFXImportRow `json:"rows"`
+}
+
+// importOFX reads an OFX/QFX statement for an account. Entries whose FITID
+// was already imported into the account are skipped, so downloading
+// overlapping statements is safe. Like importCSV it only writes when
+// commit=true.
+func (cfg *apiConfig) importOFX(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	dbAccount, ok := cfg.getOwnedAccount(w, req, userID)
+	if !ok {
+		return
+	}
+
+	req.Body = http.MaxBytesReader(w, req.Body, maxImportFileSize)
+	if err := req.ParseMultipartForm(maxImportFileSize); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Couldn't parse multipart form", err)
+		return
+	}
+
+	file, _, err := req.FormFile("file")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Missing OFX file", err)
+		return
+	}
+	defer file.Close()
+
+	transactions, err := ofx.Parse(file)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	importedFITIDs, err := cfg.db.GetAccountImportedFITIDs(req.Context(), dbAccount.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get imported transactions", err)
+		return
+	}
+	seen := map[string]bool{}
+	for _, fitID := range importedFITIDs {
+		seen[fitID] = true
+	}
+
+	windowDays, err := cfg.matchWindowDays(req)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	var dates []time.Time
+	for _, transaction := range transactions {
+		dates = append(dates, transaction.Date)
+	}
+	matcher, err := cfg.newMatcher(req.Context(), dbAccount.ID, dates, windowDays)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get existing transactions", err)
+		return
+	}
+
+	result := OFXImportResult{
+		Rows: []OFXImportRow{},
+	}
+	matches := make([]match.Result, len(transactions))
+	for i, transaction := range transactions {
+		row := OFXImportRow{
+			Transaction:     transaction,
+			AlreadyImported: seen[transaction.FITID],
+		}
+		if row.AlreadyImported {
+			result.Skipped++
+		} else {
+			matches[i] = matcher.Match(transaction.Date, transaction.Amount)
+			row.ImportMatch = ImportMatch{
+				Match:                matches[i].Kind,
+				MatchedTransactionID: matches[i].TransactionID,
+			}
+		}
+		seen[transaction.FITID] = true
+		result.Rows = append(result.Rows, row)
+	}
+
+	if req.FormValue("commit") != "true" {
+		respondWithJSON(w, http.StatusOK, result)
+		return
+	}
+
+	engine, err := cfg.loadRules(req.Context(), userID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't load rules", err)
+		return
+	}
+
+	tx, err := cfg.dbConn.BeginTx(req.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't import transactions", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	for i, row := range result.Rows {
+		if row.AlreadyImported {
+			continue
+		}
+
+		entry := importEntry{
+			Params: row.AddTransactionParams(dbAccount.ID),
+			FITID:  row.FITID,
+		}
+		if err := commitImportEntry(req.Context(), qtx, engine, userID, dbAccount.ID, entry, matches[i]); err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Couldn't import transactions", err)
+			return
+		}
+		result.countMatch(matches[i].Kind)
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't import transactions", err)
+		return
+	}
+
+	result.Committed = true
+	respondWithJSON(w, http.StatusCreated, result)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 500
+)
+
+// transactionPage is where a transaction listing starts and how many rows
+// it returns. Listings are ordered newest first by (tx_date, id), and the
+// cursor is the last row of the previous page.
+type transactionPage struct {
+	Limit      int32
+	CursorDate sql.NullTime
+	CursorID   uuid.NullUUID
+}
+
+// rowLimit is one more than the page size, so the extra row shows whether
+// there is another page.
+func (p transactionPage) rowLimit() int32 {
+	return p.Limit + 1
+}
+
+// pageFromQuery reads the optional limit and cursor query parameters.
+func pageFromQuery(query url.Values) (transactionPage, error) {
+	page := transactionPage{Limit: defaultPageSize}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > maxPageSize {
+			return transactionPage{}, fmt.Errorf("invalid limit, expected 1 to %d", maxPageSize)
+		}
+		page.Limit = int32(limit)
+	}
+
+	if cursor := query.Get("cursor"); cursor != "" {
+		txDate, id, err := decodeCursor(cursor)
+		if err != nil {
+			return transactionPage{}, errors.New("invalid cursor")
+		}
+		page.CursorDate = sql.NullTime{Time: txDate, Valid: true}
+		page.CursorID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+
+	return page, nil
+}
+
+// encodeCursor makes an opaque cursor from a row's sort key.
+func encodeCursor(txDate time.Time, id uuid.UUID) string {
+	key := txDate.UTC().Format(time.RFC3339Nano) + "|" + id.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+func decodeCursor(cursor string) (time.Time, uuid.UUID, error) {
+	key, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, uuid.Nil, err
+	}
+
+	dateStr, idStr, ok := strings.Cut(string(key), "|")
+	if !ok {
+		return time.Time{}, uuid.Nil, errors.New("malformed cursor")
+	}
+	txDate, err := time.Parse(time.RFC3339Nano, dateStr)
+	if err != nil {
+		return time.Time{}, uuid.Nil, err
+	}
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return time.Time{}, uuid.Nil, err
+	}
+	return txDate, id, nil
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jkk290/budget-tui/internal/database"
+)
+
+var errPayeeNotOwned = errors.New("payee belongs to another user")
+
+type Payee struct {
+	ID                  uuid.UUID `json:"id"`
+	PayeeName           string    `json:"payee_name"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+	UserID              uuid.UUID `json:"user_id"`
+	DefaultCategoryID   uuid.UUID `json:"default_category_id"`
+	DefaultCategoryName string    `json:"default_category_name"`
+}
+
+func payeeFromDB(dbPayee database.Payee) Payee {
+	return Payee{
+		ID:                dbPayee.ID,
+		PayeeName:         dbPayee.PayeeName,
+		CreatedAt:         dbPayee.CreatedAt,
+		UpdatedAt:         dbPayee.UpdatedAt,
+		UserID:            dbPayee.UserID,
+		DefaultCategoryID: dbPayee.DefaultCategoryID.UUID,
+	}
+}
+
+// resolvePayee finds the payee a transaction should point at. An explicit
+// payee_id wins; otherwise payee_name is looked up case-insensitively and
+// created on first use so payees can be typed straight into a transaction.
+func resolvePayee(ctx context.Context, qtx *database.Queries, userID, payeeID uuid.UUID, payeeName string) (database.Payee, bool, error) {
+	if payeeID != uuid.Nil {
+		dbPayee, err := qtx.GetPayeeByID(ctx, payeeID)
+		if err != nil {
+			return database.Payee{}, false, err
+		}
+		if dbPayee.UserID != userID {
+			return database.Payee{}, false, errPayeeNotOwned
+		}
+		return dbPayee, true, nil
+	}
+
+	payeeName = strings.TrimSpace(payeeName)
+	if payeeName == "" {
+		return database.Payee{}, false, nil
+	}
+
+	dbPayee, err := qtx.GetPayeeByName(ctx, database.GetPayeeByNameParams{
+		UserID:    userID,
+		PayeeName: payeeName,
+	})
+	if err == nil {
+		return dbPayee, true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return database.Payee{}, false, err
+	}
+
+	dbPayee, err = qtx.CreatePayee(ctx, database.CreatePayeeParams{
+		ID:        uuid.New(),
+		PayeeName: payeeName,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+		UserID:    userID,
+	})
+	if err != nil {
+		return database.Payee{}, false, err
+	}
+	return dbPayee, true, nil
+}
+
+func (cfg *apiConfig) checkDefaultCategory(ctx context.Context, userID, categoryID uuid.UUID) (uuid.NullUUID, error) {
+	if categoryID == uuid.Nil {
+		return uuid.NullUUID{}, nil
+	}
+
+	dbCategory, err := cfg.db.GetCategoryByID(ctx, categoryID)
+	if err != nil {
+		return uuid.NullUUID{}, err
+	}
+	if dbCategory.UserID != userID {
+		return uuid.NullUUID{}, errors.New("unauthorized")
+	}
+	return uuid.NullUUID{UUID: dbCategory.ID, Valid: true}, nil
+}
+
+func (cfg *apiConfig) createPayee(w http.ResponseWriter, req *http.Request) {
+	type parameters struct {
+		PayeeName         string    `json:"payee_name"`
+		DefaultCategoryID uuid.UUID `json:"default_category_id"`
+	}
+
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	decoder := json.NewDecoder(req.Body)
+	params := parameters{}
+	if err := decoder.Decode(&params); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't decode parameters", err)
+		return
+	}
+
+	params.PayeeName = strings.TrimSpace(params.PayeeName)
+	if params.PayeeName == "" {
+		respondWithError(w, http.StatusBadRequest, "Missing payee name", errors.New("invalid parameters"))
+		return
+	}
+
+	defaultCategoryID, err := cfg.checkDefaultCategory(req.Context(), userID, params.DefaultCategoryID)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid default category", err)
+		return
+	}
+
+	_, err = cfg.db.GetPayeeByName(req.Context(), database.GetPayeeByNameParams{
+		UserID:    userID,
+		PayeeName: params.PayeeName,
+	})
+	if err == nil {
+		respondWithError(w, http.StatusConflict, "Payee already exists", errors.New("duplicate payee"))
+		return
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't create payee", err)
+		return
+	}
+
+	dbPayee, err := cfg.db.CreatePayee(req.Context(), database.CreatePayeeParams{
+		ID:                uuid.New(),
+		PayeeName:         params.PayeeName,
+		CreatedAt:         time.Now(),
+		UpdatedAt:         time.Now(),
+		UserID:            userID,
+		DefaultCategoryID: defaultCategoryID,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't create payee", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, payeeFromDB(dbPayee))
+}
+
+func (cfg *apiConfig) getPayees(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	dbPayees, err := cfg.db.GetUserPayees(req.Context(), userID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get payees", err)
+		return
+	}
+
+	payees := []Payee{}
+	for _, payee := range dbPayees {
+		payees = append(payees, Payee{
+			ID:                  payee.ID,
+			PayeeName:           payee.PayeeName,
+			CreatedAt:           payee.CreatedAt,
+			UpdatedAt:           payee.UpdatedAt,
+			UserID:              payee.UserID,
+			DefaultCategoryID:   payee.DefaultCategoryID.UUID,
+			DefaultCategoryName: payee.DefaultCategoryName.String,
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, payees)
+}
+
+func (cfg *apiConfig) getOwnedPayee(w http.ResponseWriter, req *http.Request, userID uuid.UUID) (database.Payee, bool) {
+	payeeID, err := uuid.Parse(req.PathValue("payeeID"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid payee ID", err)
+		return database.Payee{}, false
+	}
+
+	dbPayee, err := cfg.db.GetPayeeByID(req.Context(), payeeID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Couldn't get payee", err)
+		return database.Payee{}, false
+	}
+	if dbPayee.UserID != userID {
+		respondWithError(w, http.StatusForbidden, "Can't access payee", errors.New("unauthorized"))
+		return database.Payee{}, false
+	}
+
+	return dbPayee, true
+}
+
+func (cfg *apiConfig) updatePayee(w http.ResponseWriter, req *http.Request) {
+	type parameters struct {
+		PayeeName         string    `json:"payee_name"`
+		DefaultCategoryID uuid.UUID `json:"default_category_id"`
+	}
+
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	dbPayee, ok := cfg.getOwnedPayee(w, req, userID)
+	if !ok {
+		return
+	}
+
+	decoder := json.NewDecoder(req.Body)
+	params := parameters{}
+	if err := decoder.Decode(&params); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't decode parameters", err)
+		return
+	}
+
+	params.PayeeName = strings.TrimSpace(params.PayeeName)
+	if params.PayeeName == "" {
+		respondWithError(w, http.StatusBadRequest, "Missing payee name", errors.New("invalid parameters"))
+		return
+	}
+
+	defaultCategoryID, err := cfg.checkDefaultCategory(req.Context(), userID, params.DefaultCategoryID)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid default category", err)
+		return
+	}
+
+	existing, err := cfg.db.GetPayeeByName(req.Context(), database.GetPayeeByNameParams{
+		UserID:    userID,
+		PayeeName: params.PayeeName,
+	})
+	if err == nil && existing.ID != dbPayee.ID {
+		respondWithError(w, http.StatusConflict, "Payee already exists", errors.New("duplicate payee"))
+		return
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't update payee", err)
+		return
+	}
+
+	updatedPayee, err := cfg.db.UpdatePayee(req.Context(), database.UpdatePayeeParams{
+		ID:                dbPayee.ID,
+		PayeeName:         params.PayeeName,
+		DefaultCategoryID: defaultCategoryID,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't update payee", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, payeeFromDB(updatedPayee))
+}
+
+// deletePayee removes the payee; its transactions keep their descriptions
+// and simply lose the payee link.
+func (cfg *apiConfig) deletePayee(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	dbPayee, ok := cfg.getOwnedPayee(w, req, userID)
+	if !ok {
+		return
+	}
+
+	if err := cfg.db.DeletePayee(req.Context(), dbPayee.ID); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't delete payee", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (cfg *apiConfig) getPayeeTransactions(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	dbPayee, ok := cfg.getOwnedPayee(w, req, userID)
+	if !ok {
+		return
+	}
+
+	dbTransactions, err := cfg.db.GetTransactionsByPayee(req.Context(), uuid.NullUUID{UUID: dbPayee.ID, Valid: true})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get transactions", err)
+		return
+	}
+
+	transactions := []Transaction{}
+	for _, transaction := range dbTransactions {
+		transactions = append(transactions, transactionFromDB(transaction))
+	}
+
+	respondWithJSON(w, http.StatusOK, transactions)
+}
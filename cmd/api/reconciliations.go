@@ -0,0 +1,280 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jkk290/budget-tui/internal/database"
+	"github.com/shopspring/decimal"
+)
+
+var errTransactionReconciled = errors.New("transaction is reconciled")
+var errReconciledPeriod = errors.New("date is in a reconciled period")
+
+type Reconciliation struct {
+	ID               uuid.UUID       `json:"id"`
+	AccountID        uuid.UUID       `json:"account_id"`
+	StatementDate    time.Time       `json:"statement_date"`
+	StatementBalance decimal.Decimal `json:"statement_balance"`
+	TransactionCount int32           `json:"transaction_count"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+}
+
+func reconciliationFromDB(dbReconciliation database.Reconciliation) Reconciliation {
+	return Reconciliation{
+		ID:               dbReconciliation.ID,
+		AccountID:        dbReconciliation.AccountID,
+		StatementDate:    dbReconciliation.StatementDate,
+		StatementBalance: dbReconciliation.StatementBalance,
+		TransactionCount: dbReconciliation.TransactionCount,
+		CreatedAt:        dbReconciliation.CreatedAt,
+		UpdatedAt:        dbReconciliation.UpdatedAt,
+	}
+}
+
+// checkNotReconciled returns errTransactionReconciled when the transaction,
+// or the other leg of its transfer, has been reconciled and is locked.
+func checkNotReconciled(ctx context.Context, q *database.Queries, dbTransaction database.Transaction) error {
+	if dbTransaction.ReconciliationID.Valid {
+		return errTransactionReconciled
+	}
+	if !dbTransaction.TransferID.Valid {
+		return nil
+	}
+
+	counterpart, err := q.GetTransferCounterpart(ctx, database.GetTransferCounterpartParams{
+		TransferID: dbTransaction.TransferID,
+		ID:         dbTransaction.ID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if counterpart.ReconciliationID.Valid {
+		return errTransactionReconciled
+	}
+	return nil
+}
+
+// checkNotInReconciledPeriod returns errReconciledPeriod when txDate falls
+// on or before the account's latest statement date. A cleared transaction
+// there would change a balance that has already been reconciled.
+func checkNotInReconciledPeriod(ctx context.Context, q *database.Queries, accountID uuid.UUID, txDate time.Time) error {
+	latest, err := q.GetLatestReconciliation(ctx, accountID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	// The statement covers its whole ending day.
+	if txDate.Before(latest.StatementDate.AddDate(0, 0, 1)) {
+		return errReconciledPeriod
+	}
+	return nil
+}
+
+func (cfg *apiConfig) createReconciliation(w http.ResponseWriter, req *http.Request) {
+	type parameters struct {
+		StatementDate    time.Time       `json:"statement_date"`
+		StatementBalance decimal.Decimal `json:"statement_balance"`
+	}
+
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	accountID, err := uuid.Parse(req.PathValue("accountID"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid account ID", err)
+		return
+	}
+
+	dbAccount, err := cfg.db.GetAccountByID(req.Context(), accountID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get account", err)
+		return
+	}
+	if dbAccount.UserID != userID {
+		respondWithError(w, http.StatusForbidden, "Can't reconcile account", errors.New("unauthorized"))
+		return
+	}
+
+	decoder := json.NewDecoder(req.Body)
+	params := parameters{}
+	if err := decoder.Decode(&params); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't decode parameters", err)
+		return
+	}
+
+	if params.StatementDate.IsZero() {
+		respondWithError(w, http.StatusBadRequest, "Missing statement date", errors.New("invalid parameters"))
+		return
+	}
+
+	// The statement covers its whole ending day.
+	statementDate := time.Date(params.StatementDate.Year(), params.StatementDate.Month(), params.StatementDate.Day(), 0, 0, 0, 0, time.UTC)
+	endDate := statementDate.AddDate(0, 0, 1)
+
+	tx, err := cfg.dbConn.BeginTx(req.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't reconcile account", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	clearedBalance, err := qtx.GetAccountClearedBalance(req.Context(), database.GetAccountClearedBalanceParams{
+		AccountID: accountID,
+		EndDate:   endDate,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get cleared balance", err)
+		return
+	}
+	if !clearedBalance.Equal(params.StatementBalance) {
+		msg := fmt.Sprintf("Cleared balance %s doesn't match statement balance %s (difference %s)",
+			clearedBalance.StringFixed(2),
+			params.StatementBalance.StringFixed(2),
+			params.StatementBalance.Sub(clearedBalance).StringFixed(2))
+		respondWithError(w, http.StatusBadRequest, msg, errors.New("invalid parameters"))
+		return
+	}
+
+	dbReconciliation, err := qtx.CreateReconciliation(req.Context(), database.CreateReconciliationParams{
+		ID:               uuid.New(),
+		StatementDate:    statementDate,
+		StatementBalance: params.StatementBalance,
+		TransactionCount: 0,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+		AccountID:        accountID,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't create reconciliation", err)
+		return
+	}
+
+	count, err := qtx.ReconcileAccountTransactions(req.Context(), database.ReconcileAccountTransactionsParams{
+		ReconciliationID: uuid.NullUUID{UUID: dbReconciliation.ID, Valid: true},
+		AccountID:        accountID,
+		EndDate:          endDate,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't reconcile transactions", err)
+		return
+	}
+
+	dbReconciliation, err = qtx.SetReconciliationTransactionCount(req.Context(), database.SetReconciliationTransactionCountParams{
+		ID:               dbReconciliation.ID,
+		TransactionCount: int32(count),
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't create reconciliation", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't reconcile account", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, reconciliationFromDB(dbReconciliation))
+}
+
+func (cfg *apiConfig) getAccountReconciliations(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	accountID, err := uuid.Parse(req.PathValue("accountID"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid account ID", err)
+		return
+	}
+
+	dbAccount, err := cfg.db.GetAccountByID(req.Context(), accountID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get account", err)
+		return
+	}
+	if dbAccount.UserID != userID {
+		respondWithError(w, http.StatusForbidden, "Can't view account reconciliations", errors.New("unauthorized"))
+		return
+	}
+
+	dbReconciliations, err := cfg.db.GetReconciliationsByAccount(req.Context(), accountID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get reconciliations", err)
+		return
+	}
+
+	reconciliations := []Reconciliation{}
+	for _, dbReconciliation := range dbReconciliations {
+		reconciliations = append(reconciliations, reconciliationFromDB(dbReconciliation))
+	}
+
+	respondWithJSON(w, http.StatusOK, reconciliations)
+}
+
+// deleteReconciliation undoes the account's latest reconciliation, which
+// unlocks its transactions. Older ones can't be undone while a later
+// statement still depends on them.
+func (cfg *apiConfig) deleteReconciliation(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	accountID, err := uuid.Parse(req.PathValue("accountID"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid account ID", err)
+		return
+	}
+
+	reconciliationID, err := uuid.Parse(req.PathValue("reconciliationID"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid reconciliation ID", err)
+		return
+	}
+
+	dbAccount, err := cfg.db.GetAccountByID(req.Context(), accountID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get account", err)
+		return
+	}
+	if dbAccount.UserID != userID {
+		respondWithError(w, http.StatusForbidden, "Can't undo reconciliation", errors.New("unauthorized"))
+		return
+	}
+
+	dbReconciliations, err := cfg.db.GetReconciliationsByAccount(req.Context(), accountID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get reconciliations", err)
+		return
+	}
+	if len(dbReconciliations) == 0 || dbReconciliations[0].ID != reconciliationID {
+		respondWithError(w, http.StatusConflict, "Only the latest reconciliation can be undone", errors.New("not latest reconciliation"))
+		return
+	}
+
+	if err := cfg.db.DeleteReconciliation(req.Context(), reconciliationID); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't delete reconciliation", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jkk290/budget-tui/internal/database"
+	"github.com/shopspring/decimal"
+)
+
+type RegisterEntry struct {
+	ID                  uuid.UUID       `json:"id"`
+	TxDate              time.Time       `json:"tx_date"`
+	TxDescription       string          `json:"tx_description"`
+	PayeeID             uuid.UUID       `json:"payee_id"`
+	PayeeName           string          `json:"payee_name"`
+	CategoryID          uuid.UUID       `json:"category_id"`
+	CategoryName        string          `json:"category_name"`
+	TransferID          uuid.UUID       `json:"transfer_id"`
+	TransferAccountName string          `json:"transfer_account_name"`
+	Split               bool            `json:"split"`
+	Amount              decimal.Decimal `json:"amount"`
+	Posted              bool            `json:"posted"`
+	Reconciled          bool            `json:"reconciled"`
+	Balance             decimal.Decimal `json:"balance"`
+}
+
+type AccountRegister struct {
+	AccountID      uuid.UUID       `json:"account_id"`
+	StartDate      *time.Time      `json:"start_date"`
+	EndDate        *time.Time      `json:"end_date"`
+	OpeningBalance decimal.Decimal `json:"opening_balance"`
+	ClosingBalance decimal.Decimal `json:"closing_balance"`
+	Entries        []RegisterEntry `json:"entries"`
+}
+
+// getAccountRegister lists an account's transactions oldest first with the
+// balance after each one. With a start date the running balance picks up
+// from everything dated before it.
+func (cfg *apiConfig) getAccountRegister(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	dbAccount, ok := cfg.getOwnedAccount(w, req, userID)
+	if !ok {
+		return
+	}
+
+	startDate, endDate, err := dateRangeFromQuery(req.URL.Query())
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), errors.New("invalid parameters"))
+		return
+	}
+
+	register := AccountRegister{
+		AccountID:      dbAccount.ID,
+		StartDate:      nullTimePtr(startDate),
+		OpeningBalance: decimal.Zero,
+		Entries:        []RegisterEntry{},
+	}
+	if endDate.Valid {
+		lastDay := endDate.Time.AddDate(0, 0, -1)
+		register.EndDate = &lastDay
+	}
+
+	if startDate.Valid {
+		register.OpeningBalance, err = cfg.db.GetAccountBalanceBefore(req.Context(), database.GetAccountBalanceBeforeParams{
+			AccountID:  dbAccount.ID,
+			BeforeDate: startDate.Time,
+		})
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Couldn't get opening balance", err)
+			return
+		}
+	}
+
+	dbEntries, err := cfg.db.GetAccountRegister(req.Context(), database.GetAccountRegisterParams{
+		AccountID: dbAccount.ID,
+		StartDate: startDate,
+		EndDate:   endDate,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get transactions", err)
+		return
+	}
+
+	balance := register.OpeningBalance
+	for _, entry := range dbEntries {
+		balance = balance.Add(entry.Amount)
+		register.Entries = append(register.Entries, RegisterEntry{
+			ID:                  entry.ID,
+			TxDate:              entry.TxDate,
+			TxDescription:       entry.TxDescription,
+			PayeeID:             entry.PayeeID.UUID,
+			PayeeName:           entry.PayeeName.String,
+			CategoryID:          entry.CategoryID.UUID,
+			CategoryName:        entry.CategoryName.String,
+			TransferID:          entry.TransferID.UUID,
+			TransferAccountName: entry.TransferAccountName.String,
+			Split:               entry.IsSplit,
+			Amount:              entry.Amount,
+			Posted:              entry.Posted,
+			Reconciled:          entry.ReconciliationID.Valid,
+			Balance:             balance,
+		})
+	}
+	register.ClosingBalance = balance
+
+	respondWithJSON(w, http.StatusOK, register)
+}
@@ -0,0 +1,535 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jkk290/budget-tui/internal/database"
+	"github.com/jkk290/budget-tui/internal/rules"
+	"github.com/shopspring/decimal"
+)
+
+type Rule struct {
+	ID                  uuid.UUID        `json:"id"`
+	RuleName            string           `json:"rule_name"`
+	SortOrder           int              `json:"sort_order"`
+	DescriptionContains string           `json:"description_contains"`
+	DescriptionRegex    string           `json:"description_regex"`
+	MinAmount           *decimal.Decimal `json:"min_amount"`
+	MaxAmount           *decimal.Decimal `json:"max_amount"`
+	AccountID           uuid.UUID        `json:"account_id"`
+	AccountName         string           `json:"account_name"`
+	SetCategoryID       uuid.UUID        `json:"set_category_id"`
+	SetCategoryName     string           `json:"set_category_name"`
+	SetDescription      string           `json:"set_description"`
+	SetPosted           *bool            `json:"set_posted"`
+	CreatedAt           time.Time        `json:"created_at"`
+	UpdatedAt           time.Time        `json:"updated_at"`
+}
+
+type ruleParameters struct {
+	RuleName            string           `json:"rule_name"`
+	DescriptionContains string           `json:"description_contains"`
+	DescriptionRegex    string           `json:"description_regex"`
+	MinAmount           *decimal.Decimal `json:"min_amount"`
+	MaxAmount           *decimal.Decimal `json:"max_amount"`
+	AccountID           uuid.UUID        `json:"account_id"`
+	SetCategoryID       uuid.UUID        `json:"set_category_id"`
+	SetDescription      string           `json:"set_description"`
+	SetPosted           *bool            `json:"set_posted"`
+}
+
+func (p ruleParameters) rule() rules.Rule {
+	return rules.Rule{
+		DescriptionContains: p.DescriptionContains,
+		DescriptionRegex:    p.DescriptionRegex,
+		MinAmount:           p.MinAmount,
+		MaxAmount:           p.MaxAmount,
+		AccountID:           p.AccountID,
+		SetCategoryID:       p.SetCategoryID,
+		SetDescription:      p.SetDescription,
+		SetPosted:           p.SetPosted,
+	}
+}
+
+func nullDecimal(d *decimal.Decimal) decimal.NullDecimal {
+	if d == nil {
+		return decimal.NullDecimal{}
+	}
+	return decimal.NullDecimal{Decimal: *d, Valid: true}
+}
+
+func nullDecimalPtr(d decimal.NullDecimal) *decimal.Decimal {
+	if !d.Valid {
+		return nil
+	}
+	return &d.Decimal
+}
+
+func nullBool(b *bool) sql.NullBool {
+	if b == nil {
+		return sql.NullBool{}
+	}
+	return sql.NullBool{Bool: *b, Valid: true}
+}
+
+func nullBoolPtr(b sql.NullBool) *bool {
+	if !b.Valid {
+		return nil
+	}
+	return &b.Bool
+}
+
+func nullUUID(id uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{UUID: id, Valid: id != uuid.Nil}
+}
+
+func ruleFromDB(dbRule database.Rule) Rule {
+	return Rule{
+		ID:                  dbRule.ID,
+		RuleName:            dbRule.RuleName,
+		SortOrder:           int(dbRule.SortOrder),
+		DescriptionContains: dbRule.DescriptionContains,
+		DescriptionRegex:    dbRule.DescriptionRegex,
+		MinAmount:           nullDecimalPtr(dbRule.MinAmount),
+		MaxAmount:           nullDecimalPtr(dbRule.MaxAmount),
+		AccountID:           dbRule.AccountID.UUID,
+		SetCategoryID:       dbRule.SetCategoryID.UUID,
+		SetDescription:      dbRule.SetDescription,
+		SetPosted:           nullBoolPtr(dbRule.SetPosted),
+		CreatedAt:           dbRule.CreatedAt,
+		UpdatedAt:           dbRule.UpdatedAt,
+	}
+}
+
+// loadRules compiles the user's rules in order. Rules left without an
+// action, e.g. because their category was deleted, are skipped.
+func (cfg *apiConfig) loadRules(ctx context.Context, userID uuid.UUID) (*rules.Engine, error) {
+	dbRules, err := cfg.db.GetUserRules(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var ruleSet []rules.Rule
+	for _, dbRule := range dbRules {
+		rule := rules.Rule{
+			DescriptionContains: dbRule.DescriptionContains,
+			DescriptionRegex:    dbRule.DescriptionRegex,
+			MinAmount:           nullDecimalPtr(dbRule.MinAmount),
+			MaxAmount:           nullDecimalPtr(dbRule.MaxAmount),
+			AccountID:           dbRule.AccountID.UUID,
+			SetCategoryID:       dbRule.SetCategoryID.UUID,
+			SetDescription:      dbRule.SetDescription,
+			SetPosted:           nullBoolPtr(dbRule.SetPosted),
+		}
+		if rule.Validate() != nil {
+			continue
+		}
+		ruleSet = append(ruleSet, rule)
+	}
+
+	return rules.Compile(ruleSet)
+}
+
+// applyRules runs the rules over a transaction that is about to be
+// created.
+func applyRules(engine *rules.Engine, params *database.AddTransactionParams) {
+	target := rules.Transaction{
+		Description: params.TxDescription,
+		Amount:      params.Amount,
+		AccountID:   params.AccountID,
+		CategoryID:  params.CategoryID.UUID,
+		Posted:      params.Posted,
+	}
+	if !engine.Apply(&target) {
+		return
+	}
+
+	params.TxDescription = target.Description
+	params.CategoryID = nullUUID(target.CategoryID)
+	params.Posted = target.Posted
+}
+
+func (cfg *apiConfig) validateRuleParameters(w http.ResponseWriter, req *http.Request, userID uuid.UUID, params ruleParameters) bool {
+	if params.RuleName == "" {
+		respondWithError(w, http.StatusBadRequest, "Missing rule name", errors.New("invalid parameters"))
+		return false
+	}
+
+	if err := params.rule().Validate(); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return false
+	}
+
+	if params.AccountID != uuid.Nil {
+		dbAccount, err := cfg.db.GetAccountByID(req.Context(), params.AccountID)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Couldn't get account", err)
+			return false
+		}
+		if dbAccount.UserID != userID {
+			respondWithError(w, http.StatusForbidden, "Can't use account in rule", errors.New("unauthorized"))
+			return false
+		}
+	}
+
+	if params.SetCategoryID != uuid.Nil {
+		dbCategory, err := cfg.db.GetCategoryByID(req.Context(), params.SetCategoryID)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Couldn't get category", err)
+			return false
+		}
+		if dbCategory.UserID != userID {
+			respondWithError(w, http.StatusForbidden, "Can't use category in rule", errors.New("unauthorized"))
+			return false
+		}
+	}
+
+	return true
+}
+
+func (cfg *apiConfig) userRules(ctx context.Context, userID uuid.UUID) ([]Rule, error) {
+	dbRules, err := cfg.db.GetUserRules(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	userRules := []Rule{}
+	for _, r := range dbRules {
+		userRules = append(userRules, Rule{
+			ID:                  r.ID,
+			RuleName:            r.RuleName,
+			SortOrder:           int(r.SortOrder),
+			DescriptionContains: r.DescriptionContains,
+			DescriptionRegex:    r.DescriptionRegex,
+			MinAmount:           nullDecimalPtr(r.MinAmount),
+			MaxAmount:           nullDecimalPtr(r.MaxAmount),
+			AccountID:           r.AccountID.UUID,
+			AccountName:         r.AccountName.String,
+			SetCategoryID:       r.SetCategoryID.UUID,
+			SetCategoryName:     r.SetCategoryName.String,
+			SetDescription:      r.SetDescription,
+			SetPosted:           nullBoolPtr(r.SetPosted),
+			CreatedAt:           r.CreatedAt,
+			UpdatedAt:           r.UpdatedAt,
+		})
+	}
+	return userRules, nil
+}
+
+func (cfg *apiConfig) getRules(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	userRules, err := cfg.userRules(req.Context(), userID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get rules", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, userRules)
+}
+
+func (cfg *apiConfig) createRule(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	decoder := json.NewDecoder(req.Body)
+	params := ruleParameters{}
+	if err := decoder.Decode(&params); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't decode parameters", err)
+		return
+	}
+
+	if !cfg.validateRuleParameters(w, req, userID, params) {
+		return
+	}
+
+	dbRule, err := cfg.db.CreateRule(req.Context(), database.CreateRuleParams{
+		ID:                  uuid.New(),
+		RuleName:            params.RuleName,
+		DescriptionContains: params.DescriptionContains,
+		DescriptionRegex:    params.DescriptionRegex,
+		MinAmount:           nullDecimal(params.MinAmount),
+		MaxAmount:           nullDecimal(params.MaxAmount),
+		AccountID:           nullUUID(params.AccountID),
+		SetCategoryID:       nullUUID(params.SetCategoryID),
+		SetDescription:      params.SetDescription,
+		SetPosted:           nullBool(params.SetPosted),
+		CreatedAt:           time.Now(),
+		UpdatedAt:           time.Now(),
+		UserID:              userID,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't create rule", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, ruleFromDB(dbRule))
+}
+
+func (cfg *apiConfig) getOwnedRule(w http.ResponseWriter, req *http.Request, userID uuid.UUID) (database.Rule, bool) {
+	ruleID, err := uuid.Parse(req.PathValue("ruleID"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid rule ID", err)
+		return database.Rule{}, false
+	}
+
+	dbRule, err := cfg.db.GetRuleByID(req.Context(), ruleID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Couldn't get rule", err)
+		return database.Rule{}, false
+	}
+	if dbRule.UserID != userID {
+		respondWithError(w, http.StatusForbidden, "Can't access rule", errors.New("unauthorized"))
+		return database.Rule{}, false
+	}
+
+	return dbRule, true
+}
+
+func (cfg *apiConfig) updateRule(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	dbRule, ok := cfg.getOwnedRule(w, req, userID)
+	if !ok {
+		return
+	}
+
+	decoder := json.NewDecoder(req.Body)
+	params := ruleParameters{}
+	if err := decoder.Decode(&params); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't decode parameters", err)
+		return
+	}
+
+	if !cfg.validateRuleParameters(w, req, userID, params) {
+		return
+	}
+
+	updatedRule, err := cfg.db.UpdateRule(req.Context(), database.UpdateRuleParams{
+		ID:                  dbRule.ID,
+		RuleName:            params.RuleName,
+		DescriptionContains: params.DescriptionContains,
+		DescriptionRegex:    params.DescriptionRegex,
+		MinAmount:           nullDecimal(params.MinAmount),
+		MaxAmount:           nullDecimal(params.MaxAmount),
+		AccountID:           nullUUID(params.AccountID),
+		SetCategoryID:       nullUUID(params.SetCategoryID),
+		SetDescription:      params.SetDescription,
+		SetPosted:           nullBool(params.SetPosted),
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't update rule", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, ruleFromDB(updatedRule))
+}
+
+func (cfg *apiConfig) deleteRule(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	dbRule, ok := cfg.getOwnedRule(w, req, userID)
+	if !ok {
+		return
+	}
+
+	if err := cfg.db.DeleteRule(req.Context(), dbRule.ID); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't delete rule", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// reorderRules sets the order rules run in. The request must list every
+// one of the user's rules exactly once.
+func (cfg *apiConfig) reorderRules(w http.ResponseWriter, req *http.Request) {
+	type parameters struct {
+		RuleIDs []uuid.UUID `json:"rule_ids"`
+	}
+
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	decoder := json.NewDecoder(req.Body)
+	params := parameters{}
+	if err := decoder.Decode(&params); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't decode parameters", err)
+		return
+	}
+
+	dbRules, err := cfg.db.GetUserRules(req.Context(), userID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get rules", err)
+		return
+	}
+
+	owned := map[uuid.UUID]bool{}
+	for _, dbRule := range dbRules {
+		owned[dbRule.ID] = true
+	}
+	seen := map[uuid.UUID]bool{}
+	for _, ruleID := range params.RuleIDs {
+		if !owned[ruleID] || seen[ruleID] {
+			respondWithError(w, http.StatusBadRequest, "rule_ids must list each of your rules once", errors.New("invalid parameters"))
+			return
+		}
+		seen[ruleID] = true
+	}
+	if len(seen) != len(owned) {
+		respondWithError(w, http.StatusBadRequest, "rule_ids must list each of your rules once", errors.New("invalid parameters"))
+		return
+	}
+
+	tx, err := cfg.dbConn.BeginTx(req.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't reorder rules", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	for i, ruleID := range params.RuleIDs {
+		if err := qtx.SetRuleSortOrder(req.Context(), database.SetRuleSortOrderParams{
+			ID:        ruleID,
+			SortOrder: int32(i + 1),
+		}); err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Couldn't reorder rules", err)
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't reorder rules", err)
+		return
+	}
+
+	userRules, err := cfg.userRules(req.Context(), userID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get rules", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, userRules)
+}
+
+// applyRulesRetroactively runs the current rules over existing
+// transactions dated between start_date and end_date, inclusive. Transfers
+// and split transactions are left alone.
+func (cfg *apiConfig) applyRulesRetroactively(w http.ResponseWriter, req *http.Request) {
+	type parameters struct {
+		StartDate time.Time `json:"start_date"`
+		EndDate   time.Time `json:"end_date"`
+	}
+
+	type response struct {
+		Checked int `json:"checked"`
+		Updated int `json:"updated"`
+	}
+
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	decoder := json.NewDecoder(req.Body)
+	params := parameters{}
+	if err := decoder.Decode(&params); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't decode parameters", err)
+		return
+	}
+
+	if params.StartDate.IsZero() || params.EndDate.IsZero() || params.EndDate.Before(params.StartDate) {
+		respondWithError(w, http.StatusBadRequest, "Missing start and end dates, or end date before start date", errors.New("invalid parameters"))
+		return
+	}
+
+	engine, err := cfg.loadRules(req.Context(), userID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't load rules", err)
+		return
+	}
+
+	start := time.Date(params.StartDate.Year(), params.StartDate.Month(), params.StartDate.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(params.EndDate.Year(), params.EndDate.Month(), params.EndDate.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+
+	tx, err := cfg.dbConn.BeginTx(req.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't apply rules", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	dbTransactions, err := qtx.GetRuleTargetTransactions(req.Context(), database.GetRuleTargetTransactionsParams{
+		UserID:    userID,
+		StartDate: start,
+		EndDate:   end,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get transactions", err)
+		return
+	}
+
+	result := response{Checked: len(dbTransactions)}
+	for _, transaction := range dbTransactions {
+		target := rules.Transaction{
+			Description: transaction.TxDescription,
+			Amount:      transaction.Amount,
+			AccountID:   transaction.AccountID,
+			CategoryID:  transaction.CategoryID.UUID,
+			Posted:      transaction.Posted,
+		}
+		if !engine.Apply(&target) {
+			continue
+		}
+
+		_, err := qtx.UpdateTransaction(req.Context(), database.UpdateTransactionParams{
+			ID:            transaction.ID,
+			Amount:        transaction.Amount,
+			TxDescription: target.Description,
+			TxDate:        transaction.TxDate,
+			Posted:        target.Posted,
+			AccountID:     transaction.AccountID,
+			CategoryID:    nullUUID(target.CategoryID),
+			PayeeID:       transaction.PayeeID,
+			Memo:          transaction.Memo,
+		})
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Couldn't apply rules", err)
+			return
+		}
+		result.Updated++
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't apply rules", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,386 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jkk290/budget-tui/internal/database"
+	"github.com/jkk290/budget-tui/internal/schedule"
+	"github.com/shopspring/decimal"
+)
+
+type ScheduledTransaction struct {
+	ID              uuid.UUID       `json:"id"`
+	Amount          decimal.Decimal `json:"amount"`
+	TxDescription   string          `json:"tx_description"`
+	Frequency       string          `json:"frequency"`
+	DayOfMonth      int             `json:"day_of_month"`
+	StartDate       time.Time       `json:"start_date"`
+	EndDate         *time.Time      `json:"end_date"`
+	OccurrenceCount int             `json:"occurrence_count"`
+	LastDate        *time.Time      `json:"last_date"`
+	NextDate        *time.Time      `json:"next_date"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	AccountID       uuid.UUID       `json:"account_id"`
+	CategoryID      uuid.UUID       `json:"category_id"`
+}
+
+type ScheduledOccurrence struct {
+	ScheduledID   uuid.UUID       `json:"scheduled_id"`
+	Date          time.Time       `json:"date"`
+	Amount        decimal.Decimal `json:"amount"`
+	TxDescription string          `json:"tx_description"`
+	AccountID     uuid.UUID       `json:"account_id"`
+	CategoryID    uuid.UUID       `json:"category_id"`
+	IsNext        bool            `json:"is_next"`
+}
+
+type scheduledParameters struct {
+	Amount          decimal.Decimal `json:"amount"`
+	TxDescription   string          `json:"tx_description"`
+	Frequency       string          `json:"frequency"`
+	DayOfMonth      int             `json:"day_of_month"`
+	StartDate       time.Time       `json:"start_date"`
+	EndDate         *time.Time      `json:"end_date"`
+	OccurrenceCount int             `json:"occurrence_count"`
+	AccountID       uuid.UUID       `json:"account_id"`
+	CategoryID      uuid.UUID       `json:"category_id"`
+}
+
+func (p scheduledParameters) rule() schedule.Rule {
+	rule := schedule.Rule{
+		Frequency:  schedule.Frequency(p.Frequency),
+		DayOfMonth: p.DayOfMonth,
+		StartDate:  p.StartDate,
+		Count:      p.OccurrenceCount,
+	}
+	if p.EndDate != nil {
+		rule.EndDate = *p.EndDate
+	}
+	return rule
+}
+
+func scheduleRule(dbScheduled database.ScheduledTransaction) schedule.Rule {
+	return schedule.Rule{
+		Frequency:  schedule.Frequency(dbScheduled.Frequency),
+		DayOfMonth: int(dbScheduled.DayOfMonth),
+		StartDate:  dbScheduled.StartDate,
+		EndDate:    dbScheduled.EndDate.Time,
+		Count:      int(dbScheduled.OccurrenceCount),
+	}
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+func scheduledFromDB(dbScheduled database.ScheduledTransaction) ScheduledTransaction {
+	scheduled := ScheduledTransaction{
+		ID:              dbScheduled.ID,
+		Amount:          dbScheduled.Amount,
+		TxDescription:   dbScheduled.TxDescription,
+		Frequency:       dbScheduled.Frequency,
+		DayOfMonth:      int(dbScheduled.DayOfMonth),
+		StartDate:       dbScheduled.StartDate,
+		EndDate:         nullTimePtr(dbScheduled.EndDate),
+		OccurrenceCount: int(dbScheduled.OccurrenceCount),
+		LastDate:        nullTimePtr(dbScheduled.LastDate),
+		CreatedAt:       dbScheduled.CreatedAt,
+		UpdatedAt:       dbScheduled.UpdatedAt,
+		AccountID:       dbScheduled.AccountID,
+		CategoryID:      dbScheduled.CategoryID.UUID,
+	}
+	if next, ok := scheduleRule(dbScheduled).Next(dbScheduled.LastDate.Time); ok {
+		scheduled.NextDate = &next
+	}
+	return scheduled
+}
+
+func (cfg *apiConfig) validateScheduledParameters(w http.ResponseWriter, req *http.Request, userID uuid.UUID, params scheduledParameters) bool {
+	if params.Amount.IsZero() || params.TxDescription == "" || params.AccountID == uuid.Nil {
+		respondWithError(w, http.StatusBadRequest, "Missing amount, description, and/or account", errors.New("invalid parameters"))
+		return false
+	}
+
+	if err := params.rule().Validate(); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return false
+	}
+
+	dbAccount, err := cfg.db.GetAccountByID(req.Context(), params.AccountID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get account", err)
+		return false
+	}
+	if dbAccount.UserID != userID {
+		respondWithError(w, http.StatusForbidden, "Can't schedule transactions for account", errors.New("unauthorized"))
+		return false
+	}
+
+	if params.CategoryID != uuid.Nil {
+		dbCategory, err := cfg.db.GetCategoryByID(req.Context(), params.CategoryID)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Couldn't get category", err)
+			return false
+		}
+		if dbCategory.UserID != userID {
+			respondWithError(w, http.StatusForbidden, "Can't use category", errors.New("unauthorized"))
+			return false
+		}
+	}
+
+	return true
+}
+
+func (cfg *apiConfig) getOwnedScheduled(w http.ResponseWriter, req *http.Request, userID uuid.UUID) (database.ScheduledTransaction, bool) {
+	scheduledID, err := uuid.Parse(req.PathValue("scheduledID"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid scheduled transaction ID", err)
+		return database.ScheduledTransaction{}, false
+	}
+
+	dbScheduled, err := cfg.db.GetScheduledTransactionByID(req.Context(), scheduledID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get scheduled transaction", err)
+		return database.ScheduledTransaction{}, false
+	}
+	if dbScheduled.UserID != userID {
+		respondWithError(w, http.StatusForbidden, "Can't access scheduled transaction", errors.New("unauthorized"))
+		return database.ScheduledTransaction{}, false
+	}
+
+	return dbScheduled, true
+}
+
+func (cfg *apiConfig) getScheduledTransactions(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	dbScheduled, err := cfg.db.GetScheduledTransactionsByUser(req.Context(), userID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get scheduled transactions", err)
+		return
+	}
+
+	scheduled := []ScheduledTransaction{}
+	for _, s := range dbScheduled {
+		scheduled = append(scheduled, scheduledFromDB(s))
+	}
+
+	respondWithJSON(w, http.StatusOK, scheduled)
+}
+
+func (cfg *apiConfig) createScheduledTransaction(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	decoder := json.NewDecoder(req.Body)
+	params := scheduledParameters{}
+	if err := decoder.Decode(&params); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't decode parameters", err)
+		return
+	}
+
+	if !cfg.validateScheduledParameters(w, req, userID, params) {
+		return
+	}
+
+	rule := params.rule()
+	dbScheduled, err := cfg.db.CreateScheduledTransaction(req.Context(), database.CreateScheduledTransactionParams{
+		ID:              uuid.New(),
+		Amount:          params.Amount,
+		TxDescription:   params.TxDescription,
+		Frequency:       string(rule.Frequency),
+		DayOfMonth:      int32(rule.DayOfMonth),
+		StartDate:       schedule.Day(rule.StartDate),
+		EndDate:         sql.NullTime{Time: schedule.Day(rule.EndDate), Valid: !rule.EndDate.IsZero()},
+		OccurrenceCount: int32(rule.Count),
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		UserID:          userID,
+		AccountID:       params.AccountID,
+		CategoryID:      uuid.NullUUID{UUID: params.CategoryID, Valid: params.CategoryID != uuid.Nil},
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't create scheduled transaction", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, scheduledFromDB(dbScheduled))
+}
+
+func (cfg *apiConfig) updateScheduledTransaction(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	dbScheduled, ok := cfg.getOwnedScheduled(w, req, userID)
+	if !ok {
+		return
+	}
+
+	decoder := json.NewDecoder(req.Body)
+	params := scheduledParameters{}
+	if err := decoder.Decode(&params); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't decode parameters", err)
+		return
+	}
+
+	if !cfg.validateScheduledParameters(w, req, userID, params) {
+		return
+	}
+
+	rule := params.rule()
+	updatedScheduled, err := cfg.db.UpdateScheduledTransaction(req.Context(), database.UpdateScheduledTransactionParams{
+		ID:              dbScheduled.ID,
+		Amount:          params.Amount,
+		TxDescription:   params.TxDescription,
+		Frequency:       string(rule.Frequency),
+		DayOfMonth:      int32(rule.DayOfMonth),
+		StartDate:       schedule.Day(rule.StartDate),
+		EndDate:         sql.NullTime{Time: schedule.Day(rule.EndDate), Valid: !rule.EndDate.IsZero()},
+		OccurrenceCount: int32(rule.Count),
+		AccountID:       params.AccountID,
+		CategoryID:      uuid.NullUUID{UUID: params.CategoryID, Valid: params.CategoryID != uuid.Nil},
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't update scheduled transaction", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, scheduledFromDB(updatedScheduled))
+}
+
+func (cfg *apiConfig) deleteScheduledTransaction(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	dbScheduled, ok := cfg.getOwnedScheduled(w, req, userID)
+	if !ok {
+		return
+	}
+
+	if err := cfg.db.DeleteScheduledTransaction(req.Context(), dbScheduled.ID); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't delete scheduled transaction", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (cfg *apiConfig) enterScheduledTransaction(w http.ResponseWriter, req *http.Request) {
+	cfg.handleNextOccurrence(w, req, true)
+}
+
+func (cfg *apiConfig) skipScheduledTransaction(w http.ResponseWriter, req *http.Request) {
+	cfg.handleNextOccurrence(w, req, false)
+}
+
+func (cfg *apiConfig) handleNextOccurrence(w http.ResponseWriter, req *http.Request, enter bool) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	dbScheduled, ok := cfg.getOwnedScheduled(w, req, userID)
+	if !ok {
+		return
+	}
+
+	next, ok := scheduleRule(dbScheduled).Next(dbScheduled.LastDate.Time)
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, "Scheduled transaction has no remaining occurrences", errors.New("invalid parameters"))
+		return
+	}
+
+	err = cfg.recordOccurrence(req.Context(), dbScheduled, next, enter)
+	if errors.Is(err, errOccurrenceRecorded) {
+		respondWithError(w, http.StatusConflict, "Occurrence was already entered or skipped", err)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't update scheduled transaction", err)
+		return
+	}
+
+	updatedScheduled, err := cfg.db.GetScheduledTransactionByID(req.Context(), dbScheduled.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get scheduled transaction", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, scheduledFromDB(updatedScheduled))
+}
+
+func (cfg *apiConfig) getUpcomingScheduled(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	days := 30
+	if daysString := req.URL.Query().Get("days"); daysString != "" {
+		days, err = strconv.Atoi(daysString)
+		if err != nil || days < 1 || days > 366 {
+			respondWithError(w, http.StatusBadRequest, "days must be between 1 and 366", errors.New("invalid parameters"))
+			return
+		}
+	}
+	through := schedule.Day(time.Now()).AddDate(0, 0, days)
+
+	dbScheduled, err := cfg.db.GetScheduledTransactionsByUser(req.Context(), userID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get scheduled transactions", err)
+		return
+	}
+
+	occurrences := []ScheduledOccurrence{}
+	for _, s := range dbScheduled {
+		for i, date := range scheduleRule(s).Between(s.LastDate.Time, through) {
+			occurrences = append(occurrences, ScheduledOccurrence{
+				ScheduledID:   s.ID,
+				Date:          date,
+				Amount:        s.Amount,
+				TxDescription: s.TxDescription,
+				AccountID:     s.AccountID,
+				CategoryID:    s.CategoryID.UUID,
+				IsNext:        i == 0,
+			})
+		}
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].Date.Before(occurrences[j].Date)
+	})
+
+	respondWithJSON(w, http.StatusOK, occurrences)
+}
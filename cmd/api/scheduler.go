@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jkk290/budget-tui/internal/database"
+)
+
+// errOccurrenceRecorded means another request already entered or skipped
+// the occurrence, so last_date is already on or past it.
+var errOccurrenceRecorded = errors.New("occurrence already recorded")
+
+func (cfg *apiConfig) runScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := cfg.materializeScheduled(ctx, time.Now()); err != nil {
+			log.Printf("Error materializing scheduled transactions: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// materializeScheduled enters every occurrence that is due on or before now
+// as an unposted transaction. Re-running it is safe: each occurrence is
+// unique per schedule and date, and last_date only ever moves forward in
+// the same database transaction.
+func (cfg *apiConfig) materializeScheduled(ctx context.Context, now time.Time) error {
+	dbScheduled, err := cfg.db.GetAllScheduledTransactions(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range dbScheduled {
+		for _, date := range scheduleRule(s).Between(s.LastDate.Time, now) {
+			err := cfg.recordOccurrence(ctx, s, date, true)
+			if errors.Is(err, errOccurrenceRecorded) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (cfg *apiConfig) recordOccurrence(ctx context.Context, scheduled database.ScheduledTransaction, date time.Time, enter bool) error {
+	tx, err := cfg.dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	// Moving last_date first locks the row, so a manual enter or skip
+	// running at the same time waits here and then finds nothing to do.
+	updated, err := qtx.SetScheduledTransactionLastDate(ctx, database.SetScheduledTransactionLastDateParams{
+		ID:       scheduled.ID,
+		LastDate: sql.NullTime{Time: date, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return errOccurrenceRecorded
+	}
+
+	if enter {
+		err := qtx.AddScheduledOccurrence(ctx, database.AddScheduledOccurrenceParams{
+			ID:            uuid.New(),
+			Amount:        scheduled.Amount,
+			TxDescription: scheduled.TxDescription,
+			TxDate:        date,
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
+			AccountID:     scheduled.AccountID,
+			CategoryID:    scheduled.CategoryID,
+			ScheduledID:   uuid.NullUUID{UUID: scheduled.ID, Valid: true},
+			ScheduledDate: sql.NullTime{Time: date, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
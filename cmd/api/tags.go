@@ -0,0 +1,530 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jkk290/budget-tui/internal/database"
+	"github.com/shopspring/decimal"
+)
+
+var errTagNotOwned = errors.New("tag belongs to another user")
+
+type Tag struct {
+	ID        uuid.UUID `json:"id"`
+	TagName   string    `json:"tag_name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	UserID    uuid.UUID `json:"user_id"`
+}
+
+// TransactionTag is the short form of a tag listed on a transaction.
+type TransactionTag struct {
+	ID      uuid.UUID `json:"id"`
+	TagName string    `json:"tag_name"`
+}
+
+func tagFromDB(dbTag database.Tag) Tag {
+	return Tag{
+		ID:        dbTag.ID,
+		TagName:   dbTag.TagName,
+		CreatedAt: dbTag.CreatedAt,
+		UpdatedAt: dbTag.UpdatedAt,
+		UserID:    dbTag.UserID,
+	}
+}
+
+// uniqueIDs drops repeated IDs, keeping the first of each.
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	unique := []uuid.UUID{}
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// checkTagIDs makes sure every tag exists and belongs to the user,
+// returning the IDs without repeats.
+func checkTagIDs(ctx context.Context, q *database.Queries, userID uuid.UUID, tagIDs []uuid.UUID) ([]uuid.UUID, error) {
+	tagIDs = uniqueIDs(tagIDs)
+	if len(tagIDs) == 0 {
+		return tagIDs, nil
+	}
+
+	dbTags, err := q.GetUserTagsByIDs(ctx, database.GetUserTagsByIDsParams{
+		UserID: userID,
+		TagIds: tagIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(dbTags) != len(tagIDs) {
+		return nil, errTagNotOwned
+	}
+	return tagIDs, nil
+}
+
+// setTransactionTags replaces a transaction's tags.
+func setTransactionTags(ctx context.Context, qtx *database.Queries, transactionID uuid.UUID, tagIDs []uuid.UUID) error {
+	if err := qtx.DeleteTransactionTags(ctx, transactionID); err != nil {
+		return err
+	}
+	if len(tagIDs) == 0 {
+		return nil
+	}
+	_, err := qtx.AddTransactionTags(ctx, database.AddTransactionTagsParams{
+		TransactionIds: []uuid.UUID{transactionID},
+		TagIds:         tagIDs,
+	})
+	return err
+}
+
+// tagsByTransaction looks up the tags on each of the transactions.
+func tagsByTransaction(ctx context.Context, q *database.Queries, transactionIDs []uuid.UUID) (map[uuid.UUID][]TransactionTag, error) {
+	dbTags, err := q.GetTagsForTransactions(ctx, transactionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[uuid.UUID][]TransactionTag)
+	for _, tag := range dbTags {
+		tags[tag.TransactionID] = append(tags[tag.TransactionID], TransactionTag{
+			ID:      tag.ID,
+			TagName: tag.TagName,
+		})
+	}
+	return tags, nil
+}
+
+func (cfg *apiConfig) createTag(w http.ResponseWriter, req *http.Request) {
+	type parameters struct {
+		TagName string `json:"tag_name"`
+	}
+
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	decoder := json.NewDecoder(req.Body)
+	params := parameters{}
+	if err := decoder.Decode(&params); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't decode parameters", err)
+		return
+	}
+
+	params.TagName = strings.TrimSpace(params.TagName)
+	if params.TagName == "" {
+		respondWithError(w, http.StatusBadRequest, "Missing tag name", errors.New("invalid parameters"))
+		return
+	}
+
+	_, err = cfg.db.GetTagByName(req.Context(), database.GetTagByNameParams{
+		UserID:  userID,
+		TagName: params.TagName,
+	})
+	if err == nil {
+		respondWithError(w, http.StatusConflict, "Tag already exists", errors.New("duplicate tag"))
+		return
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't create tag", err)
+		return
+	}
+
+	dbTag, err := cfg.db.CreateTag(req.Context(), database.CreateTagParams{
+		ID:        uuid.New(),
+		TagName:   params.TagName,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+		UserID:    userID,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't create tag", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, tagFromDB(dbTag))
+}
+
+func (cfg *apiConfig) getTags(w http.ResponseWriter, req *http.Request) {
+	type userTag struct {
+		Tag
+		TransactionCount int64 `json:"transaction_count"`
+	}
+
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	dbTags, err := cfg.db.GetUserTags(req.Context(), userID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get tags", err)
+		return
+	}
+
+	tags := []userTag{}
+	for _, tag := range dbTags {
+		tags = append(tags, userTag{
+			Tag: Tag{
+				ID:        tag.ID,
+				TagName:   tag.TagName,
+				CreatedAt: tag.CreatedAt,
+				UpdatedAt: tag.UpdatedAt,
+				UserID:    tag.UserID,
+			},
+			TransactionCount: tag.TransactionCount,
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, tags)
+}
+
+func (cfg *apiConfig) getOwnedTag(w http.ResponseWriter, req *http.Request, userID uuid.UUID) (database.Tag, bool) {
+	tagID, err := uuid.Parse(req.PathValue("tagID"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid tag ID", err)
+		return database.Tag{}, false
+	}
+
+	dbTag, err := cfg.db.GetTagByID(req.Context(), tagID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Couldn't get tag", err)
+		return database.Tag{}, false
+	}
+	if dbTag.UserID != userID {
+		respondWithError(w, http.StatusForbidden, "Can't access tag", errors.New("unauthorized"))
+		return database.Tag{}, false
+	}
+
+	return dbTag, true
+}
+
+func (cfg *apiConfig) updateTag(w http.ResponseWriter, req *http.Request) {
+	type parameters struct {
+		TagName string `json:"tag_name"`
+	}
+
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	dbTag, ok := cfg.getOwnedTag(w, req, userID)
+	if !ok {
+		return
+	}
+
+	decoder := json.NewDecoder(req.Body)
+	params := parameters{}
+	if err := decoder.Decode(&params); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't decode parameters", err)
+		return
+	}
+
+	params.TagName = strings.TrimSpace(params.TagName)
+	if params.TagName == "" {
+		respondWithError(w, http.StatusBadRequest, "Missing tag name", errors.New("invalid parameters"))
+		return
+	}
+
+	existing, err := cfg.db.GetTagByName(req.Context(), database.GetTagByNameParams{
+		UserID:  userID,
+		TagName: params.TagName,
+	})
+	if err == nil && existing.ID != dbTag.ID {
+		respondWithError(w, http.StatusConflict, "Tag already exists, merge the tags instead", errors.New("duplicate tag"))
+		return
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't update tag", err)
+		return
+	}
+
+	updatedTag, err := cfg.db.UpdateTag(req.Context(), database.UpdateTagParams{
+		ID:      dbTag.ID,
+		TagName: params.TagName,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't update tag", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, tagFromDB(updatedTag))
+}
+
+// deleteTag removes the tag from every transaction and deletes it.
+func (cfg *apiConfig) deleteTag(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	dbTag, ok := cfg.getOwnedTag(w, req, userID)
+	if !ok {
+		return
+	}
+
+	if err := cfg.db.DeleteTag(req.Context(), dbTag.ID); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't delete tag", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// mergeTag moves every transaction from the tag in the path onto the target
+// tag, then deletes the merged tag.
+func (cfg *apiConfig) mergeTag(w http.ResponseWriter, req *http.Request) {
+	type parameters struct {
+		TargetTagID uuid.UUID `json:"target_tag_id"`
+	}
+
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	sourceTag, ok := cfg.getOwnedTag(w, req, userID)
+	if !ok {
+		return
+	}
+
+	decoder := json.NewDecoder(req.Body)
+	params := parameters{}
+	if err := decoder.Decode(&params); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't decode parameters", err)
+		return
+	}
+
+	if params.TargetTagID == uuid.Nil || params.TargetTagID == sourceTag.ID {
+		respondWithError(w, http.StatusBadRequest, "Pick a different tag to merge into", errors.New("invalid parameters"))
+		return
+	}
+
+	targetTag, err := cfg.db.GetTagByID(req.Context(), params.TargetTagID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Couldn't get target tag", err)
+		return
+	}
+	if targetTag.UserID != userID {
+		respondWithError(w, http.StatusForbidden, "Can't access target tag", errors.New("unauthorized"))
+		return
+	}
+
+	tx, err := cfg.dbConn.BeginTx(req.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't merge tags", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	err = qtx.MoveTagTransactions(req.Context(), database.MoveTagTransactionsParams{
+		TargetID: targetTag.ID,
+		SourceID: sourceTag.ID,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't merge tags", err)
+		return
+	}
+	if err := qtx.DeleteTag(req.Context(), sourceTag.ID); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't merge tags", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't merge tags", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, tagFromDB(targetTag))
+}
+
+// tagTransactions adds and removes tags on many transactions at once.
+func (cfg *apiConfig) tagTransactions(w http.ResponseWriter, req *http.Request) {
+	type parameters struct {
+		TransactionIDs []uuid.UUID `json:"transaction_ids"`
+		AddTagIDs      []uuid.UUID `json:"add_tag_ids"`
+		RemoveTagIDs   []uuid.UUID `json:"remove_tag_ids"`
+	}
+
+	type response struct {
+		Added   int64 `json:"added"`
+		Removed int64 `json:"removed"`
+	}
+
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	decoder := json.NewDecoder(req.Body)
+	params := parameters{}
+	if err := decoder.Decode(&params); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't decode parameters", err)
+		return
+	}
+
+	transactionIDs := uniqueIDs(params.TransactionIDs)
+	if len(transactionIDs) == 0 || len(params.AddTagIDs)+len(params.RemoveTagIDs) == 0 {
+		respondWithError(w, http.StatusBadRequest, "Missing transactions and/or tags", errors.New("invalid parameters"))
+		return
+	}
+
+	ownedIDs, err := cfg.db.GetUserTransactionIDs(req.Context(), database.GetUserTransactionIDsParams{
+		UserID:         userID,
+		TransactionIds: transactionIDs,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get transactions", err)
+		return
+	}
+	if len(ownedIDs) != len(transactionIDs) {
+		respondWithError(w, http.StatusForbidden, "Can't tag transactions", errors.New("unauthorized"))
+		return
+	}
+
+	addTagIDs, err := checkTagIDs(req.Context(), cfg.db, userID, params.AddTagIDs)
+	if err == nil {
+		params.RemoveTagIDs, err = checkTagIDs(req.Context(), cfg.db, userID, params.RemoveTagIDs)
+	}
+	if errors.Is(err, errTagNotOwned) {
+		respondWithError(w, http.StatusForbidden, "Can't use tag", err)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get tags", err)
+		return
+	}
+
+	tx, err := cfg.dbConn.BeginTx(req.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't tag transactions", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	resp := response{}
+	if len(params.RemoveTagIDs) > 0 {
+		resp.Removed, err = qtx.RemoveTransactionTags(req.Context(), database.RemoveTransactionTagsParams{
+			TransactionIds: transactionIDs,
+			TagIds:         params.RemoveTagIDs,
+		})
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Couldn't untag transactions", err)
+			return
+		}
+	}
+	if len(addTagIDs) > 0 {
+		resp.Added, err = qtx.AddTransactionTags(req.Context(), database.AddTransactionTagsParams{
+			TransactionIds: transactionIDs,
+			TagIds:         addTagIDs,
+		})
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Couldn't tag transactions", err)
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't tag transactions", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, resp)
+}
+
+// getTagReport totals the transactions under each tag. A transaction with
+// several tags counts toward each of them, and transfers are left out.
+func (cfg *apiConfig) getTagReport(w http.ResponseWriter, req *http.Request) {
+	type tagTotal struct {
+		TagID            uuid.UUID       `json:"tag_id"`
+		TagName          string          `json:"tag_name"`
+		TransactionCount int64           `json:"transaction_count"`
+		Spent            decimal.Decimal `json:"spent"`
+		Income           decimal.Decimal `json:"income"`
+		Net              decimal.Decimal `json:"net"`
+	}
+
+	type response struct {
+		StartDate *time.Time `json:"start_date"`
+		EndDate   *time.Time `json:"end_date"`
+		Tags      []tagTotal `json:"tags"`
+	}
+
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	startDate, endDate, err := dateRangeFromQuery(req.URL.Query())
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), errors.New("invalid parameters"))
+		return
+	}
+
+	dbTotals, err := cfg.db.GetTagSpendingReport(req.Context(), database.GetTagSpendingReportParams{
+		StartDate: startDate,
+		EndDate:   endDate,
+		UserID:    userID,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't build tag report", err)
+		return
+	}
+
+	resp := response{
+		StartDate: nullTimePtr(startDate),
+		Tags:      []tagTotal{},
+	}
+	if endDate.Valid {
+		lastDay := endDate.Time.AddDate(0, 0, -1)
+		resp.EndDate = &lastDay
+	}
+	for _, total := range dbTotals {
+		resp.Tags = append(resp.Tags, tagTotal{
+			TagID:            total.TagID,
+			TagName:          total.TagName,
+			TransactionCount: total.TransactionCount,
+			Spent:            total.Spent,
+			Income:           total.Income,
+			Net:              total.Net,
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, resp)
+}
+
+// replaceTransactionTags swaps a transaction's tags in one database
+// transaction.
+func (cfg *apiConfig) replaceTransactionTags(ctx context.Context, transactionID uuid.UUID, tagIDs []uuid.UUID) error {
+	tx, err := cfg.dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := setTransactionTags(ctx, cfg.db.WithTx(tx), transactionID, tagIDs); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
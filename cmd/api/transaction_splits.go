@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jkk290/budget-tui/internal/database"
+	"github.com/shopspring/decimal"
+)
+
+var errCategoryNotOwned = errors.New("category belongs to another user")
+
+type TransactionSplit struct {
+	ID           uuid.UUID       `json:"id"`
+	CategoryID   uuid.UUID       `json:"category_id"`
+	CategoryName string          `json:"category_name"`
+	Amount       decimal.Decimal `json:"amount"`
+	Memo         string          `json:"memo"`
+}
+
+type splitParameters struct {
+	CategoryID uuid.UUID       `json:"category_id"`
+	Amount     decimal.Decimal `json:"amount"`
+	Memo       string          `json:"memo"`
+}
+
+func validateSplits(amount decimal.Decimal, splits []splitParameters) error {
+	if len(splits) == 0 {
+		return nil
+	}
+	if len(splits) < 2 {
+		return errors.New("a split transaction needs at least two lines")
+	}
+
+	total := decimal.Zero
+	for _, split := range splits {
+		if split.Amount.IsZero() {
+			return errors.New("split amounts can't be zero")
+		}
+		total = total.Add(split.Amount)
+	}
+
+	if !total.Equal(amount) {
+		return errors.New("split amounts must add up to the transaction amount")
+	}
+
+	return nil
+}
+
+// checkTransactionCategories makes sure the transaction's category and
+// every split line's category belong to the user. Nil IDs are skipped.
+func checkTransactionCategories(ctx context.Context, q *database.Queries, userID, categoryID uuid.UUID, splits []splitParameters) error {
+	categoryIDs := []uuid.UUID{categoryID}
+	for _, split := range splits {
+		categoryIDs = append(categoryIDs, split.CategoryID)
+	}
+
+	for _, id := range uniqueIDs(categoryIDs) {
+		if id == uuid.Nil {
+			continue
+		}
+		dbCategory, err := q.GetCategoryByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if dbCategory.UserID != userID {
+			return errCategoryNotOwned
+		}
+	}
+	return nil
+}
+
+func saveTransactionSplits(ctx context.Context, qtx *database.Queries, transactionID uuid.UUID, splits []splitParameters) ([]TransactionSplit, error) {
+	if err := qtx.DeleteTransactionSplits(ctx, transactionID); err != nil {
+		return nil, err
+	}
+
+	var saved []TransactionSplit
+	for i, split := range splits {
+		splitCategoryID := uuid.NullUUID{
+			UUID:  split.CategoryID,
+			Valid: split.CategoryID != uuid.Nil,
+		}
+
+		dbSplit, err := qtx.AddTransactionSplit(ctx, database.AddTransactionSplitParams{
+			ID:            uuid.New(),
+			TransactionID: transactionID,
+			CategoryID:    splitCategoryID,
+			Amount:        split.Amount,
+			Memo:          split.Memo,
+			SortOrder:     int32(i),
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		saved = append(saved, TransactionSplit{
+			ID:         dbSplit.ID,
+			CategoryID: dbSplit.CategoryID.UUID,
+			Amount:     dbSplit.Amount,
+			Memo:       dbSplit.Memo,
+		})
+	}
+
+	return saved, nil
+}
+
+// splitParametersFromDB turns saved split lines back into parameters, so
+// kept splits can be checked like new ones.
+func splitParametersFromDB(dbSplits []database.TransactionSplit) []splitParameters {
+	splits := make([]splitParameters, len(dbSplits))
+	for i, split := range dbSplits {
+		splits[i] = splitParameters{
+			CategoryID: split.CategoryID.UUID,
+			Amount:     split.Amount,
+			Memo:       split.Memo,
+		}
+	}
+	return splits
+}
+
+// updateTransactionWithSplits saves the transaction and replaces its split
+// lines. Nil splits keep the current lines as they are.
+func (cfg *apiConfig) updateTransactionWithSplits(ctx context.Context, arg database.UpdateTransactionParams, splits []splitParameters) (database.Transaction, []TransactionSplit, error) {
+	tx, err := cfg.dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		return database.Transaction{}, nil, err
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	updatedTransaction, err := qtx.UpdateTransaction(ctx, arg)
+	if err != nil {
+		return database.Transaction{}, nil, err
+	}
+
+	var saved []TransactionSplit
+	if splits != nil {
+		saved, err = saveTransactionSplits(ctx, qtx, updatedTransaction.ID, splits)
+		if err != nil {
+			return database.Transaction{}, nil, err
+		}
+	} else {
+		dbSplits, err := qtx.GetTransactionSplits(ctx, updatedTransaction.ID)
+		if err != nil {
+			return database.Transaction{}, nil, err
+		}
+		for _, split := range dbSplits {
+			saved = append(saved, TransactionSplit{
+				ID:         split.ID,
+				CategoryID: split.CategoryID.UUID,
+				Amount:     split.Amount,
+				Memo:       split.Memo,
+			})
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return database.Transaction{}, nil, err
+	}
+
+	return updatedTransaction, saved, nil
+}
@@ -1,9 +1,14 @@
 package main
 
 import (
+	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,32 +17,52 @@ import (
 )
 
 type Transaction struct {
-	ID            uuid.UUID       `json:"id"`
-	Amount        decimal.Decimal `json:"amount"`
-	TxDescription string          `json:"tx_description"`
-	TxDate        time.Time       `json:"tx_date"`
-	CreatedAt     time.Time       `json:"created_at"`
-	UpdatedAt     time.Time       `json:"updated_at"`
-	Posted        bool            `json:"posted"`
-	AccountID     uuid.UUID       `json:"account_id"`
-	CategoryID    uuid.UUID       `json:"category_id"`
+	ID              uuid.UUID          `json:"id"`
+	Amount          decimal.Decimal    `json:"amount"`
+	TxDescription   string             `json:"tx_description"`
+	Memo            string             `json:"memo"`
+	TxDate          time.Time          `json:"tx_date"`
+	CreatedAt       time.Time          `json:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+	Posted          bool               `json:"posted"`
+	AccountID       uuid.UUID          `json:"account_id"`
+	CategoryID      uuid.UUID          `json:"category_id"`
+	TransferID      uuid.UUID          `json:"transfer_id"`
+	PayeeID         uuid.UUID          `json:"payee_id"`
+	Reconciled      bool               `json:"reconciled"`
+	Splits          []TransactionSplit `json:"splits,omitempty"`
+	Tags            []TransactionTag   `json:"tags,omitempty"`
+	AttachmentCount int64              `json:"attachment_count"`
+}
+
+// TransactionPage is one page of a transaction listing. NextCursor is empty
+// on the last page.
+type TransactionPage struct {
+	Transactions []Transaction `json:"transactions"`
+	NextCursor   string        `json:"next_cursor"`
 }
 
 func (cfg *apiConfig) addTransaction(w http.ResponseWriter, req *http.Request) {
 	type parameters struct {
-		Amount        decimal.Decimal `json:"amount"`
-		TxDescription string          `json:"tx_description"`
-		TxDate        time.Time       `json:"tx_date"`
-		Posted        bool            `json:"posted"`
-		AccountID     uuid.UUID       `json:"account_id"`
-		CategoryID    uuid.UUID       `json:"category_id"`
+		Amount        decimal.Decimal   `json:"amount"`
+		TxDescription string            `json:"tx_description"`
+		Memo          string            `json:"memo"`
+		TxDate        time.Time         `json:"tx_date"`
+		Posted        bool              `json:"posted"`
+		AccountID     uuid.UUID         `json:"account_id"`
+		CategoryID    uuid.UUID         `json:"category_id"`
+		PayeeID       uuid.UUID         `json:"payee_id"`
+		PayeeName     string            `json:"payee_name"`
+		Splits        []splitParameters `json:"splits"`
+		TagIDs        []uuid.UUID       `json:"tag_ids"`
 	}
 
 	type response struct {
 		Transaction
+		PossibleDuplicates []uuid.UUID `json:"possible_duplicates"`
 	}
 
-	_, err := checkToken(req.Header, cfg.jwtSecret)
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
 	if err != nil {
 		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
 		return
@@ -55,31 +80,148 @@ func (cfg *apiConfig) addTransaction(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	dbAccount, err := cfg.db.GetAccountByID(req.Context(), params.AccountID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get account", err)
+		return
+	}
+	if dbAccount.UserID != userID {
+		respondWithError(w, http.StatusForbidden, "Can't add transactions to account", errors.New("unauthorized"))
+		return
+	}
+
+	if params.Posted {
+		err := checkNotInReconciledPeriod(req.Context(), cfg.db, params.AccountID, params.TxDate)
+		if errors.Is(err, errReconciledPeriod) {
+			respondWithError(w, http.StatusConflict, "Cleared transactions can't be dated on or before the last reconciled statement", err)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Couldn't get reconciliations", err)
+			return
+		}
+	}
+
+	if err := validateSplits(params.Amount, params.Splits); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	err = checkTransactionCategories(req.Context(), cfg.db, userID, params.CategoryID, params.Splits)
+	if errors.Is(err, errCategoryNotOwned) {
+		respondWithError(w, http.StatusForbidden, "Can't use category", err)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get category", err)
+		return
+	}
+
 	txCategoryID := uuid.NullUUID{
 		UUID:  uuid.Nil,
 		Valid: false,
 	}
-	if params.CategoryID != uuid.Nil {
+	if params.CategoryID != uuid.Nil && len(params.Splits) == 0 {
 		txCategoryID.UUID = params.CategoryID
 		txCategoryID.Valid = true
 	}
 
-	dbTransaction, err := cfg.db.AddTransaction(req.Context(), database.AddTransactionParams{
+	tagIDs, err := checkTagIDs(req.Context(), cfg.db, userID, params.TagIDs)
+	if errors.Is(err, errTagNotOwned) {
+		respondWithError(w, http.StatusForbidden, "Can't use tag", err)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get tags", err)
+		return
+	}
+
+	engine, err := cfg.loadRules(req.Context(), userID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't load rules", err)
+		return
+	}
+
+	matcher, err := cfg.newMatcher(req.Context(), params.AccountID, []time.Time{params.TxDate}, cfg.matchWindow)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get existing transactions", err)
+		return
+	}
+
+	tx, err := cfg.dbConn.BeginTx(req.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't create transaction", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	dbPayee, hasPayee, err := resolvePayee(req.Context(), qtx, userID, params.PayeeID, params.PayeeName)
+	if errors.Is(err, errPayeeNotOwned) {
+		respondWithError(w, http.StatusForbidden, "Can't use payee", err)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get payee", err)
+		return
+	}
+
+	txPayeeID := uuid.NullUUID{}
+	if hasPayee {
+		txPayeeID = uuid.NullUUID{UUID: dbPayee.ID, Valid: true}
+		// Fall back to the payee's default category when none was picked.
+		if params.CategoryID == uuid.Nil && len(params.Splits) == 0 {
+			txCategoryID = dbPayee.DefaultCategoryID
+		}
+	}
+
+	addParams := database.AddTransactionParams{
 		ID:            uuid.New(),
 		Amount:        params.Amount,
 		TxDescription: params.TxDescription,
+		Memo:          strings.TrimSpace(params.Memo),
 		TxDate:        params.TxDate,
 		CreatedAt:     time.Now(),
 		UpdatedAt:     time.Now(),
 		Posted:        params.Posted,
 		AccountID:     params.AccountID,
 		CategoryID:    txCategoryID,
-	})
+		TransferID:    uuid.NullUUID{},
+		PayeeID:       txPayeeID,
+	}
+	applyRules(engine, &addParams)
+	if len(params.Splits) > 0 {
+		addParams.CategoryID = uuid.NullUUID{}
+	}
+
+	dbTransaction, err := qtx.AddTransaction(req.Context(), addParams)
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Couldn't create transaction", err)
 		return
 	}
 
+	splits, err := saveTransactionSplits(req.Context(), qtx, dbTransaction.ID, params.Splits)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't save transaction splits", err)
+		return
+	}
+
+	if err := setTransactionTags(req.Context(), qtx, dbTransaction.ID, tagIDs); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't save transaction tags", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't create transaction", err)
+		return
+	}
+
+	tags, err := tagsByTransaction(req.Context(), cfg.db, []uuid.UUID{dbTransaction.ID})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get transaction tags", err)
+		return
+	}
+
 	// dbAmountFloat, err := strconv.ParseFloat(dbTransaction.Amount, 64)
 	// if err != nil {
 	// 	respondWithError(w, http.StatusInternalServerError, "Couldn't parse amount", err)
@@ -91,29 +233,139 @@ func (cfg *apiConfig) addTransaction(w http.ResponseWriter, req *http.Request) {
 			ID:            dbTransaction.ID,
 			Amount:        dbTransaction.Amount,
 			TxDescription: dbTransaction.TxDescription,
+			Memo:          dbTransaction.Memo,
 			TxDate:        dbTransaction.TxDate,
 			CreatedAt:     dbTransaction.CreatedAt,
 			UpdatedAt:     dbTransaction.UpdatedAt,
 			Posted:        dbTransaction.Posted,
 			AccountID:     dbTransaction.AccountID,
 			CategoryID:    dbTransaction.CategoryID.UUID,
+			TransferID:    dbTransaction.TransferID.UUID,
+			PayeeID:       dbTransaction.PayeeID.UUID,
+			Reconciled:    dbTransaction.ReconciliationID.Valid,
+			Splits:        splits,
+			Tags:          tags[dbTransaction.ID],
 		},
+		PossibleDuplicates: matcher.Similar(params.TxDate, params.Amount),
 	})
 }
 
+// likeEscaper escapes the LIKE wildcards so search text is matched
+// literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// uuidsFromQuery reads every value of a repeatable ID parameter. Each value
+// may also be a comma separated list.
+func uuidsFromQuery(query url.Values, key string) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for _, value := range query[key] {
+		for _, idStr := range strings.Split(value, ",") {
+			idStr = strings.TrimSpace(idStr)
+			if idStr == "" {
+				continue
+			}
+			id, err := uuid.Parse(idStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s %q", key, idStr)
+			}
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// transactionFilterFromQuery reads the optional search filters for listing
+// transactions. Every filter that is set must match.
+func transactionFilterFromQuery(userID uuid.UUID, query url.Values) (database.GetUserTransactionsParams, error) {
+	params := database.GetUserTransactionsParams{UserID: userID}
+
+	startDate, endDate, err := dateRangeFromQuery(query)
+	if err != nil {
+		return database.GetUserTransactionsParams{}, err
+	}
+	params.StartDate = startDate
+	params.EndDate = endDate
+
+	if minStr := query.Get("min_amount"); minStr != "" {
+		minAmount, err := decimal.NewFromString(minStr)
+		if err != nil {
+			return database.GetUserTransactionsParams{}, errors.New("invalid min_amount")
+		}
+		params.MinAmount = decimal.NewNullDecimal(minAmount)
+	}
+	if maxStr := query.Get("max_amount"); maxStr != "" {
+		maxAmount, err := decimal.NewFromString(maxStr)
+		if err != nil {
+			return database.GetUserTransactionsParams{}, errors.New("invalid max_amount")
+		}
+		params.MaxAmount = decimal.NewNullDecimal(maxAmount)
+	}
+	if params.MinAmount.Valid && params.MaxAmount.Valid && params.MinAmount.Decimal.GreaterThan(params.MaxAmount.Decimal) {
+		return database.GetUserTransactionsParams{}, errors.New("min_amount must not be greater than max_amount")
+	}
+
+	params.AccountIds, err = uuidsFromQuery(query, "account_id")
+	if err != nil {
+		return database.GetUserTransactionsParams{}, err
+	}
+	params.CategoryIds, err = uuidsFromQuery(query, "category_id")
+	if err != nil {
+		return database.GetUserTransactionsParams{}, err
+	}
+	params.TagIds, err = uuidsFromQuery(query, "tag_id")
+	if err != nil {
+		return database.GetUserTransactionsParams{}, err
+	}
+
+	if groupStr := query.Get("group_id"); groupStr != "" {
+		groupID, err := uuid.Parse(groupStr)
+		if err != nil {
+			return database.GetUserTransactionsParams{}, errors.New("invalid group_id")
+		}
+		params.GroupID = uuid.NullUUID{UUID: groupID, Valid: true}
+	}
+
+	if postedStr := query.Get("posted"); postedStr != "" {
+		posted, err := strconv.ParseBool(postedStr)
+		if err != nil {
+			return database.GetUserTransactionsParams{}, errors.New("invalid posted, expected true or false")
+		}
+		params.Posted = sql.NullBool{Bool: posted, Valid: true}
+	}
+
+	if search := strings.TrimSpace(query.Get("q")); search != "" {
+		params.Search = sql.NullString{String: likeEscaper.Replace(search), Valid: true}
+	}
+
+	return params, nil
+}
+
 func (cfg *apiConfig) getUserTransactions(w http.ResponseWriter, req *http.Request) {
 	type userTransaction struct {
-		ID            uuid.UUID       `json:"id"`
-		Amount        decimal.Decimal `json:"amount"`
-		TxDescription string          `json:"tx_description"`
-		TxDate        time.Time       `json:"tx_date"`
-		CreatedAt     time.Time       `json:"created_at"`
-		UpdatedAt     time.Time       `json:"updated_at"`
-		Posted        bool            `json:"posted"`
-		AccountID     uuid.UUID       `json:"account_id"`
-		CategoryID    uuid.UUID       `json:"category_id"`
-		AccountName   string          `json:"account_name"`
-		CategoryName  string          `json:"category_name"`
+		ID              uuid.UUID          `json:"id"`
+		Amount          decimal.Decimal    `json:"amount"`
+		TxDescription   string             `json:"tx_description"`
+		Memo            string             `json:"memo"`
+		TxDate          time.Time          `json:"tx_date"`
+		CreatedAt       time.Time          `json:"created_at"`
+		UpdatedAt       time.Time          `json:"updated_at"`
+		Posted          bool               `json:"posted"`
+		AccountID       uuid.UUID          `json:"account_id"`
+		CategoryID      uuid.UUID          `json:"category_id"`
+		TransferID      uuid.UUID          `json:"transfer_id"`
+		PayeeID         uuid.UUID          `json:"payee_id"`
+		Reconciled      bool               `json:"reconciled"`
+		AccountName     string             `json:"account_name"`
+		CategoryName    string             `json:"category_name"`
+		PayeeName       string             `json:"payee_name"`
+		Splits          []TransactionSplit `json:"splits,omitempty"`
+		Tags            []TransactionTag   `json:"tags,omitempty"`
+		AttachmentCount int64              `json:"attachment_count"`
+	}
+
+	type response struct {
+		Transactions []userTransaction `json:"transactions"`
+		NextCursor   string            `json:"next_cursor"`
 	}
 
 	userID, err := checkToken(req.Header, cfg.jwtSecret)
@@ -122,39 +374,112 @@ func (cfg *apiConfig) getUserTransactions(w http.ResponseWriter, req *http.Reque
 		return
 	}
 
-	dbTransactions, err := cfg.db.GetUserTransactions(req.Context(), userID)
+	params, err := transactionFilterFromQuery(userID, req.URL.Query())
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), errors.New("invalid parameters"))
+		return
+	}
+
+	page, err := pageFromQuery(req.URL.Query())
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), errors.New("invalid parameters"))
+		return
+	}
+	params.CursorDate = page.CursorDate
+	params.CursorID = page.CursorID
+	params.RowLimit = page.rowLimit()
+
+	dbTransactions, err := cfg.db.GetUserTransactions(req.Context(), params)
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Couldn't get user transactions", err)
+		return
 	}
 
-	var transactions []userTransaction
+	nextCursor := ""
+	if len(dbTransactions) > int(page.Limit) {
+		dbTransactions = dbTransactions[:page.Limit]
+		last := dbTransactions[len(dbTransactions)-1]
+		nextCursor = encodeCursor(last.TxDate, last.ID)
+	}
+
+	transactionIDs := make([]uuid.UUID, len(dbTransactions))
+	for i, tx := range dbTransactions {
+		transactionIDs[i] = tx.ID
+	}
+
+	dbSplits, err := cfg.db.GetTransactionSplitsForTransactions(req.Context(), transactionIDs)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get transaction splits", err)
+		return
+	}
+
+	splitsByTransaction := make(map[uuid.UUID][]TransactionSplit)
+	for _, split := range dbSplits {
+		splitsByTransaction[split.TransactionID] = append(splitsByTransaction[split.TransactionID], TransactionSplit{
+			ID:           split.ID,
+			CategoryID:   split.CategoryID.UUID,
+			CategoryName: split.CategoryName.String,
+			Amount:       split.Amount,
+			Memo:         split.Memo,
+		})
+	}
+
+	tags, err := tagsByTransaction(req.Context(), cfg.db, transactionIDs)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get transaction tags", err)
+		return
+	}
+
+	counts, err := attachmentCounts(req.Context(), cfg.db, transactionIDs)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get attachment counts", err)
+		return
+	}
+
+	transactions := []userTransaction{}
 	for _, tx := range dbTransactions {
 		transactions = append(transactions, userTransaction{
-			ID:            tx.ID,
-			Amount:        tx.Amount,
-			TxDescription: tx.TxDescription,
-			TxDate:        tx.TxDate,
-			CreatedAt:     tx.CreatedAt,
-			UpdatedAt:     tx.UpdatedAt,
-			Posted:        tx.Posted,
-			AccountID:     tx.AccountID,
-			CategoryID:    tx.CategoryID.UUID,
-			AccountName:   tx.AccountName,
-			CategoryName:  tx.CategoryName,
+			ID:              tx.ID,
+			Amount:          tx.Amount,
+			TxDescription:   tx.TxDescription,
+			Memo:            tx.Memo,
+			TxDate:          tx.TxDate,
+			CreatedAt:       tx.CreatedAt,
+			UpdatedAt:       tx.UpdatedAt,
+			Posted:          tx.Posted,
+			AccountID:       tx.AccountID,
+			CategoryID:      tx.CategoryID.UUID,
+			TransferID:      tx.TransferID.UUID,
+			PayeeID:         tx.PayeeID.UUID,
+			Reconciled:      tx.ReconciliationID.Valid,
+			AccountName:     tx.AccountName,
+			CategoryName:    tx.CategoryName.String,
+			PayeeName:       tx.PayeeName.String,
+			Splits:          splitsByTransaction[tx.ID],
+			Tags:            tags[tx.ID],
+			AttachmentCount: counts[tx.ID],
 		})
 	}
 
-	respondWithJSON(w, http.StatusOK, transactions)
+	respondWithJSON(w, http.StatusOK, response{
+		Transactions: transactions,
+		NextCursor:   nextCursor,
+	})
 }
 
 func (cfg *apiConfig) updateTransaction(w http.ResponseWriter, req *http.Request) {
 	type parameters struct {
-		Amount        decimal.Decimal `json:"amount"`
-		TxDescription string          `json:"tx_description"`
-		TxDate        time.Time       `json:"tx_date"`
-		Posted        bool            `json:"posted"`
-		AccountID     uuid.UUID       `json:"account_id"`
-		CategoryID    uuid.UUID       `json:"category_id"`
+		Amount        decimal.Decimal   `json:"amount"`
+		TxDescription string            `json:"tx_description"`
+		Memo          string            `json:"memo"`
+		TxDate        time.Time         `json:"tx_date"`
+		Posted        bool              `json:"posted"`
+		AccountID     uuid.UUID         `json:"account_id"`
+		CategoryID    uuid.UUID         `json:"category_id"`
+		PayeeID       uuid.UUID         `json:"payee_id"`
+		PayeeName     string            `json:"payee_name"`
+		Splits        []splitParameters `json:"splits"`
+		TagIDs        []uuid.UUID       `json:"tag_ids"`
 	}
 
 	type response struct {
@@ -196,29 +521,167 @@ func (cfg *apiConfig) updateTransaction(w http.ResponseWriter, req *http.Request
 		return
 	}
 
+	// Either side of a transfer can be moved too, so this covers both.
+	dbAccount, err := cfg.db.GetAccountByID(req.Context(), params.AccountID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get account", err)
+		return
+	}
+	if dbAccount.UserID != userID {
+		respondWithError(w, http.StatusForbidden, "Can't move transactions to account", errors.New("unauthorized"))
+		return
+	}
+
+	if err := validateSplits(params.Amount, params.Splits); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	err = checkTransactionCategories(req.Context(), cfg.db, userID, params.CategoryID, params.Splits)
+	if errors.Is(err, errCategoryNotOwned) {
+		respondWithError(w, http.StatusForbidden, "Can't use category", err)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get category", err)
+		return
+	}
+
 	updatedCatergoryID := uuid.NullUUID{
 		UUID:  uuid.Nil,
 		Valid: false,
 	}
-	if params.CategoryID != uuid.Nil {
+	if params.CategoryID != uuid.Nil && len(params.Splits) == 0 {
 		updatedCatergoryID.UUID = params.CategoryID
 		updatedCatergoryID.Valid = true
 	}
 
-	updatedTransaction, err := cfg.db.UpdateTransaction(req.Context(), database.UpdateTransactionParams{
+	dbTransaction, err := cfg.db.GetTransactionByID(req.Context(), transactionID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get transaction", err)
+		return
+	}
+
+	if err := checkNotReconciled(req.Context(), cfg.db, dbTransaction); err != nil {
+		if errors.Is(err, errTransactionReconciled) {
+			respondWithError(w, http.StatusConflict, "Reconciled transactions can't be changed", err)
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get transaction", err)
+		return
+	}
+
+	// Only a change that moves a cleared transaction is checked, so older
+	// cleared entries can still be edited in place.
+	moved := !dbTransaction.Posted || !params.TxDate.Equal(dbTransaction.TxDate) || params.AccountID != dbTransaction.AccountID
+	if params.Posted && moved {
+		err := checkNotInReconciledPeriod(req.Context(), cfg.db, params.AccountID, params.TxDate)
+		if errors.Is(err, errReconciledPeriod) {
+			respondWithError(w, http.StatusConflict, "Cleared transactions can't be dated on or before the last reconciled statement", err)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Couldn't get reconciliations", err)
+			return
+		}
+	}
+
+	// Leaving splits out keeps the transaction's split lines as they are,
+	// so they still have to add up to the new amount and the transaction
+	// stays without a category.
+	if params.Splits == nil {
+		dbSplits, err := cfg.db.GetTransactionSplits(req.Context(), transactionID)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Couldn't get transaction splits", err)
+			return
+		}
+		if len(dbSplits) > 0 {
+			if err := validateSplits(params.Amount, splitParametersFromDB(dbSplits)); err != nil {
+				respondWithError(w, http.StatusBadRequest, err.Error(), err)
+				return
+			}
+			updatedCatergoryID = uuid.NullUUID{}
+		}
+	}
+
+	// Leaving tag_ids out keeps the transaction's tags as they are.
+	tagIDs, err := checkTagIDs(req.Context(), cfg.db, userID, params.TagIDs)
+	if errors.Is(err, errTagNotOwned) {
+		respondWithError(w, http.StatusForbidden, "Can't use tag", err)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get tags", err)
+		return
+	}
+
+	dbPayee, hasPayee, err := resolvePayee(req.Context(), cfg.db, userID, params.PayeeID, params.PayeeName)
+	if errors.Is(err, errPayeeNotOwned) {
+		respondWithError(w, http.StatusForbidden, "Can't use payee", err)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get payee", err)
+		return
+	}
+
+	updatedPayeeID := uuid.NullUUID{}
+	if hasPayee {
+		updatedPayeeID = uuid.NullUUID{UUID: dbPayee.ID, Valid: true}
+	}
+
+	updateParams := database.UpdateTransactionParams{
 		ID:            transactionID,
 		Amount:        params.Amount,
 		TxDescription: params.TxDescription,
+		Memo:          strings.TrimSpace(params.Memo),
 		TxDate:        params.TxDate,
 		Posted:        params.Posted,
 		AccountID:     params.AccountID,
 		CategoryID:    updatedCatergoryID,
-	})
+		PayeeID:       updatedPayeeID,
+	}
+
+	if dbTransaction.TransferID.Valid && len(params.Splits) > 0 {
+		respondWithError(w, http.StatusBadRequest, "Transfers can't be split", errors.New("invalid parameters"))
+		return
+	}
+
+	var updatedTransaction database.Transaction
+	var splits []TransactionSplit
+	if dbTransaction.TransferID.Valid {
+		updatedTransaction, err = cfg.updateTransfer(req.Context(), dbTransaction.TransferID, updateParams)
+	} else {
+		updatedTransaction, splits, err = cfg.updateTransactionWithSplits(req.Context(), updateParams, params.Splits)
+	}
+	if errors.Is(err, errTransferSameAccount) {
+		respondWithError(w, http.StatusBadRequest, "Transfer accounts must be different", err)
+		return
+	}
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Couldn't update transaction", err)
 		return
 	}
 
+	if params.TagIDs != nil {
+		if err := cfg.replaceTransactionTags(req.Context(), transactionID, tagIDs); err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Couldn't save transaction tags", err)
+			return
+		}
+	}
+
+	tags, err := tagsByTransaction(req.Context(), cfg.db, []uuid.UUID{transactionID})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get transaction tags", err)
+		return
+	}
+
+	counts, err := attachmentCounts(req.Context(), cfg.db, []uuid.UUID{transactionID})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get attachment counts", err)
+		return
+	}
+
 	// updatedAmountFloat, err := strconv.ParseFloat(updatedTransaction.Amount, 64)
 	// if err != nil {
 	// 	respondWithError(w, http.StatusInternalServerError, "Couldn't parse amount", err)
@@ -227,15 +690,22 @@ func (cfg *apiConfig) updateTransaction(w http.ResponseWriter, req *http.Request
 
 	respondWithJSON(w, http.StatusOK, response{
 		Transaction: Transaction{
-			ID:            updatedTransaction.ID,
-			Amount:        updatedTransaction.Amount,
-			TxDescription: updatedTransaction.TxDescription,
-			TxDate:        updatedTransaction.TxDate,
-			Posted:        updatedTransaction.Posted,
-			CreatedAt:     updatedTransaction.CreatedAt,
-			UpdatedAt:     updatedTransaction.UpdatedAt,
-			AccountID:     updatedTransaction.AccountID,
-			CategoryID:    updatedTransaction.CategoryID.UUID,
+			ID:              updatedTransaction.ID,
+			Amount:          updatedTransaction.Amount,
+			TxDescription:   updatedTransaction.TxDescription,
+			Memo:            updatedTransaction.Memo,
+			TxDate:          updatedTransaction.TxDate,
+			Posted:          updatedTransaction.Posted,
+			CreatedAt:       updatedTransaction.CreatedAt,
+			UpdatedAt:       updatedTransaction.UpdatedAt,
+			AccountID:       updatedTransaction.AccountID,
+			CategoryID:      updatedTransaction.CategoryID.UUID,
+			TransferID:      updatedTransaction.TransferID.UUID,
+			PayeeID:         updatedTransaction.PayeeID.UUID,
+			Reconciled:      updatedTransaction.ReconciliationID.Valid,
+			Splits:          splits,
+			Tags:            tags[transactionID],
+			AttachmentCount: counts[transactionID],
 		},
 	})
 }
@@ -264,13 +734,126 @@ func (cfg *apiConfig) deleteTransaction(w http.ResponseWriter, req *http.Request
 		return
 	}
 
+	dbTransaction, err := cfg.db.GetTransactionByID(req.Context(), transactionID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get transaction", err)
+		return
+	}
+
+	if err := checkNotReconciled(req.Context(), cfg.db, dbTransaction); err != nil {
+		if errors.Is(err, errTransactionReconciled) {
+			respondWithError(w, http.StatusConflict, "Reconciled transactions can't be changed", err)
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get transaction", err)
+		return
+	}
+
+	// Attachment rows go with the transaction, so note their files first.
+	var attachmentIDs []uuid.UUID
+	if dbTransaction.TransferID.Valid {
+		attachmentIDs, err = cfg.db.GetTransferAttachmentIDs(req.Context(), dbTransaction.TransferID)
+	} else {
+		dbAttachments, listErr := cfg.db.GetTransactionAttachments(req.Context(), transactionID)
+		for _, dbAttachment := range dbAttachments {
+			attachmentIDs = append(attachmentIDs, dbAttachment.ID)
+		}
+		err = listErr
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get attachments", err)
+		return
+	}
+
+	if dbTransaction.TransferID.Valid {
+		if err := cfg.db.DeleteTransfer(req.Context(), dbTransaction.TransferID); err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Couldn't delete transfer", err)
+			return
+		}
+		cfg.removeAttachmentFiles(attachmentIDs)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
 	if err := cfg.db.DeleteTransaction(req.Context(), transactionID); err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Couldn't delete transaction", err)
 		return
 	}
+	cfg.removeAttachmentFiles(attachmentIDs)
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (cfg *apiConfig) updateTransactionPosted(w http.ResponseWriter, req *http.Request) {
+	type parameters struct {
+		Posted bool `json:"posted"`
+	}
+
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	transactionIDString := req.PathValue("transactionID")
+	transactionID, err := uuid.Parse(transactionIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid transaction ID", err)
+		return
+	}
+
+	dbTransactionUserID, err := cfg.db.GetTransactionUserID(req.Context(), transactionID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get transaction", err)
+		return
+	}
+	if dbTransactionUserID != userID {
+		respondWithError(w, http.StatusUnauthorized, "Can't update transaction", errors.New("unauthorized"))
+		return
+	}
+
+	decoder := json.NewDecoder(req.Body)
+	params := parameters{}
+	if err := decoder.Decode(&params); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't decode parameters", err)
+		return
+	}
+
+	dbTransaction, err := cfg.db.GetTransactionByID(req.Context(), transactionID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't get transaction", err)
+		return
+	}
+	// Each leg of a transfer clears on its own statement, so only this leg
+	// needs to be unlocked.
+	if dbTransaction.ReconciliationID.Valid {
+		respondWithError(w, http.StatusConflict, "Reconciled transactions can't be changed", errTransactionReconciled)
+		return
+	}
+
+	if params.Posted && !dbTransaction.Posted {
+		err := checkNotInReconciledPeriod(req.Context(), cfg.db, dbTransaction.AccountID, dbTransaction.TxDate)
+		if errors.Is(err, errReconciledPeriod) {
+			respondWithError(w, http.StatusConflict, "Cleared transactions can't be dated on or before the last reconciled statement", err)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Couldn't get reconciliations", err)
+			return
+		}
+	}
+
+	updatedTransaction, err := cfg.db.UpdateTransactionPosted(req.Context(), database.UpdateTransactionPostedParams{
+		ID:     transactionID,
+		Posted: params.Posted,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't update transaction", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, transactionFromDB(updatedTransaction))
+}
+
 func (cfg *apiConfig) getAccountTransactions(w http.ResponseWriter, req *http.Request) {
 	userID, err := checkToken(req.Header, cfg.jwtSecret)
 	if err != nil {
@@ -295,12 +878,30 @@ func (cfg *apiConfig) getAccountTransactions(w http.ResponseWriter, req *http.Re
 		return
 	}
 
-	dbTransactions, err := cfg.db.GetTransactionsByAccount(req.Context(), dbAccount.ID)
+	page, err := pageFromQuery(req.URL.Query())
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), errors.New("invalid parameters"))
+		return
+	}
+
+	dbTransactions, err := cfg.db.GetTransactionsByAccount(req.Context(), database.GetTransactionsByAccountParams{
+		AccountID:  dbAccount.ID,
+		CursorDate: page.CursorDate,
+		CursorID:   page.CursorID,
+		RowLimit:   page.rowLimit(),
+	})
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Couldn't get transactions", err)
 		return
 	}
 
+	nextCursor := ""
+	if len(dbTransactions) > int(page.Limit) {
+		dbTransactions = dbTransactions[:page.Limit]
+		last := dbTransactions[len(dbTransactions)-1]
+		nextCursor = encodeCursor(last.TxDate, last.ID)
+	}
+
 	transactions := []Transaction{}
 	for _, transaction := range dbTransactions {
 		// amountFloat, err := strconv.ParseFloat(transaction.Amount, 64)
@@ -312,16 +913,23 @@ func (cfg *apiConfig) getAccountTransactions(w http.ResponseWriter, req *http.Re
 			ID:            transaction.ID,
 			Amount:        transaction.Amount,
 			TxDescription: transaction.TxDescription,
+			Memo:          transaction.Memo,
 			TxDate:        transaction.TxDate,
 			CreatedAt:     transaction.CreatedAt,
 			UpdatedAt:     transaction.UpdatedAt,
 			Posted:        transaction.Posted,
 			AccountID:     transaction.AccountID,
 			CategoryID:    transaction.CategoryID.UUID,
+			TransferID:    transaction.TransferID.UUID,
+			PayeeID:       transaction.PayeeID.UUID,
+			Reconciled:    transaction.ReconciliationID.Valid,
 		})
 	}
 
-	respondWithJSON(w, http.StatusOK, transactions)
+	respondWithJSON(w, http.StatusOK, TransactionPage{
+		Transactions: transactions,
+		NextCursor:   nextCursor,
+	})
 }
 
 func (cfg *apiConfig) getCategoryTransactions(w http.ResponseWriter, req *http.Request) {
@@ -353,26 +961,51 @@ func (cfg *apiConfig) getCategoryTransactions(w http.ResponseWriter, req *http.R
 		Valid: true,
 	}
 
-	dbTransactions, err := cfg.db.GetTransactionsByCategory(req.Context(), catId)
+	page, err := pageFromQuery(req.URL.Query())
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), errors.New("invalid parameters"))
+		return
+	}
+
+	dbTransactions, err := cfg.db.GetTransactionsByCategory(req.Context(), database.GetTransactionsByCategoryParams{
+		CategoryID: catId,
+		CursorDate: page.CursorDate,
+		CursorID:   page.CursorID,
+		RowLimit:   page.rowLimit(),
+	})
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Couldn't get transactions", err)
 		return
 	}
 
+	nextCursor := ""
+	if len(dbTransactions) > int(page.Limit) {
+		dbTransactions = dbTransactions[:page.Limit]
+		last := dbTransactions[len(dbTransactions)-1]
+		nextCursor = encodeCursor(last.TxDate, last.ID)
+	}
+
 	transactions := []Transaction{}
 	for _, transaction := range dbTransactions {
 		transactions = append(transactions, Transaction{
 			ID:            transaction.ID,
 			Amount:        transaction.Amount,
 			TxDescription: transaction.TxDescription,
+			Memo:          transaction.Memo,
 			TxDate:        transaction.TxDate,
 			CreatedAt:     transaction.CreatedAt,
 			UpdatedAt:     transaction.UpdatedAt,
 			Posted:        transaction.Posted,
 			AccountID:     transaction.AccountID,
 			CategoryID:    transaction.CategoryID.UUID,
+			TransferID:    transaction.TransferID.UUID,
+			PayeeID:       transaction.PayeeID.UUID,
+			Reconciled:    transaction.ReconciliationID.Valid,
 		})
 	}
 
-	respondWithJSON(w, http.StatusOK, transactions)
+	respondWithJSON(w, http.StatusOK, TransactionPage{
+		Transactions: transactions,
+		NextCursor:   nextCursor,
+	})
 }
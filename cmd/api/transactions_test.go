@@ -0,0 +1,255 @@
+package main
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jkk290/budget-tui/internal/auth"
+	"github.com/jkk290/budget-tui/internal/database"
+	"github.com/shopspring/decimal"
+)
+
+// newAuthedRequest builds a request carrying a token for userID.
+func newAuthedRequest(t *testing.T, cfg *apiConfig, userID uuid.UUID, method, target, body string) *http.Request {
+	t.Helper()
+	token, err := auth.MakeJWT(userID, cfg.jwtSecret, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
+
+func testAccount(userID uuid.UUID) database.Account {
+	return database.Account{
+		ID:          uuid.New(),
+		AccountName: "Checking",
+		AccountType: "checking",
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+		UserID:      userID,
+		Currency:    "USD",
+	}
+}
+
+func TestAddTransactionToAnotherUsersAccount(t *testing.T) {
+	fake, cfg := newFakeDB(t)
+	callerID := uuid.New()
+	account := testAccount(uuid.New())
+	fake.set("GetAccountByID", accountRow(account))
+
+	body := `{"amount": "-42.00", "tx_description": "Probe", "tx_date": "2026-01-15T00:00:00Z", "account_id": "` + account.ID.String() + `"}`
+	req := newAuthedRequest(t, cfg, callerID, http.MethodPost, "/api/v1/transactions", body)
+	rec := httptest.NewRecorder()
+
+	cfg.addTransaction(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d; body: %s", rec.Code, http.StatusForbidden, rec.Body.String())
+	}
+	if strings.Contains(rec.Body.String(), "possible_duplicates") {
+		t.Errorf("response leaks possible duplicates: %s", rec.Body.String())
+	}
+	if len(fake.ran) != 1 || fake.ran[0] != "GetAccountByID" {
+		t.Errorf("handler ran statements after the ownership check: %q", fake.ran)
+	}
+}
+
+func TestUpdateTransactionWithoutSplitsKeepsThem(t *testing.T) {
+	fake, cfg := newFakeDB(t)
+	userID := uuid.New()
+	account := testAccount(userID)
+	transaction := database.Transaction{
+		ID:            uuid.New(),
+		Amount:        decimal.RequireFromString("-30.00"),
+		TxDescription: "Groceries and soap",
+		TxDate:        time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC),
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+		AccountID:     account.ID,
+	}
+	splits := []database.TransactionSplit{
+		{ID: uuid.New(), TransactionID: transaction.ID, CategoryID: uuid.NullUUID{UUID: uuid.New(), Valid: true}, Amount: decimal.RequireFromString("-25.00"), SortOrder: 0},
+		{ID: uuid.New(), TransactionID: transaction.ID, CategoryID: uuid.NullUUID{UUID: uuid.New(), Valid: true}, Amount: decimal.RequireFromString("-5.00"), SortOrder: 1},
+	}
+
+	fake.set("GetTransactionUserID", []driver.Value{userID.String()})
+	fake.set("GetAccountByID", accountRow(account))
+	fake.set("GetTransactionByID", transactionRow(transaction))
+	fake.set("GetTransactionSplits", splitRow(splits[0]), splitRow(splits[1]))
+	fake.set("UpdateTransaction", transactionRow(transaction))
+
+	body := `{"amount": "-30.00", "tx_description": "Groceries, soap", "tx_date": "2026-01-15T00:00:00Z", "account_id": "` + account.ID.String() + `"}`
+	req := newAuthedRequest(t, cfg, userID, http.MethodPut, "/api/v1/transactions/"+transaction.ID.String(), body)
+	req.SetPathValue("transactionID", transaction.ID.String())
+	rec := httptest.NewRecorder()
+
+	cfg.updateTransaction(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d; body: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	for _, name := range []string{"DeleteTransactionSplits", "AddTransactionSplit"} {
+		if fake.didRun(name) {
+			t.Errorf("%s ran, but the update left splits out", name)
+		}
+	}
+	// UpdateTransaction's seventh argument is category_id, which has to
+	// stay empty while the transaction is split.
+	if categoryID := fake.args["UpdateTransaction"][6].Value; categoryID != nil {
+		t.Errorf("category_id = %v, want NULL for a split transaction", categoryID)
+	}
+
+	var got Transaction
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Splits) != len(splits) {
+		t.Errorf("response has %d splits, want %d", len(got.Splits), len(splits))
+	}
+}
+
+func TestUpdateTransactionWithoutSplitsChecksKeptTotal(t *testing.T) {
+	fake, cfg := newFakeDB(t)
+	userID := uuid.New()
+	account := testAccount(userID)
+	transaction := database.Transaction{
+		ID:        uuid.New(),
+		Amount:    decimal.RequireFromString("-30.00"),
+		TxDate:    time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC),
+		AccountID: account.ID,
+	}
+	fake.set("GetTransactionUserID", []driver.Value{userID.String()})
+	fake.set("GetAccountByID", accountRow(account))
+	fake.set("GetTransactionByID", transactionRow(transaction))
+	fake.set("GetTransactionSplits",
+		splitRow(database.TransactionSplit{ID: uuid.New(), TransactionID: transaction.ID, Amount: decimal.RequireFromString("-25.00")}),
+		splitRow(database.TransactionSplit{ID: uuid.New(), TransactionID: transaction.ID, Amount: decimal.RequireFromString("-5.00")}),
+	)
+
+	body := `{"amount": "-40.00", "tx_description": "Groceries", "tx_date": "2026-01-15T00:00:00Z", "account_id": "` + account.ID.String() + `"}`
+	req := newAuthedRequest(t, cfg, userID, http.MethodPut, "/api/v1/transactions/"+transaction.ID.String(), body)
+	req.SetPathValue("transactionID", transaction.ID.String())
+	rec := httptest.NewRecorder()
+
+	cfg.updateTransaction(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d; body: %s", rec.Code, http.StatusBadRequest, rec.Body.String())
+	}
+	if fake.didRun("UpdateTransaction") {
+		t.Error("transaction was updated even though its splits no longer add up")
+	}
+}
+
+func testReconciliation(accountID uuid.UUID, statementDate time.Time) database.Reconciliation {
+	return database.Reconciliation{
+		ID:               uuid.New(),
+		StatementDate:    statementDate,
+		StatementBalance: decimal.RequireFromString("100.00"),
+		TransactionCount: 3,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+		AccountID:        accountID,
+	}
+}
+
+func TestAddTransactionInReconciledPeriod(t *testing.T) {
+	tests := []struct {
+		name     string
+		txDate   string
+		posted   bool
+		conflict bool
+	}{
+		{"cleared before the statement", "2026-01-20T00:00:00Z", true, true},
+		{"cleared late on the statement day", "2026-01-31T18:00:00Z", true, true},
+		{"cleared after the statement", "2026-02-01T00:00:00Z", true, false},
+		{"not cleared yet", "2026-01-20T00:00:00Z", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake, cfg := newFakeDB(t)
+			userID := uuid.New()
+			account := testAccount(userID)
+			fake.set("GetAccountByID", accountRow(account))
+			fake.set("GetLatestReconciliation", reconciliationRow(testReconciliation(account.ID, time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC))))
+
+			body := `{"amount": "-42.00", "tx_description": "Coffee", "tx_date": "` + tt.txDate + `", "posted": ` + strconv.FormatBool(tt.posted) + `, "account_id": "` + account.ID.String() + `"}`
+			req := newAuthedRequest(t, cfg, userID, http.MethodPost, "/api/v1/transactions", body)
+			rec := httptest.NewRecorder()
+
+			cfg.addTransaction(rec, req)
+
+			if got := rec.Code == http.StatusConflict; got != tt.conflict {
+				t.Fatalf("status = %d, want conflict %v; body: %s", rec.Code, tt.conflict, rec.Body.String())
+			}
+			if fake.didRun("AddTransaction") == tt.conflict {
+				t.Errorf("AddTransaction ran = %v, want %v", !tt.conflict, !tt.conflict)
+			}
+		})
+	}
+}
+
+func TestUpdateTransactionInReconciledPeriod(t *testing.T) {
+	statementDate := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		posted   bool
+		body     string
+		conflict bool
+	}{
+		{"cleared entry moved back before the statement", true, `"tx_date": "2026-01-30T00:00:00Z", "posted": true`, true},
+		{"unposted entry cleared after the statement", false, `"tx_date": "2026-02-03T00:00:00Z", "posted": true`, false},
+		{"unposted entry cleared in the reconciled period", false, `"tx_date": "2026-01-25T00:00:00Z", "posted": true`, true},
+		{"unposted entry moved back before the statement", false, `"tx_date": "2026-01-25T00:00:00Z", "posted": false`, false},
+		{"cleared entry edited in place", true, `"tx_date": "2026-01-10T00:00:00Z", "posted": true`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake, cfg := newFakeDB(t)
+			userID := uuid.New()
+			account := testAccount(userID)
+			transaction := database.Transaction{
+				ID:            uuid.New(),
+				Amount:        decimal.RequireFromString("-30.00"),
+				TxDescription: "Groceries",
+				TxDate:        time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC),
+				Posted:        tt.posted,
+				AccountID:     account.ID,
+			}
+			if !tt.posted {
+				transaction.TxDate = time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)
+			}
+			fake.set("GetTransactionUserID", []driver.Value{userID.String()})
+			fake.set("GetAccountByID", accountRow(account))
+			fake.set("GetTransactionByID", transactionRow(transaction))
+			fake.set("GetLatestReconciliation", reconciliationRow(testReconciliation(account.ID, statementDate)))
+			fake.set("UpdateTransaction", transactionRow(transaction))
+
+			body := `{"amount": "-30.00", "tx_description": "Groceries", ` + tt.body + `, "account_id": "` + account.ID.String() + `"}`
+			req := newAuthedRequest(t, cfg, userID, http.MethodPut, "/api/v1/transactions/"+transaction.ID.String(), body)
+			req.SetPathValue("transactionID", transaction.ID.String())
+			rec := httptest.NewRecorder()
+
+			cfg.updateTransaction(rec, req)
+
+			if got := rec.Code == http.StatusConflict; got != tt.conflict {
+				t.Fatalf("status = %d, want conflict %v; body: %s", rec.Code, tt.conflict, rec.Body.String())
+			}
+			if fake.didRun("UpdateTransaction") == tt.conflict {
+				t.Errorf("UpdateTransaction ran = %v, want %v", !tt.conflict, !tt.conflict)
+			}
+		})
+	}
+}
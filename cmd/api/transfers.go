@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jkk290/budget-tui/internal/database"
+	"github.com/shopspring/decimal"
+)
+
+var errTransferSameAccount = errors.New("transfer accounts must be different")
+
+type Transfer struct {
+	TransferID uuid.UUID   `json:"transfer_id"`
+	From       Transaction `json:"from"`
+	To         Transaction `json:"to"`
+}
+
+func transactionFromDB(dbTransaction database.Transaction) Transaction {
+	return Transaction{
+		ID:            dbTransaction.ID,
+		Amount:        dbTransaction.Amount,
+		TxDescription: dbTransaction.TxDescription,
+		TxDate:        dbTransaction.TxDate,
+		CreatedAt:     dbTransaction.CreatedAt,
+		UpdatedAt:     dbTransaction.UpdatedAt,
+		Posted:        dbTransaction.Posted,
+		AccountID:     dbTransaction.AccountID,
+		CategoryID:    dbTransaction.CategoryID.UUID,
+		TransferID:    dbTransaction.TransferID.UUID,
+		PayeeID:       dbTransaction.PayeeID.UUID,
+		Reconciled:    dbTransaction.ReconciliationID.Valid,
+	}
+}
+
+func (cfg *apiConfig) createTransfer(w http.ResponseWriter, req *http.Request) {
+	type parameters struct {
+		FromAccountID uuid.UUID       `json:"from_account_id"`
+		ToAccountID   uuid.UUID       `json:"to_account_id"`
+		Amount        decimal.Decimal `json:"amount"`
+		TxDescription string          `json:"tx_description"`
+		TxDate        time.Time       `json:"tx_date"`
+		Posted        bool            `json:"posted"`
+	}
+
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	decoder := json.NewDecoder(req.Body)
+	params := parameters{}
+	if err := decoder.Decode(&params); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't decode parameters", err)
+		return
+	}
+
+	if !params.Amount.IsPositive() || params.TxDescription == "" || params.TxDate.IsZero() || params.FromAccountID == uuid.Nil || params.ToAccountID == uuid.Nil {
+		respondWithError(w, http.StatusBadRequest, "Missing positive amount, description, date, and/or accounts", errors.New("invalid parameters"))
+		return
+	}
+
+	if params.FromAccountID == params.ToAccountID {
+		respondWithError(w, http.StatusBadRequest, "Transfer accounts must be different", errTransferSameAccount)
+		return
+	}
+
+	for _, accountID := range []uuid.UUID{params.FromAccountID, params.ToAccountID} {
+		dbAccount, err := cfg.db.GetAccountByID(req.Context(), accountID)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Couldn't get account", err)
+			return
+		}
+		if dbAccount.UserID != userID {
+			respondWithError(w, http.StatusForbidden, "Can't transfer with account", errors.New("unauthorized"))
+			return
+		}
+		if !params.Posted {
+			continue
+		}
+		err = checkNotInReconciledPeriod(req.Context(), cfg.db, accountID, params.TxDate)
+		if errors.Is(err, errReconciledPeriod) {
+			respondWithError(w, http.StatusConflict, "Cleared transactions can't be dated on or before the last reconciled statement", err)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Couldn't get reconciliations", err)
+			return
+		}
+	}
+
+	tx, err := cfg.dbConn.BeginTx(req.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't start transfer", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	transferID := uuid.NullUUID{
+		UUID:  uuid.New(),
+		Valid: true,
+	}
+
+	fromTransaction, err := qtx.AddTransaction(req.Context(), database.AddTransactionParams{
+		ID:            uuid.New(),
+		Amount:        params.Amount.Neg(),
+		TxDescription: params.TxDescription,
+		TxDate:        params.TxDate,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+		Posted:        params.Posted,
+		AccountID:     params.FromAccountID,
+		CategoryID:    uuid.NullUUID{},
+		TransferID:    transferID,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't create transfer", err)
+		return
+	}
+
+	toTransaction, err := qtx.AddTransaction(req.Context(), database.AddTransactionParams{
+		ID:            uuid.New(),
+		Amount:        params.Amount,
+		TxDescription: params.TxDescription,
+		TxDate:        params.TxDate,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+		Posted:        params.Posted,
+		AccountID:     params.ToAccountID,
+		CategoryID:    uuid.NullUUID{},
+		TransferID:    transferID,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't create transfer", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't create transfer", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, Transfer{
+		TransferID: transferID.UUID,
+		From:       transactionFromDB(fromTransaction),
+		To:         transactionFromDB(toTransaction),
+	})
+}
+
+// updateTransfer applies an edit to one side of a transfer and mirrors the
+// amount, description and date onto the other side in the same transaction.
+func (cfg *apiConfig) updateTransfer(ctx context.Context, transferID uuid.NullUUID, arg database.UpdateTransactionParams) (database.Transaction, error) {
+	tx, err := cfg.dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		return database.Transaction{}, err
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	counterpart, err := qtx.GetTransferCounterpart(ctx, database.GetTransferCounterpartParams{
+		TransferID: transferID,
+		ID:         arg.ID,
+	})
+	if err != nil {
+		return database.Transaction{}, err
+	}
+	if counterpart.AccountID == arg.AccountID {
+		return database.Transaction{}, errTransferSameAccount
+	}
+
+	arg.CategoryID = uuid.NullUUID{}
+	updatedTransaction, err := qtx.UpdateTransaction(ctx, arg)
+	if err != nil {
+		return database.Transaction{}, err
+	}
+
+	_, err = qtx.UpdateTransaction(ctx, database.UpdateTransactionParams{
+		ID:            counterpart.ID,
+		Amount:        arg.Amount.Neg(),
+		TxDescription: arg.TxDescription,
+		TxDate:        arg.TxDate,
+		Posted:        counterpart.Posted,
+		AccountID:     counterpart.AccountID,
+		CategoryID:    counterpart.CategoryID,
+		PayeeID:       counterpart.PayeeID,
+		Memo:          counterpart.Memo,
+	})
+	if err != nil {
+		return database.Transaction{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return database.Transaction{}, err
+	}
+
+	return updatedTransaction, nil
+}
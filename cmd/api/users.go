@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jkk290/budget-tui/internal/auth"
+	"github.com/jkk290/budget-tui/internal/currency"
 	"github.com/jkk290/budget-tui/internal/database"
 )
 
@@ -16,6 +17,7 @@ type User struct {
 	UpdatedAt      time.Time `json:"updated_at"`
 	Username       string    `json:"username"`
 	HashedPassword string    `json:"hashed_pw"`
+	BaseCurrency   string    `json:"base_currency"`
 }
 
 func (cfg *apiConfig) createUser(w http.ResponseWriter, req *http.Request) {
@@ -55,10 +57,77 @@ func (cfg *apiConfig) createUser(w http.ResponseWriter, req *http.Request) {
 
 	respondWithJSON(w, http.StatusCreated, response{
 		User: User{
-			ID:        user.ID,
-			CreatedAt: user.CreatedAt,
-			UpdatedAt: user.UpdatedAt,
-			Username:  user.Username,
+			ID:           user.ID,
+			CreatedAt:    user.CreatedAt,
+			UpdatedAt:    user.UpdatedAt,
+			Username:     user.Username,
+			BaseCurrency: user.BaseCurrency,
 		},
 	})
 }
+
+func (cfg *apiConfig) getCurrentUser(w http.ResponseWriter, req *http.Request) {
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	user, err := cfg.db.GetUserByID(req.Context(), userID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Couldn't get user", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, User{
+		ID:           user.ID,
+		CreatedAt:    user.CreatedAt,
+		UpdatedAt:    user.UpdatedAt,
+		Username:     user.Username,
+		BaseCurrency: user.BaseCurrency,
+	})
+}
+
+// updateCurrentUser changes the currency budgets and net worth are reported
+// in. Existing rates are kept, since they name both currencies.
+func (cfg *apiConfig) updateCurrentUser(w http.ResponseWriter, req *http.Request) {
+	type parameters struct {
+		BaseCurrency string `json:"base_currency"`
+	}
+
+	userID, err := checkToken(req.Header, cfg.jwtSecret)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Couldn't validate JWT", err)
+		return
+	}
+
+	decoder := json.NewDecoder(req.Body)
+	params := parameters{}
+	if err := decoder.Decode(&params); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't decode parameters", err)
+		return
+	}
+
+	baseCurrency, err := currency.Normalize(params.BaseCurrency)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	user, err := cfg.db.UpdateUserBaseCurrency(req.Context(), database.UpdateUserBaseCurrencyParams{
+		ID:           userID,
+		BaseCurrency: baseCurrency,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Couldn't update user", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, User{
+		ID:           user.ID,
+		CreatedAt:    user.CreatedAt,
+		UpdatedAt:    user.UpdatedAt,
+		Username:     user.Username,
+		BaseCurrency: user.BaseCurrency,
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/google/uuid"
@@ -13,10 +15,12 @@ import (
 
 type AccountsAPI interface {
 	ListAccounts(ctx context.Context) ([]Account, error)
-	ListAccountTransactions(ctx context.Context, id uuid.UUID) ([]Transaction, error)
+	GetAccountRegister(ctx context.Context, id uuid.UUID, start, end time.Time) (AccountRegister, error)
 	CreateAccount(ctx context.Context, req CreateAccountRequest) (Account, error)
 	UpdateAccount(ctx context.Context, id uuid.UUID, req UpdateAccountRequest) (Account, error)
 	DeleteAccount(ctx context.Context, id uuid.UUID) error
+	SetTransactionPosted(ctx context.Context, id uuid.UUID, posted bool) (Transaction, error)
+	ReconcileAccount(ctx context.Context, id uuid.UUID, req ReconcileRequest) (Reconciliation, error)
 }
 
 type accountsClient struct {
@@ -62,50 +66,131 @@ func loadAccountsCmd(api AccountsAPI) tea.Cmd {
 	}
 }
 
-func (a *accountsClient) ListAccountTransactions(ctx context.Context, id uuid.UUID) ([]Transaction, error) {
-	req, err := a.client.newRequest(ctx, http.MethodGet, "/accounts/"+id.String()+"/transactions", nil)
+type RegisterEntry struct {
+	ID                  uuid.UUID       `json:"id"`
+	TxDate              time.Time       `json:"tx_date"`
+	TxDescription       string          `json:"tx_description"`
+	PayeeID             uuid.UUID       `json:"payee_id"`
+	PayeeName           string          `json:"payee_name"`
+	CategoryID          uuid.UUID       `json:"category_id"`
+	CategoryName        string          `json:"category_name"`
+	TransferID          uuid.UUID       `json:"transfer_id"`
+	TransferAccountName string          `json:"transfer_account_name"`
+	Split               bool            `json:"split"`
+	Amount              decimal.Decimal `json:"amount"`
+	Posted              bool            `json:"posted"`
+	Reconciled          bool            `json:"reconciled"`
+	Balance             decimal.Decimal `json:"balance"`
+}
+
+type AccountRegister struct {
+	AccountID      uuid.UUID       `json:"account_id"`
+	StartDate      *time.Time      `json:"start_date"`
+	EndDate        *time.Time      `json:"end_date"`
+	OpeningBalance decimal.Decimal `json:"opening_balance"`
+	ClosingBalance decimal.Decimal `json:"closing_balance"`
+	Entries        []RegisterEntry `json:"entries"`
+}
+
+// GetAccountRegister loads the account's register between start and end,
+// both inclusive. A zero start or end leaves that side open.
+func (a *accountsClient) GetAccountRegister(ctx context.Context, id uuid.UUID, start, end time.Time) (AccountRegister, error) {
+	query := url.Values{}
+	if !start.IsZero() {
+		query.Set("start", start.Format(time.DateOnly))
+	}
+	if !end.IsZero() {
+		query.Set("end", end.Format(time.DateOnly))
+	}
+
+	path := "/accounts/" + id.String() + "/register"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	req, err := a.client.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, err
+		return AccountRegister{}, err
 	}
 
 	res, err := a.client.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return AccountRegister{}, err
 	}
 	defer res.Body.Close()
 
-	var accountTxs []Transaction
-	if err := json.NewDecoder(res.Body).Decode(&accountTxs); err != nil {
-		return nil, err
+	if res.StatusCode != http.StatusOK {
+		return AccountRegister{}, fmt.Errorf("Failed loading register: %s", res.Status)
 	}
 
-	return accountTxs, nil
+	var register AccountRegister
+	if err := json.NewDecoder(res.Body).Decode(&register); err != nil {
+		return AccountRegister{}, err
+	}
+
+	return register, nil
 }
 
-type loadAccountTxsMsg struct {
-	accountTxs []Transaction
-	err        error
+type loadAccountRegisterMsg struct {
+	register AccountRegister
+	err      error
 }
 
-func loadAccountTxsCmd(api AccountsAPI, id uuid.UUID) tea.Cmd {
+func loadAccountRegisterCmd(api AccountsAPI, id uuid.UUID, start, end time.Time) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.TODO()
-		accountTxs, err := api.ListAccountTransactions(ctx, id)
-		return loadAccountTxsMsg{
-			accountTxs: accountTxs,
-			err:        err,
+		register, err := api.GetAccountRegister(ctx, id, start, end)
+		return loadAccountRegisterMsg{
+			register: register,
+			err:      err,
 		}
 	}
 }
 
-type loadAccountTxsSubmittedMsg struct {
+type loadAccountRegisterSubmittedMsg struct {
 	accountID uuid.UUID
+	start     time.Time
+	end       time.Time
 }
 
-func submitLoadAccountTxsMsg(id uuid.UUID) tea.Cmd {
+func submitLoadAccountRegisterMsg(id uuid.UUID, start, end time.Time) tea.Cmd {
 	return func() tea.Msg {
-		return loadAccountTxsSubmittedMsg{
+		return loadAccountRegisterSubmittedMsg{
 			accountID: id,
+			start:     start,
+			end:       end,
+		}
+	}
+}
+
+type reconcileEntriesLoadedMsg struct {
+	entries []RegisterEntry
+	err     error
+}
+
+// loadReconcileEntriesCmd loads every transaction up to the statement date,
+// since the cleared balance includes ones reconciled long ago.
+func loadReconcileEntriesCmd(api AccountsAPI, id uuid.UUID, statementDate time.Time) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.TODO()
+		register, err := api.GetAccountRegister(ctx, id, time.Time{}, statementDate)
+		return reconcileEntriesLoadedMsg{
+			entries: register.Entries,
+			err:     err,
+		}
+	}
+}
+
+type reconcileStartSubmittedMsg struct {
+	AccountID     uuid.UUID
+	StatementDate time.Time
+}
+
+func submitReconcileStartMsg(id uuid.UUID, statementDate time.Time) tea.Cmd {
+	return func() tea.Msg {
+		return reconcileStartSubmittedMsg{
+			AccountID:     id,
+			StatementDate: statementDate,
 		}
 	}
 }
@@ -113,6 +198,7 @@ func submitLoadAccountTxsMsg(id uuid.UUID) tea.Cmd {
 type CreateAccountRequest struct {
 	AccountName    string          `json:"account_name"`
 	AccountType    string          `json:"account_type"`
+	Currency       string          `json:"currency,omitempty"`
 	InitialBalance decimal.Decimal `json:"initial_balance"`
 }
 
@@ -159,14 +245,16 @@ func createAccountCmd(api AccountsAPI, req CreateAccountRequest) tea.Cmd {
 type accountCreateSubmittedMsg struct {
 	Name        string
 	Type        string
+	Currency    string
 	BalanceText string
 }
 
-func submitCreateAccountMsg(name, accountType, balance string) tea.Cmd {
+func submitCreateAccountMsg(name, accountType, accountCurrency, balance string) tea.Cmd {
 	return func() tea.Msg {
 		return accountCreateSubmittedMsg{
 			Name:        name,
 			Type:        accountType,
+			Currency:    accountCurrency,
 			BalanceText: balance,
 		}
 	}
@@ -278,3 +366,130 @@ func submitDeleteAccountMsg(id uuid.UUID) tea.Cmd {
 }
 
 type accountsReloadRequestedMsg struct{}
+
+type setTransactionPostedRequest struct {
+	Posted bool `json:"posted"`
+}
+
+func (a *accountsClient) SetTransactionPosted(ctx context.Context, id uuid.UUID, posted bool) (Transaction, error) {
+	httpReq, err := a.client.newJSONRequest(ctx, http.MethodPut, "/transactions/"+id.String()+"/posted", setTransactionPostedRequest{Posted: posted})
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	res, err := a.client.httpClient.Do(httpReq)
+	if err != nil {
+		return Transaction{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return Transaction{}, fmt.Errorf("Failed updating transaction: %s", res.Status)
+	}
+
+	var transaction Transaction
+	if err := json.NewDecoder(res.Body).Decode(&transaction); err != nil {
+		return Transaction{}, err
+	}
+
+	return transaction, nil
+}
+
+type transactionPostedMsg struct {
+	transaction Transaction
+	err         error
+}
+
+func setTransactionPostedCmd(api AccountsAPI, id uuid.UUID, posted bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		transaction, err := api.SetTransactionPosted(ctx, id, posted)
+		return transactionPostedMsg{
+			transaction: transaction,
+			err:         err,
+		}
+	}
+}
+
+type transactionPostedSubmittedMsg struct {
+	TransactionID uuid.UUID
+	Posted        bool
+}
+
+func submitTransactionPostedMsg(id uuid.UUID, posted bool) tea.Cmd {
+	return func() tea.Msg {
+		return transactionPostedSubmittedMsg{
+			TransactionID: id,
+			Posted:        posted,
+		}
+	}
+}
+
+type Reconciliation struct {
+	ID               uuid.UUID       `json:"id"`
+	AccountID        uuid.UUID       `json:"account_id"`
+	StatementDate    time.Time       `json:"statement_date"`
+	StatementBalance decimal.Decimal `json:"statement_balance"`
+	TransactionCount int32           `json:"transaction_count"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+}
+
+type ReconcileRequest struct {
+	StatementDate    time.Time       `json:"statement_date"`
+	StatementBalance decimal.Decimal `json:"statement_balance"`
+}
+
+func (a *accountsClient) ReconcileAccount(ctx context.Context, id uuid.UUID, req ReconcileRequest) (Reconciliation, error) {
+	httpReq, err := a.client.newJSONRequest(ctx, http.MethodPost, "/accounts/"+id.String()+"/reconciliations", req)
+	if err != nil {
+		return Reconciliation{}, err
+	}
+
+	res, err := a.client.httpClient.Do(httpReq)
+	if err != nil {
+		return Reconciliation{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated {
+		return Reconciliation{}, fmt.Errorf("Failed reconciling account: %s", res.Status)
+	}
+
+	var reconciliation Reconciliation
+	if err := json.NewDecoder(res.Body).Decode(&reconciliation); err != nil {
+		return Reconciliation{}, err
+	}
+
+	return reconciliation, nil
+}
+
+type accountReconciledMsg struct {
+	reconciliation Reconciliation
+	err            error
+}
+
+func reconcileAccountCmd(api AccountsAPI, id uuid.UUID, req ReconcileRequest) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		reconciliation, err := api.ReconcileAccount(ctx, id, req)
+		return accountReconciledMsg{
+			reconciliation: reconciliation,
+			err:            err,
+		}
+	}
+}
+
+type accountReconcileSubmittedMsg struct {
+	AccountID uuid.UUID
+	Request   ReconcileRequest
+}
+
+func submitReconcileAccountMsg(id uuid.UUID, req ReconcileRequest) tea.Cmd {
+	return func() tea.Msg {
+		return accountReconcileSubmittedMsg{
+			AccountID: id,
+			Request:   req,
+		}
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
@@ -19,52 +20,119 @@ const (
 	accountsModeFormNew
 	accountsModeFormEdit
 	accountsModeDelete
+	accountsModeReconcileForm
+	accountsModeReconcile
 )
 
 const (
 	formFieldName = iota
 	formFieldType
+	formFieldCurrency
 	formFieldBalance
 	formFieldSave
 )
 
+// registerPageSize is how many register rows are shown at once.
+const registerPageSize = 15
+
+// registerWindows are the date ranges the register can show, cycled with
+// 'w'.
+var registerWindows = []string{
+	"All time",
+	"Last 30 days",
+	"Last 90 days",
+	"This year",
+}
+
+const (
+	reconcileFieldDate = iota
+	reconcileFieldBalance
+	reconcileFieldStart
+)
+
 const (
 	confirmYes = iota
 	confirmCancel
 )
 
 type Account struct {
-	ID             uuid.UUID       `json:"id"`
-	AccountName    string          `json:"account_name"`
-	AccountType    string          `json:"account_type"`
-	CreatedAt      time.Time       `json:"created_at"`
-	UpdatedAt      time.Time       `json:"updated_at"`
-	UserID         uuid.UUID       `json:"user_id"`
-	AccountBalance decimal.Decimal `json:"account_balance"`
+	ID               uuid.UUID       `json:"id"`
+	AccountName      string          `json:"account_name"`
+	AccountType      string          `json:"account_type"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+	UserID           uuid.UUID       `json:"user_id"`
+	ClearedBalance   decimal.Decimal `json:"cleared_balance"`
+	UnclearedBalance decimal.Decimal `json:"uncleared_balance"`
+	WorkingBalance   decimal.Decimal `json:"working_balance"`
+	FutureAmount     decimal.Decimal `json:"future_amount"`
+	Currency         string          `json:"currency"`
+	// BaseWorkingBalance is the working balance in the user's base
+	// currency, or null when there is no exchange rate for the account.
+	BaseWorkingBalance decimal.NullDecimal `json:"base_working_balance"`
 }
 
 type accountsModel struct {
-	mode       accountsMode
-	accounts   []Account
-	accountTxs []Transaction
-	cursor     int
+	mode         accountsMode
+	accounts     []Account
+	cursor       int
+	baseCurrency string
+
+	register       AccountRegister
+	registerWindow int
+	registerCursor int
+	registerOffset int
 
 	formEditing     bool
 	formFieldCursor int
 	nameInput       textinput.Model
+	currencyInput   textinput.Model
 	balanceInput    textinput.Model
 	formTypeIndex   int
 
 	confirmCursor int
 
+	reconcileFieldCursor int
+	statementDateInput   textinput.Model
+	statementBalInput    textinput.Model
+	statementDate        time.Time
+	statementBalance     decimal.Decimal
+	reconcileEntries     []RegisterEntry
+	reconcileCursor      int
+
 	errorMsg string
 }
 
-var accountTypes = []string{
-	"Checking",
-	"Savings",
-	"Credit Card",
-	"Investing",
+// accountTypeOption is one of the account types the API accepts.
+// Liability balances are negative: they're what's owed.
+type accountTypeOption struct {
+	value     string
+	label     string
+	liability bool
+}
+
+var accountTypes = []accountTypeOption{
+	{value: "checking", label: "Checking"},
+	{value: "savings", label: "Savings"},
+	{value: "cash", label: "Cash"},
+	{value: "investment", label: "Investment"},
+	{value: "other_asset", label: "Other Asset"},
+	{value: "credit_card", label: "Credit Card", liability: true},
+	{value: "line_of_credit", label: "Line of Credit", liability: true},
+	{value: "loan", label: "Loan", liability: true},
+	{value: "mortgage", label: "Mortgage", liability: true},
+	{value: "other_liability", label: "Other Liability", liability: true},
+}
+
+// accountType looks up an account's type, treating anything unknown as an
+// other asset like the server does.
+func accountType(value string) accountTypeOption {
+	for _, option := range accountTypes {
+		if option.value == value {
+			return option
+		}
+	}
+	return accountTypes[4]
 }
 
 func initialAccountModel() accountsModel {
@@ -72,20 +140,39 @@ func initialAccountModel() accountsModel {
 	name.CharLimit = 64
 	name.Blur()
 
+	accountCurrency := textinput.New()
+	accountCurrency.Placeholder = "USD"
+	accountCurrency.CharLimit = 3
+	accountCurrency.Blur()
+
 	balance := textinput.New()
 	balance.CharLimit = 16
 	balance.Blur()
 
+	statementDate := textinput.New()
+	statementDate.Placeholder = "YYYY-MM-DD"
+	statementDate.CharLimit = 10
+	statementDate.Blur()
+
+	statementBal := textinput.New()
+	statementBal.CharLimit = 16
+	statementBal.Blur()
+
 	return accountsModel{
 		accounts:        []Account{},
 		cursor:          0,
 		formEditing:     false,
 		formFieldCursor: formFieldName,
 		nameInput:       name,
+		currencyInput:   accountCurrency,
 		balanceInput:    balance,
 		formTypeIndex:   0,
 		confirmCursor:   confirmCancel,
-		errorMsg:        "",
+
+		statementDateInput: statementDate,
+		statementBalInput:  statementBal,
+
+		errorMsg: "",
 	}
 }
 
@@ -102,16 +189,30 @@ func (m accountsModel) Update(msg tea.Msg) (accountsModel, tea.Cmd) {
 			return accountsReloadRequestedMsg{}
 		}
 
-	case loadAccountTxsMsg:
+	case loadAccountRegisterMsg:
 		if msg.err != nil {
 			m.errorMsg = msg.err.Error()
 			return m, nil
 		}
 		m.errorMsg = ""
-		m.accountTxs = msg.accountTxs
+		m.register = msg.register
+		// Start at the newest transaction, which is at the bottom.
+		m.registerCursor = max(len(m.register.Entries)-1, 0)
+		m.registerOffset = max(len(m.register.Entries)-registerPageSize, 0)
 		m.mode = accountsModeDetails
 		return m, nil
 
+	case reconcileEntriesLoadedMsg:
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.errorMsg = ""
+		m.reconcileEntries = msg.entries
+		m.reconcileCursor = 0
+		m.mode = accountsModeReconcile
+		return m, nil
+
 	case accountUpdatedMsg:
 		if msg.err != nil {
 			m.errorMsg = msg.err.Error()
@@ -122,6 +223,32 @@ func (m accountsModel) Update(msg tea.Msg) (accountsModel, tea.Cmd) {
 			return accountsReloadRequestedMsg{}
 		}
 
+	case transactionPostedMsg:
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.errorMsg = ""
+		for i, entry := range m.reconcileEntries {
+			if entry.ID == msg.transaction.ID {
+				m.reconcileEntries[i].Posted = msg.transaction.Posted
+			}
+		}
+		for i, entry := range m.register.Entries {
+			if entry.ID == msg.transaction.ID {
+				m.register.Entries[i].Posted = msg.transaction.Posted
+			}
+		}
+		return m, nil
+
+	case accountReconciledMsg:
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.errorMsg = ""
+		return m, m.loadRegister()
+
 	case accountDeletedMsg:
 		if msg.err != nil {
 			m.errorMsg = msg.err.Error()
@@ -165,8 +292,10 @@ func (m accountsModel) Update(msg tea.Msg) (accountsModel, tea.Cmd) {
 					m.cursor++
 				}
 			case "enter":
-				// m.mode = accountsModeDetails
-				return m, submitLoadAccountTxsMsg(m.accounts[m.cursor].ID)
+				if len(m.accounts) > 0 {
+					m.registerWindow = 0
+					return m, m.loadRegister()
+				}
 
 			case "n":
 				m.mode = accountsModeFormNew
@@ -177,6 +306,9 @@ func (m accountsModel) Update(msg tea.Msg) (accountsModel, tea.Cmd) {
 				m.nameInput.SetValue("")
 				m.nameInput.Blur()
 
+				m.currencyInput.SetValue(m.baseCurrency)
+				m.currencyInput.Blur()
+
 				m.balanceInput.SetValue("")
 				m.balanceInput.Blur()
 
@@ -192,6 +324,21 @@ func (m accountsModel) Update(msg tea.Msg) (accountsModel, tea.Cmd) {
 			switch key {
 			case "esc":
 				m.mode = accountsModeList
+			case "up", "k":
+				m.moveRegisterCursor(-1)
+			case "down", "j":
+				m.moveRegisterCursor(1)
+			case "pgup", "ctrl+u":
+				m.moveRegisterCursor(-registerPageSize)
+			case "pgdown", "ctrl+d":
+				m.moveRegisterCursor(registerPageSize)
+			case "g":
+				m.moveRegisterCursor(-len(m.register.Entries))
+			case "G":
+				m.moveRegisterCursor(len(m.register.Entries))
+			case "w":
+				m.registerWindow = (m.registerWindow + 1) % len(registerWindows)
+				return m, m.loadRegister()
 			case "d":
 				m.mode = accountsModeDelete
 				m.confirmCursor = confirmCancel
@@ -202,13 +349,113 @@ func (m accountsModel) Update(msg tea.Msg) (accountsModel, tea.Cmd) {
 
 				m.nameInput.SetValue(m.accounts[m.cursor].AccountName)
 				m.nameInput.Blur()
-				m.formTypeIndex = slices.Index(accountTypes, (m.accounts[m.cursor].AccountType))
+				m.formTypeIndex = slices.Index(accountTypes, accountType(m.accounts[m.cursor].AccountType))
+			case "r":
+				m.mode = accountsModeReconcileForm
+				m.reconcileFieldCursor = reconcileFieldDate
+				m.formEditing = false
+				m.statementDateInput.SetValue(time.Now().Format(time.DateOnly))
+				m.statementDateInput.Blur()
+				m.statementBalInput.SetValue("")
+				m.statementBalInput.Blur()
+				m.errorMsg = ""
+			}
+		case accountsModeReconcileForm:
+			if m.formEditing {
+				if key == "esc" || key == "enter" {
+					m.formEditing = false
+					m.statementDateInput.Blur()
+					m.statementBalInput.Blur()
+					return m, nil
+				}
+
+				var cmd tea.Cmd
+				switch m.reconcileFieldCursor {
+				case reconcileFieldDate:
+					m.statementDateInput, cmd = m.statementDateInput.Update(msg)
+				case reconcileFieldBalance:
+					m.statementBalInput, cmd = m.statementBalInput.Update(msg)
+				}
+				return m, cmd
+			}
+
+			switch key {
+			case "esc":
+				m.mode = accountsModeDetails
+				m.errorMsg = ""
+			case "up", "k":
+				if m.reconcileFieldCursor > reconcileFieldDate {
+					m.reconcileFieldCursor--
+				}
+			case "down", "j":
+				if m.reconcileFieldCursor < reconcileFieldStart {
+					m.reconcileFieldCursor++
+				}
+			case "enter":
+				switch m.reconcileFieldCursor {
+				case reconcileFieldDate:
+					m.formEditing = true
+					m.statementDateInput.Focus()
+				case reconcileFieldBalance:
+					m.formEditing = true
+					m.statementBalInput.Focus()
+				case reconcileFieldStart:
+					statementDate, err := time.Parse(time.DateOnly, m.statementDateInput.Value())
+					if err != nil {
+						m.errorMsg = fmt.Sprintf("invalid statement date: %s", err)
+						return m, nil
+					}
+					statementBalance, err := decimal.NewFromString(m.statementBalInput.Value())
+					if err != nil {
+						m.errorMsg = fmt.Sprintf("invalid statement balance: %s", err)
+						return m, nil
+					}
+					// Card statements show what's owed, which is a negative
+					// balance here.
+					if accountType(m.accounts[m.cursor].AccountType).liability {
+						statementBalance = statementBalance.Neg()
+					}
+					m.statementDate = statementDate
+					m.statementBalance = statementBalance
+					m.errorMsg = ""
+					return m, submitReconcileStartMsg(m.accounts[m.cursor].ID, statementDate)
+				}
+			}
+		case accountsModeReconcile:
+			txs := m.reconcileTxs()
+			switch key {
+			case "esc":
+				m.errorMsg = ""
+				return m, m.loadRegister()
+			case "up", "k":
+				if m.reconcileCursor > 0 {
+					m.reconcileCursor--
+				}
+			case "down", "j":
+				if m.reconcileCursor < len(txs)-1 {
+					m.reconcileCursor++
+				}
+			case " ", "c":
+				if len(txs) > 0 {
+					entry := txs[m.reconcileCursor]
+					return m, submitTransactionPostedMsg(entry.ID, !entry.Posted)
+				}
+			case "f":
+				if !m.reconcileDifference().IsZero() {
+					m.errorMsg = "The difference must be zero to finish reconciling"
+					return m, nil
+				}
+				return m, submitReconcileAccountMsg(m.accounts[m.cursor].ID, ReconcileRequest{
+					StatementDate:    m.statementDate,
+					StatementBalance: m.statementBalance,
+				})
 			}
 		case accountsModeFormNew, accountsModeFormEdit:
 			if m.formEditing {
 				if key == "esc" {
 					m.formEditing = false
 					m.nameInput.Blur()
+					m.currencyInput.Blur()
 					m.balanceInput.Blur()
 					return m, nil
 				}
@@ -218,6 +465,10 @@ func (m accountsModel) Update(msg tea.Msg) (accountsModel, tea.Cmd) {
 					var cmd tea.Cmd
 					m.nameInput, cmd = m.nameInput.Update(msg)
 					return m, cmd
+				case formFieldCurrency:
+					var cmd tea.Cmd
+					m.currencyInput, cmd = m.currencyInput.Update(msg)
+					return m, cmd
 				case formFieldBalance:
 					var cmd tea.Cmd
 					m.balanceInput, cmd = m.balanceInput.Update(msg)
@@ -234,26 +485,33 @@ func (m accountsModel) Update(msg tea.Msg) (accountsModel, tea.Cmd) {
 				if m.formFieldCursor > formFieldName {
 					m.formFieldCursor--
 				}
-				if m.mode == accountsModeFormEdit && m.formFieldCursor == formFieldBalance {
+				// The currency and opening balance are only set when the
+				// account is created.
+				if m.mode == accountsModeFormEdit && (m.formFieldCursor == formFieldCurrency || m.formFieldCursor == formFieldBalance) {
 					m.formFieldCursor = formFieldType
 				}
 			case "down", "j":
 				if m.formFieldCursor < formFieldSave {
 					m.formFieldCursor++
 				}
-				if m.mode == accountsModeFormEdit && m.formFieldCursor == formFieldBalance {
+				if m.mode == accountsModeFormEdit && (m.formFieldCursor == formFieldCurrency || m.formFieldCursor == formFieldBalance) {
 					m.formFieldCursor = formFieldSave
 				}
 			case "enter":
 				switch m.formFieldCursor {
-				case formFieldName, formFieldBalance:
+				case formFieldName, formFieldCurrency, formFieldBalance:
 					m.formEditing = true
 					m.nameInput.Blur()
+					m.currencyInput.Blur()
 					m.balanceInput.Blur()
 
 					switch m.formFieldCursor {
 					case formFieldName:
 						m.nameInput.Focus()
+					case formFieldCurrency:
+						if m.mode == accountsModeFormNew {
+							m.currencyInput.Focus()
+						}
 					case formFieldBalance:
 						if m.mode == accountsModeFormNew {
 							m.balanceInput.Focus()
@@ -263,9 +521,10 @@ func (m accountsModel) Update(msg tea.Msg) (accountsModel, tea.Cmd) {
 					switch m.mode {
 					case accountsModeFormNew:
 						name := m.nameInput.Value()
-						accountType := accountTypes[m.formTypeIndex]
+						accountType := accountTypes[m.formTypeIndex].value
+						accountCurrency := m.currencyInput.Value()
 						balance := m.balanceInput.Value()
-						return m, submitCreateAccountMsg(name, accountType, balance)
+						return m, submitCreateAccountMsg(name, accountType, accountCurrency, balance)
 					case accountsModeFormEdit:
 						id := m.accounts[m.cursor].ID
 						name := m.nameInput.Value()
@@ -278,6 +537,10 @@ func (m accountsModel) Update(msg tea.Msg) (accountsModel, tea.Cmd) {
 					var cmd tea.Cmd
 					m.nameInput, cmd = m.nameInput.Update(msg)
 					return m, cmd
+				case formFieldCurrency:
+					var cmd tea.Cmd
+					m.currencyInput, cmd = m.currencyInput.Update(msg)
+					return m, cmd
 				case formFieldBalance:
 					var cmd tea.Cmd
 					m.balanceInput, cmd = m.balanceInput.Update(msg)
@@ -335,26 +598,68 @@ func (m accountsModel) View() string {
 				cursor = ">"
 			}
 
-			s += fmt.Sprintf("%s %s\n  - $%s\n\n", cursor, account.AccountName, account.AccountBalance.String())
+			s += fmt.Sprintf("%s %s (%s)\n", cursor, account.AccountName, accountType(account.AccountType).label)
+			s += fmt.Sprintf("  - Cleared: %s | Uncleared: %s | Working: %s | Future: %s\n\n",
+				formatMoney(account.ClearedBalance, account.Currency),
+				formatMoney(account.UnclearedBalance, account.Currency),
+				formatMoney(account.WorkingBalance, account.Currency),
+				formatMoney(account.FutureAmount, account.Currency))
 		}
+		s += m.netWorthView()
 		s += "\n(Use 'j'/'k' to move, 'enter' to view details, 'n' to create new account, 'd' to delete account)\n"
 		return s
 	case accountsModeDetails:
 		acc := m.accounts[m.cursor]
 		s := "Account Details\n\n"
 		s += m.errorView()
-		s += fmt.Sprintf("Name: %s\nType: %s\nBalance: $%s\n\n", acc.AccountName,
-			acc.AccountType,
-			acc.AccountBalance.String())
-
-		s += "Transactions\n\n"
-		for _, transaction := range m.accountTxs {
+		s += fmt.Sprintf("Name: %s\nType: %s\nCurrency: %s\n", acc.AccountName, accountType(acc.AccountType).label, acc.Currency)
+		s += fmt.Sprintf("Cleared Balance: %s\nUncleared Balance: %s\nWorking Balance: %s\nFuture Transactions: %s\n",
+			formatMoney(acc.ClearedBalance, acc.Currency),
+			formatMoney(acc.UnclearedBalance, acc.Currency),
+			formatMoney(acc.WorkingBalance, acc.Currency),
+			formatMoney(acc.FutureAmount, acc.Currency))
+		if accountType(acc.AccountType).liability {
+			s += fmt.Sprintf("Owed: %s\n", formatMoney(acc.WorkingBalance.Neg(), acc.Currency))
+		}
+		if acc.Currency != m.baseCurrency {
+			if acc.BaseWorkingBalance.Valid {
+				s += fmt.Sprintf("Working Balance in %s: %s\n", m.baseCurrency, formatMoney(acc.BaseWorkingBalance.Decimal, m.baseCurrency))
+			} else {
+				s += fmt.Sprintf("Working Balance in %s: no exchange rate for %s\n", m.baseCurrency, acc.Currency)
+			}
+		}
+		s += "\n"
 
-			dateStr := transaction.TxDate.Format("2006-01-02")
-			s += fmt.Sprintf("%s | %s | %s\n", dateStr, transaction.TxDescription, transaction.Amount)
+		s += fmt.Sprintf("Register - %s\n\n", registerWindows[m.registerWindow])
+		s += fmt.Sprintf("  %-10s  %-24s  %-18s  %10s  %s  %10s\n", "Date", "Payee/Description", "Category", "Amount", "C", "Balance")
+		if len(m.register.Entries) == 0 {
+			s += "\n  No transactions in this range.\n"
+		}
+		if m.register.StartDate != nil && m.registerOffset == 0 {
+			s += fmt.Sprintf("  %-10s  %-24s  %-18s  %10s  %s  %10s\n", "", "Opening balance", "", "", " ", m.register.OpeningBalance.StringFixed(2))
+		}
+		end := min(m.registerOffset+registerPageSize, len(m.register.Entries))
+		for i := m.registerOffset; i < end; i++ {
+			entry := m.register.Entries[i]
+			cursor := " "
+			if m.registerCursor == i {
+				cursor = ">"
+			}
+			s += fmt.Sprintf("%s %-10s  %-24s  %-18s  %10s  %s  %10s\n",
+				cursor,
+				entry.TxDate.Format(time.DateOnly),
+				truncate(registerPayee(entry), 24),
+				truncate(registerCategory(entry), 18),
+				entry.Amount.StringFixed(2),
+				clearedFlag(entry),
+				entry.Balance.StringFixed(2))
+		}
+		if len(m.register.Entries) > registerPageSize {
+			s += fmt.Sprintf("\n  Showing %d-%d of %d\n", m.registerOffset+1, end, len(m.register.Entries))
 		}
 
-		s += "\n(Press 'esc' to go back, 'e' to edit, 'd' to delete)\n"
+		s += "\n(C = cleared, R = reconciled)\n"
+		s += "(Use 'j'/'k' to scroll, 'g'/'G' for top/bottom, 'w' to change range, 'esc' to go back, 'e' to edit, 'd' to delete, 'r' to reconcile)\n"
 
 		return s
 
@@ -371,8 +676,13 @@ func (m accountsModel) View() string {
 		}
 
 		s += fmt.Sprintf("%s Name: %s\n", currentRow(formFieldName), m.nameInput.View())
-		s += fmt.Sprintf("%s Type ('h'/'l' to change): %s\n", currentRow(formFieldType), accountTypes[m.formTypeIndex])
-		s += fmt.Sprintf("%s Initial Balance: %s\n", currentRow(formFieldBalance), m.balanceInput.View())
+		s += fmt.Sprintf("%s Type ('h'/'l' to change): %s\n", currentRow(formFieldType), accountTypes[m.formTypeIndex].label)
+		s += fmt.Sprintf("%s Currency: %s\n", currentRow(formFieldCurrency), m.currencyInput.View())
+		if accountTypes[m.formTypeIndex].liability {
+			s += fmt.Sprintf("%s Amount Owed: %s\n", currentRow(formFieldBalance), m.balanceInput.View())
+		} else {
+			s += fmt.Sprintf("%s Initial Balance: %s\n", currentRow(formFieldBalance), m.balanceInput.View())
+		}
 		s += "\n"
 		s += fmt.Sprintf("%s [ Save ]\n", currentRow(formFieldSave))
 		s += "\n(Use 'j'/'k' to move, 'enter' to edit field, 'esc' to stop editing, 'esc' again to cancel)\n"
@@ -391,11 +701,64 @@ func (m accountsModel) View() string {
 		}
 
 		s += fmt.Sprintf("%s Name: %s\n", currentRow(formFieldName), m.nameInput.View())
-		s += fmt.Sprintf("%s Type: %s\n", currentRow(formFieldType), accountTypes[m.formTypeIndex])
+		s += fmt.Sprintf("%s Type: %s\n", currentRow(formFieldType), accountTypes[m.formTypeIndex].label)
 		s += "\n"
 		s += fmt.Sprintf("%s [ Save ]\n", currentRow(formFieldSave))
 		s += "\n(Use 'j'/'k' to move, 'enter' to edit field, 'esc' to stop editing, 'esc' again to cancel)\n"
 
+		return s
+	case accountsModeReconcileForm:
+		s := fmt.Sprintf("Reconcile %s\n\n", m.accounts[m.cursor].AccountName)
+
+		s += m.errorView()
+
+		currentRow := func(field int) string {
+			if m.reconcileFieldCursor == field {
+				return ">"
+			}
+			return " "
+		}
+
+		s += fmt.Sprintf("%s Statement ending date: %s\n", currentRow(reconcileFieldDate), m.statementDateInput.View())
+		if accountType(m.accounts[m.cursor].AccountType).liability {
+			s += fmt.Sprintf("%s Statement balance owed: %s\n", currentRow(reconcileFieldBalance), m.statementBalInput.View())
+		} else {
+			s += fmt.Sprintf("%s Statement ending balance: %s\n", currentRow(reconcileFieldBalance), m.statementBalInput.View())
+		}
+		s += "\n"
+		s += fmt.Sprintf("%s [ Start ]\n", currentRow(reconcileFieldStart))
+		s += "\n(Use 'j'/'k' to move, 'enter' to edit field, 'esc' to stop editing, 'esc' again to cancel)\n"
+
+		return s
+	case accountsModeReconcile:
+		s := fmt.Sprintf("Reconcile %s through %s\n\n", m.accounts[m.cursor].AccountName, m.statementDate.Format(time.DateOnly))
+
+		s += m.errorView()
+
+		cleared := m.clearedBalance()
+		accountCurrency := m.accounts[m.cursor].Currency
+		s += fmt.Sprintf("Statement balance: %s\n", formatMoney(m.statementBalance, accountCurrency))
+		s += fmt.Sprintf("Cleared balance:   %s\n", formatMoney(cleared, accountCurrency))
+		s += fmt.Sprintf("Difference:        %s\n\n", formatMoney(m.reconcileDifference(), accountCurrency))
+
+		txs := m.reconcileTxs()
+		if len(txs) == 0 {
+			s += "No unreconciled transactions on or before the statement date.\n"
+		}
+		for i, entry := range txs {
+			cursor := " "
+			if m.reconcileCursor == i {
+				cursor = ">"
+			}
+			check := "[ ]"
+			if entry.Posted {
+				check = "[x]"
+			}
+			s += fmt.Sprintf("%s %s %s | %s | %s\n", cursor, check, entry.TxDate.Format(time.DateOnly), registerPayee(entry), entry.Amount.StringFixed(2))
+		}
+
+		s += "\n(Use 'j'/'k' to move, 'space' to toggle cleared, 'f' to finish when the difference is zero, 'esc' to cancel)\n"
+
 		return s
 	case accountsModeDelete:
 		s := "Delete Account\n\n"
@@ -420,6 +783,156 @@ func (m accountsModel) View() string {
 	return "Unknown accounts mode\n"
 }
 
+// loadRegister reloads the selected account's register for the current
+// window.
+func (m accountsModel) loadRegister() tea.Cmd {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var start time.Time
+	switch m.registerWindow {
+	case 1:
+		start = today.AddDate(0, 0, -29)
+	case 2:
+		start = today.AddDate(0, 0, -89)
+	case 3:
+		start = time.Date(today.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return submitLoadAccountRegisterMsg(m.accounts[m.cursor].ID, start, time.Time{})
+}
+
+func (m *accountsModel) moveRegisterCursor(offset int) {
+	if len(m.register.Entries) == 0 {
+		return
+	}
+	m.registerCursor = min(max(m.registerCursor+offset, 0), len(m.register.Entries)-1)
+	if m.registerCursor < m.registerOffset {
+		m.registerOffset = m.registerCursor
+	}
+	if m.registerCursor >= m.registerOffset+registerPageSize {
+		m.registerOffset = m.registerCursor - registerPageSize + 1
+	}
+}
+
+// reconcileTxs returns the unreconciled transactions up to the statement
+// date, oldest first.
+func (m accountsModel) reconcileTxs() []RegisterEntry {
+	var entries []RegisterEntry
+	for _, entry := range m.reconcileEntries {
+		if !entry.Reconciled {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// clearedBalance sums every cleared transaction up to the statement date,
+// including ones reconciled against earlier statements.
+func (m accountsModel) clearedBalance() decimal.Decimal {
+	balance := decimal.Zero
+	for _, entry := range m.reconcileEntries {
+		if entry.Posted {
+			balance = balance.Add(entry.Amount)
+		}
+	}
+	return balance
+}
+
+func (m accountsModel) reconcileDifference() decimal.Decimal {
+	return m.statementBalance.Sub(m.clearedBalance())
+}
+
+func clearedFlag(entry RegisterEntry) string {
+	switch {
+	case entry.Reconciled:
+		return "R"
+	case entry.Posted:
+		return "C"
+	}
+	return " "
+}
+
+func registerPayee(entry RegisterEntry) string {
+	if entry.PayeeName != "" {
+		return entry.PayeeName
+	}
+	return entry.TxDescription
+}
+
+func registerCategory(entry RegisterEntry) string {
+	switch {
+	case entry.Split:
+		return "Split"
+	case entry.TransferID != uuid.Nil:
+		return "Transfer: " + entry.TransferAccountName
+	case entry.CategoryName != "":
+		return entry.CategoryName
+	}
+	return "Uncategorized"
+}
+
+// truncate shortens s to fit in width columns.
+// netWorthView totals the working balances in the base currency. Accounts
+// without an exchange rate are left out and listed instead.
+func (m accountsModel) netWorthView() string {
+	if len(m.accounts) == 0 {
+		return ""
+	}
+
+	assets := decimal.Zero
+	liabilities := decimal.Zero
+	missing := []string{}
+	for _, account := range m.accounts {
+		if !account.BaseWorkingBalance.Valid {
+			missing = append(missing, account.AccountName)
+			continue
+		}
+		if accountType(account.AccountType).liability {
+			liabilities = liabilities.Sub(account.BaseWorkingBalance.Decimal)
+		} else {
+			assets = assets.Add(account.BaseWorkingBalance.Decimal)
+		}
+	}
+
+	s := fmt.Sprintf("Net worth: %s (assets %s, liabilities %s)\n",
+		formatMoney(assets.Sub(liabilities), m.baseCurrency),
+		formatMoney(assets, m.baseCurrency),
+		formatMoney(liabilities, m.baseCurrency))
+	if len(missing) > 0 {
+		s += fmt.Sprintf("  Not included, no exchange rate: %s\n", strings.Join(missing, ", "))
+	}
+	return s
+}
+
+// currencySymbols are the currencies shown with a symbol rather than their
+// code.
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+}
+
+// formatMoney shows an amount in its currency, like "$12.50" or
+// "12.50 CHF". An empty currency is treated as dollars, which is what an
+// older server means.
+func formatMoney(amount decimal.Decimal, currencyCode string) string {
+	if currencyCode == "" {
+		currencyCode = "USD"
+	}
+	if symbol, ok := currencySymbols[currencyCode]; ok {
+		return symbol + amount.StringFixed(2)
+	}
+	return amount.StringFixed(2) + " " + currencyCode
+}
+
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
+
 func (m accountsModel) errorView() string {
 	if m.errorMsg == "" {
 		return ""
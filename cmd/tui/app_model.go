@@ -25,7 +25,12 @@ const (
 	navCategories
 	navGroups
 	navAccounts
+	navNetWorth
 	navTransactions
+	navScheduled
+	navDuplicates
+	navRules
+	navExport
 )
 
 type section int
@@ -35,7 +40,12 @@ const (
 	sectionCategories
 	sectionGroups
 	sectionAccounts
+	sectionNetWorth
 	sectionTransactions
+	sectionScheduled
+	sectionDuplicates
+	sectionRules
+	sectionExport
 )
 
 type focus int
@@ -55,9 +65,16 @@ type model struct {
 
 	client          *Client
 	accountsAPI     AccountsAPI
+	netWorthAPI     NetWorthAPI
 	transactionsAPI TransactionsAPI
 	categoriesAPI   CategoriesAPI
 	groupsAPI       GroupsAPI
+	scheduledAPI    ScheduledAPI
+	duplicatesAPI   DuplicatesAPI
+	payeesAPI       PayeesAPI
+	tagsAPI         TagsAPI
+	rulesAPI        RulesAPI
+	exportAPI       ExportAPI
 
 	navItems  []string
 	navCursor int
@@ -69,7 +86,12 @@ type model struct {
 	categoriesModel   categoriesModel
 	groupsModel       groupsModel
 	accountsModel     accountsModel
+	netWorthModel     netWorthModel
 	transactionsModel transactionsModel
+	scheduledModel    scheduledModel
+	duplicatesModel   duplicatesModel
+	rulesModel        rulesModel
+	exportModel       exportModel
 
 	focus  focus
 	width  int
@@ -92,19 +114,31 @@ func initialModel(client *Client) model {
 		loginUsername: username,
 		loginPassword: password,
 
-		navItems:          []string{"Budget", "Categories", "Category Groups", "Accounts", "Transactions"},
+		navItems:          []string{"Budget", "Categories", "Category Groups", "Accounts", "Net Worth", "Transactions", "Scheduled", "Duplicates", "Rules", "Export"},
 		navCursor:         0,
 		currentSection:    sectionBudget,
 		budgetModel:       initialBudgetModel(),
 		budgetAPI:         client.Budget(),
 		accountsModel:     initialAccountModel(),
 		accountsAPI:       client.Accounts(),
+		netWorthModel:     initialNetWorthModel(),
+		netWorthAPI:       client.NetWorth(),
 		transactionsModel: initialTransactionsModel(),
 		transactionsAPI:   client.Transactions(),
 		categoriesModel:   initialCategoriesModel(),
 		categoriesAPI:     client.Categories(),
 		groupsModel:       initialGroupsModel(),
 		groupsAPI:         client.Groups(),
+		scheduledModel:    initialScheduledModel(),
+		scheduledAPI:      client.Scheduled(),
+		duplicatesModel:   initialDuplicatesModel(),
+		duplicatesAPI:     client.Duplicates(),
+		payeesAPI:         client.Payees(),
+		tagsAPI:           client.Tags(),
+		rulesModel:        initialRulesModel(),
+		rulesAPI:          client.Rules(),
+		exportModel:       initialExportModel(),
+		exportAPI:         client.Export(),
 	}
 }
 
@@ -148,12 +182,16 @@ func (m model) updateMain(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		m.accountsModel.accounts = msg.accounts
 		m.accountsModel.cursor = 0
+		m.transactionsModel.accountCurrencies = make(map[uuid.UUID]string, len(msg.accounts))
+		for _, account := range msg.accounts {
+			m.transactionsModel.accountCurrencies[account.ID] = account.Currency
+		}
 		return m, nil
 
-	case loadAccountTxsSubmittedMsg:
-		return m, loadAccountTxsCmd(m.accountsAPI, msg.accountID)
+	case loadAccountRegisterSubmittedMsg:
+		return m, loadAccountRegisterCmd(m.accountsAPI, msg.accountID, msg.start, msg.end)
 
-	case loadAccountTxsMsg:
+	case loadAccountRegisterMsg:
 		var cmd tea.Cmd
 		m.accountsModel, cmd = m.accountsModel.Update(msg)
 		return m, cmd
@@ -171,6 +209,7 @@ func (m model) updateMain(msg tea.Msg) (tea.Model, tea.Cmd) {
 		req := CreateAccountRequest{
 			AccountName:    msg.Name,
 			AccountType:    msg.Type,
+			Currency:       msg.Currency,
 			InitialBalance: balanceDecimal,
 		}
 
@@ -201,9 +240,38 @@ func (m model) updateMain(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.accountsModel, cmd = m.accountsModel.Update(msg)
 		return m, cmd
 
+	case transactionPostedSubmittedMsg:
+		return m, setTransactionPostedCmd(m.accountsAPI, msg.TransactionID, msg.Posted)
+
+	case transactionPostedMsg:
+		var cmd tea.Cmd
+		m.accountsModel, cmd = m.accountsModel.Update(msg)
+		if msg.err != nil {
+			return m, cmd
+		}
+		return m, tea.Batch(cmd, func() tea.Msg {
+			return transactionsReloadRequestedMsg{}
+		})
+
+	case reconcileStartSubmittedMsg:
+		return m, loadReconcileEntriesCmd(m.accountsAPI, msg.AccountID, msg.StatementDate)
+
+	case reconcileEntriesLoadedMsg:
+		var cmd tea.Cmd
+		m.accountsModel, cmd = m.accountsModel.Update(msg)
+		return m, cmd
+
+	case accountReconcileSubmittedMsg:
+		return m, reconcileAccountCmd(m.accountsAPI, msg.AccountID, msg.Request)
+
+	case accountReconciledMsg:
+		var cmd tea.Cmd
+		m.accountsModel, cmd = m.accountsModel.Update(msg)
+		return m, cmd
+
 	// Budget
 	case budgetReloadRequestedMsg:
-		cmd := loadBudgetCmd(m.budgetAPI)
+		cmd := loadBudgetCmd(m.budgetAPI, m.budgetModel.month)
 		return m, cmd
 
 	case budgetLoadedMsg:
@@ -211,6 +279,26 @@ func (m model) updateMain(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.budgetModel, cmd = m.budgetModel.Update(msg)
 		return m, cmd
 
+	case categoryBudgetSubmittedMsg:
+		assignedDecimal, err := decimal.NewFromString(msg.AssignedText)
+		if err != nil {
+			var cmd tea.Cmd
+			m.budgetModel, cmd = m.budgetModel.Update(categoryBudgetSetMsg{
+				err: fmt.Errorf("invalid amount: %w", err),
+			})
+			return m, cmd
+		}
+
+		req := SetCategoryBudgetRequest{
+			Assigned: assignedDecimal,
+		}
+		return m, setCategoryBudgetCmd(m.budgetAPI, msg.CategoryID, msg.Month, req)
+
+	case categoryBudgetSetMsg:
+		var cmd tea.Cmd
+		m.budgetModel, cmd = m.budgetModel.Update(msg)
+		return m, cmd
+
 	// Categories
 	case categoriesReloadRequestedMsg:
 		cmd := loadCategoriesCmd(m.categoriesAPI)
@@ -230,7 +318,7 @@ func (m model) updateMain(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case loadCategoryTxsSubmittedMsg:
-		return m, loadCategoryTxsCmd(m.categoriesAPI, msg.categoryID)
+		return m, loadCategoryTxsCmd(m.categoriesAPI, msg.categoryID, msg.cursor)
 
 	case loadCategoryTxsMsg:
 		var cmd tea.Cmd
@@ -247,9 +335,11 @@ func (m model) updateMain(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 		req := CreateCategoryRequest{
-			Name:    msg.Name,
-			Budget:  budgetDecimal,
-			GroupID: msg.GroupID,
+			Name:                 msg.Name,
+			Budget:               budgetDecimal,
+			GroupID:              msg.GroupID,
+			RolloverOverspending: msg.RolloverOverspending,
+			IsIncome:             msg.IsIncome,
 		}
 		return m, createCategoryCmd(m.categoriesAPI, req)
 
@@ -269,9 +359,11 @@ func (m model) updateMain(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		req := UpdateCategoryRequest{
-			Name:    msg.Name,
-			Budget:  budgetDecimal,
-			GroupID: msg.GroupID,
+			Name:                 msg.Name,
+			Budget:               budgetDecimal,
+			GroupID:              msg.GroupID,
+			RolloverOverspending: msg.RolloverOverspending,
+			IsIncome:             msg.IsIncome,
 		}
 		return m, updateCategoryCmd(m.categoriesAPI, msg.CategoryID, req)
 
@@ -312,6 +404,8 @@ func (m model) updateMain(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cm.budgetInput.SetValue("")
 		cm.budgetInput.Blur()
 		cm.formGroupIndex = 0
+		cm.formRollover = true
+		cm.formIncome = false
 		cm.errorMsg = ""
 		m.categoriesModel = cm
 		return m, nil
@@ -340,6 +434,8 @@ func (m model) updateMain(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cm.nameInput.Blur()
 		cm.budgetInput.SetValue(currentCat.Budget.String())
 		cm.budgetInput.Blur()
+		cm.formRollover = currentCat.RolloverOverspending
+		cm.formIncome = currentCat.IsIncome
 		cm.formGroupIndex = 0
 		for i, option := range groupOptions {
 			if option.ID == currentCat.GroupID {
@@ -402,9 +498,32 @@ func (m model) updateMain(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// Transactions
 	case transactionsReloadRequestedMsg:
-		cmd := loadTransactionsCmd(m.transactionsAPI)
+		// Payees typed into the form are created by the server, so refresh
+		// the autocomplete list along with the transactions. Tag counts
+		// change with every save too.
+		return m, tea.Batch(loadTransactionsCmd(m.transactionsAPI, m.transactionsModel.filter, ""), loadPayeesCmd(m.payeesAPI), loadTagsCmd(m.tagsAPI))
+
+	case payeesLoadedMsg:
+		var cmd tea.Cmd
+		m.transactionsModel, cmd = m.transactionsModel.Update(msg)
 		return m, cmd
 
+	case tagsLoadedMsg:
+		var cmd tea.Cmd
+		m.transactionsModel, cmd = m.transactionsModel.Update(msg)
+		return m, cmd
+
+	case tagCreateSubmittedMsg:
+		return m, createTagCmd(m.tagsAPI, msg.TagName)
+
+	case tagCreatedMsg:
+		var cmd tea.Cmd
+		m.transactionsModel, cmd = m.transactionsModel.Update(msg)
+		return m, cmd
+
+	case transactionsMoreRequestedMsg:
+		return m, loadTransactionsCmd(m.transactionsAPI, m.transactionsModel.filter, m.transactionsModel.nextCursor)
+
 	case transactionsLoadedMsg:
 		if msg.err != nil {
 			var cmd tea.Cmd
@@ -414,10 +533,68 @@ func (m model) updateMain(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
-		m.transactionsModel.transactions = msg.transactions
-		m.transactionsModel.cursor = 0
+		tm := m.transactionsModel
+		if msg.cursor == "" {
+			tm.transactions = msg.transactions
+			tm.cursor = 0
+		} else {
+			// Drop pages from a listing that has since been reloaded.
+			if msg.cursor != tm.nextCursor {
+				return m, nil
+			}
+			tm.transactions = append(tm.transactions, msg.transactions...)
+		}
+		tm.nextCursor = msg.nextCursor
+		tm.loadingMore = false
+		tm.errorMsg = ""
+		m.transactionsModel = tm
+		return m, nil
+
+	case transactionsFilterRequestedMsg:
+		tm := m.transactionsModel
+		tm.filterAccountOptions = []txAccountOption{{Name: "Any"}}
+		for _, account := range m.accountsModel.accounts {
+			tm.filterAccountOptions = append(tm.filterAccountOptions, txAccountOption{
+				ID:   account.ID,
+				Name: account.AccountName,
+			})
+		}
+		tm.filterCategoryOptions = []txCategoryOption{{Name: "Any"}}
+		for _, category := range m.categoriesModel.categories {
+			tm.filterCategoryOptions = append(tm.filterCategoryOptions, txCategoryOption{
+				ID:   category.ID,
+				Name: category.CategoryName,
+			})
+		}
+		tm.filterGroupOptions = []txGroupOption{{Name: "Any"}}
+		for _, group := range m.groupsModel.groups {
+			tm.filterGroupOptions = append(tm.filterGroupOptions, txGroupOption{
+				ID:   group.ID,
+				Name: group.GroupName,
+			})
+		}
+		tm.filterTagOptions = []txTagOption{{Name: "Any"}}
+		for _, tag := range tm.tags {
+			tm.filterTagOptions = append(tm.filterTagOptions, txTagOption{
+				ID:   tag.ID,
+				Name: tag.TagName,
+			})
+		}
+		tm.openFilterForm()
+		m.transactionsModel = tm
 		return m, nil
 
+	case transactionsFilterSubmittedMsg:
+		filter, err := parseTransactionFilter(msg)
+		if err != nil {
+			m.transactionsModel.errorMsg = err.Error()
+			return m, nil
+		}
+		m.transactionsModel.filter = filter
+		m.transactionsModel.mode = transactionsModeList
+		m.transactionsModel.errorMsg = ""
+		return m, loadTransactionsCmd(m.transactionsAPI, filter, "")
+
 	case transactionCreateSubmittedMsg:
 		amountDecimal, err := decimal.NewFromString(msg.AmountText)
 		if err != nil {
@@ -452,13 +629,26 @@ func (m model) updateMain(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+		splits, err := parseSplitSubmissions(msg.Splits)
+		if err != nil {
+			var cmd tea.Cmd
+			m.transactionsModel, cmd = m.transactionsModel.Update(transactionCreatedMsg{
+				err: err,
+			})
+			return m, cmd
+		}
+
 		req := CreateTransactionRequest{
 			Amount:        amountDecimal,
 			TxDescription: msg.TxDescription,
+			Memo:          msg.Memo,
 			TxDate:        txDateTime,
 			Posted:        msg.Posted,
 			AccountID:     accountID,
 			CategoryID:    categoryID,
+			PayeeName:     msg.PayeeName,
+			Splits:        splits,
+			TagIDs:        msg.TagIDs,
 		}
 		return m, createTransactionCmd(m.transactionsAPI, req)
 
@@ -485,13 +675,26 @@ func (m model) updateMain(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+		splits, err := parseSplitSubmissions(msg.Splits)
+		if err != nil {
+			var cmd tea.Cmd
+			m.transactionsModel, cmd = m.transactionsModel.Update(transactionUpdatedMsg{
+				err: err,
+			})
+			return m, cmd
+		}
+
 		req := UpdateTransactionRequest{
 			Amount:        amountDecimal,
 			TxDescription: msg.Description,
+			Memo:          msg.Memo,
 			TxDate:        txDateTime,
 			Posted:        msg.Posted,
 			AccountID:     msg.AccountID,
 			CategoryID:    msg.CategoryID,
+			PayeeName:     msg.PayeeName,
+			Splits:        splits,
+			TagIDs:        msg.TagIDs,
 		}
 		return m, updateTransactionCmd(m.transactionsAPI, msg.TransactionID, req)
 
@@ -535,15 +738,93 @@ func (m model) updateMain(msg tea.Msg) (tea.Model, tea.Cmd) {
 		tm.amountInput.Blur()
 		tm.descriptionInput.SetValue("")
 		tm.descriptionInput.Blur()
+		tm.memoInput.SetValue("")
+		tm.memoInput.Blur()
+		tm.payeeInput.SetValue("")
+		tm.payeeInput.Blur()
 		tm.dateInput.SetValue("")
 		tm.dateInput.Blur()
 		tm.formPostedIndex = 0
 		tm.formAccountIndex = 0
 		tm.formCategoryIndex = 0
+		tm.splitLines = nil
+		tm.formTagIDs = map[uuid.UUID]bool{}
+		tm.errorMsg = ""
+		m.transactionsModel = tm
+		return m, nil
+
+	case transactionsTransferRequestedMsg:
+		if len(m.accountsModel.accounts) < 2 {
+			m.transactionsModel.errorMsg = "Transfers need at least two accounts"
+			return m, nil
+		}
+
+		accountOptions := make([]txAccountOption, len(m.accountsModel.accounts))
+		for i, account := range m.accountsModel.accounts {
+			accountOptions[i] = txAccountOption{
+				ID:   account.ID,
+				Name: account.AccountName,
+			}
+		}
+
+		tm := m.transactionsModel
+		tm.accountOptions = accountOptions
+		tm.mode = transactionsModeFormTransfer
+		tm.formFieldCursor = transferFormFieldFrom
+		tm.formEditing = false
+		tm.amountInput.SetValue("")
+		tm.amountInput.Blur()
+		tm.descriptionInput.SetValue("")
+		tm.descriptionInput.Blur()
+		tm.dateInput.SetValue("")
+		tm.dateInput.Blur()
+		tm.formPostedIndex = 0
+		tm.formAccountIndex = 0
+		tm.formToAccountIndex = 1
 		tm.errorMsg = ""
 		m.transactionsModel = tm
 		return m, nil
 
+	case transferCreateSubmittedMsg:
+		amountDecimal, err := decimal.NewFromString(msg.AmountText)
+		if err != nil {
+			var cmd tea.Cmd
+			m.transactionsModel, cmd = m.transactionsModel.Update(transferCreatedMsg{
+				err: fmt.Errorf("invalid amount: %w", err),
+			})
+			return m, cmd
+		}
+		txDateTime, err := time.Parse(time.DateOnly, msg.TxDate)
+		if err != nil {
+			var cmd tea.Cmd
+			m.transactionsModel, cmd = m.transactionsModel.Update(transferCreatedMsg{
+				err: fmt.Errorf("invalid date: %w", err),
+			})
+			return m, cmd
+		}
+		if msg.FromAccountID == msg.ToAccountID {
+			var cmd tea.Cmd
+			m.transactionsModel, cmd = m.transactionsModel.Update(transferCreatedMsg{
+				err: fmt.Errorf("from and to accounts must be different"),
+			})
+			return m, cmd
+		}
+
+		req := CreateTransferRequest{
+			FromAccountID: msg.FromAccountID,
+			ToAccountID:   msg.ToAccountID,
+			Amount:        amountDecimal,
+			TxDescription: msg.TxDescription,
+			TxDate:        txDateTime,
+			Posted:        msg.Posted,
+		}
+		return m, createTransferCmd(m.transactionsAPI, req)
+
+	case transferCreatedMsg:
+		var cmd tea.Cmd
+		m.transactionsModel, cmd = m.transactionsModel.Update(msg)
+		return m, cmd
+
 	case transactionsEditRequestedMsg:
 		accountOptions := make([]txAccountOption, len(m.accountsModel.accounts))
 		for i, account := range m.accountsModel.accounts {
@@ -572,6 +853,10 @@ func (m model) updateMain(msg tea.Msg) (tea.Model, tea.Cmd) {
 		tm.amountInput.Blur()
 		tm.descriptionInput.SetValue(currentTx.TxDescription)
 		tm.descriptionInput.Blur()
+		tm.memoInput.SetValue(currentTx.Memo)
+		tm.memoInput.Blur()
+		tm.payeeInput.SetValue(currentTx.PayeeName)
+		tm.payeeInput.Blur()
 		tm.dateInput.SetValue(currentTx.TxDate.Format("2006-01-02"))
 		tm.dateInput.Blur()
 
@@ -595,10 +880,194 @@ func (m model) updateMain(msg tea.Msg) (tea.Model, tea.Cmd) {
 				break
 			}
 		}
+		tm.splitLines = nil
+		for _, split := range currentTx.Splits {
+			tm.splitLines = append(tm.splitLines, newSplitLine(categoryOptions, split))
+		}
+		tm.formTagIDs = map[uuid.UUID]bool{}
+		for _, tag := range currentTx.Tags {
+			tm.formTagIDs[tag.ID] = true
+		}
 		tm.errorMsg = ""
 		m.transactionsModel = tm
 		return m, nil
 
+	// Scheduled
+	case scheduledReloadRequestedMsg:
+		return m, loadScheduledCmd(m.scheduledAPI, scheduledUpcomingDays)
+
+	case scheduledLoadedMsg:
+		var cmd tea.Cmd
+		m.scheduledModel, cmd = m.scheduledModel.Update(msg)
+		return m, cmd
+
+	case scheduledEnterSubmittedMsg:
+		return m, enterScheduledCmd(m.scheduledAPI, msg.scheduledID)
+
+	case scheduledSkipSubmittedMsg:
+		return m, skipScheduledCmd(m.scheduledAPI, msg.scheduledID)
+
+	case scheduledOccurrenceHandledMsg:
+		var cmd tea.Cmd
+		m.scheduledModel, cmd = m.scheduledModel.Update(msg)
+		return m, cmd
+
+	// Net worth
+	case netWorthReloadRequestedMsg:
+		return m, loadNetWorthCmd(m.netWorthAPI, netWorthIntervals[m.netWorthModel.interval])
+
+	case netWorthLoadedMsg:
+		var cmd tea.Cmd
+		m.netWorthModel, cmd = m.netWorthModel.Update(msg)
+		return m, cmd
+
+	// Duplicates
+	case duplicatesReloadRequestedMsg:
+		return m, loadDuplicatesCmd(m.duplicatesAPI)
+
+	case duplicatesLoadedMsg:
+		var cmd tea.Cmd
+		m.duplicatesModel, cmd = m.duplicatesModel.Update(msg)
+		return m, cmd
+
+	case duplicateMatchSubmittedMsg:
+		return m, matchDuplicateCmd(m.duplicatesAPI, msg.duplicateID)
+
+	case duplicateKeepSubmittedMsg:
+		return m, keepDuplicateCmd(m.duplicatesAPI, msg.duplicateID)
+
+	case duplicateResolvedMsg:
+		var cmd tea.Cmd
+		m.duplicatesModel, cmd = m.duplicatesModel.Update(msg)
+		return m, cmd
+
+	// Rules
+	case rulesReloadRequestedMsg:
+		return m, loadRulesCmd(m.rulesAPI)
+
+	case rulesLoadedMsg:
+		var cmd tea.Cmd
+		m.rulesModel, cmd = m.rulesModel.Update(msg)
+		return m, cmd
+
+	case rulesNewRequestedMsg, rulesEditRequestedMsg:
+		accountOptions := []ruleOption{{ID: uuid.Nil, Name: "Any account"}}
+		for _, account := range m.accountsModel.accounts {
+			accountOptions = append(accountOptions, ruleOption{
+				ID:   account.ID,
+				Name: account.AccountName,
+			})
+		}
+
+		categoryOptions := []ruleOption{{ID: uuid.Nil, Name: "Don't change"}}
+		for _, category := range m.categoriesModel.categories {
+			categoryOptions = append(categoryOptions, ruleOption{
+				ID:   category.ID,
+				Name: category.CategoryName,
+			})
+		}
+
+		rm := m.rulesModel
+		rm.accountOptions = accountOptions
+		rm.categoryOptions = categoryOptions
+		if _, ok := msg.(rulesEditRequestedMsg); ok {
+			rm.mode = rulesModeFormEdit
+			rm.resetForm(rm.rules[rm.cursor])
+		} else {
+			rm.mode = rulesModeFormNew
+			rm.resetForm(Rule{})
+		}
+		m.rulesModel = rm
+		return m, nil
+
+	case ruleSubmittedMsg:
+		req := RuleRequest{
+			RuleName:            msg.RuleName,
+			DescriptionContains: msg.DescriptionContains,
+			DescriptionRegex:    msg.DescriptionRegex,
+			AccountID:           msg.AccountID,
+			SetCategoryID:       msg.SetCategoryID,
+			SetDescription:      msg.SetDescription,
+			SetPosted:           msg.SetPosted,
+		}
+
+		if msg.MinAmountText != "" {
+			minDecimal, err := decimal.NewFromString(msg.MinAmountText)
+			if err != nil {
+				var cmd tea.Cmd
+				m.rulesModel, cmd = m.rulesModel.Update(ruleSavedMsg{
+					err: fmt.Errorf("invalid min amount: %w", err),
+				})
+				return m, cmd
+			}
+			req.MinAmount = &minDecimal
+		}
+		if msg.MaxAmountText != "" {
+			maxDecimal, err := decimal.NewFromString(msg.MaxAmountText)
+			if err != nil {
+				var cmd tea.Cmd
+				m.rulesModel, cmd = m.rulesModel.Update(ruleSavedMsg{
+					err: fmt.Errorf("invalid max amount: %w", err),
+				})
+				return m, cmd
+			}
+			req.MaxAmount = &maxDecimal
+		}
+
+		if msg.RuleID == uuid.Nil {
+			return m, createRuleCmd(m.rulesAPI, req)
+		}
+		return m, updateRuleCmd(m.rulesAPI, msg.RuleID, req)
+
+	case ruleSavedMsg:
+		var cmd tea.Cmd
+		m.rulesModel, cmd = m.rulesModel.Update(msg)
+		return m, cmd
+
+	case ruleDeleteSubmittedMsg:
+		return m, deleteRuleCmd(m.rulesAPI, msg.ruleID)
+
+	case ruleDeletedMsg:
+		var cmd tea.Cmd
+		m.rulesModel, cmd = m.rulesModel.Update(msg)
+		return m, cmd
+
+	case rulesReorderSubmittedMsg:
+		return m, reorderRulesCmd(m.rulesAPI, msg.ruleIDs)
+
+	case rulesApplySubmittedMsg:
+		startDate, err := time.Parse(time.DateOnly, msg.StartDate)
+		if err != nil {
+			var cmd tea.Cmd
+			m.rulesModel, cmd = m.rulesModel.Update(rulesAppliedMsg{
+				err: fmt.Errorf("invalid start date: %w", err),
+			})
+			return m, cmd
+		}
+		endDate, err := time.Parse(time.DateOnly, msg.EndDate)
+		if err != nil {
+			var cmd tea.Cmd
+			m.rulesModel, cmd = m.rulesModel.Update(rulesAppliedMsg{
+				err: fmt.Errorf("invalid end date: %w", err),
+			})
+			return m, cmd
+		}
+		return m, applyRulesCmd(m.rulesAPI, startDate, endDate)
+
+	case rulesAppliedMsg:
+		var cmd tea.Cmd
+		m.rulesModel, cmd = m.rulesModel.Update(msg)
+		return m, cmd
+
+	// Export
+	case exportSubmittedMsg:
+		return m, exportTransactionsCmd(m.exportAPI, msg.Request, msg.Path)
+
+	case exportFinishedMsg:
+		var cmd tea.Cmd
+		m.exportModel, cmd = m.exportModel.Update(msg)
+		return m, cmd
+
 	case tea.KeyMsg:
 		key := msg.String()
 
@@ -609,14 +1078,26 @@ func (m model) updateMain(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if key == "q" {
 			isEditing := false
 			switch m.currentSection {
+			case sectionBudget:
+				isEditing = m.budgetModel.IsEditing()
 			case sectionAccounts:
 				isEditing = m.accountsModel.IsEditing()
+			case sectionNetWorth:
+				isEditing = m.netWorthModel.IsEditing()
 			case sectionTransactions:
 				isEditing = m.transactionsModel.IsEditing()
 			case sectionCategories:
 				isEditing = m.categoriesModel.IsEditing()
 			case sectionGroups:
 				isEditing = m.groupsModel.IsEditing()
+			case sectionScheduled:
+				isEditing = m.scheduledModel.IsEditing()
+			case sectionDuplicates:
+				isEditing = m.duplicatesModel.IsEditing()
+			case sectionRules:
+				isEditing = m.rulesModel.IsEditing()
+			case sectionExport:
+				isEditing = m.exportModel.IsEditing()
 			}
 			if !isEditing {
 				return m, tea.Quit
@@ -647,7 +1128,22 @@ func (m model) updateMain(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.currentSection = section(m.navCursor)
 				m.focus = focusMain
 				if m.currentSection == sectionBudget {
-					return m, loadBudgetCmd(m.budgetAPI)
+					return m, loadBudgetCmd(m.budgetAPI, m.budgetModel.month)
+				}
+				if m.currentSection == sectionNetWorth {
+					return m, loadNetWorthCmd(m.netWorthAPI, netWorthIntervals[m.netWorthModel.interval])
+				}
+				if m.currentSection == sectionScheduled {
+					return m, loadScheduledCmd(m.scheduledAPI, scheduledUpcomingDays)
+				}
+				if m.currentSection == sectionDuplicates {
+					return m, loadDuplicatesCmd(m.duplicatesAPI)
+				}
+				if m.currentSection == sectionRules {
+					return m, loadRulesCmd(m.rulesAPI)
+				}
+				if m.currentSection == sectionExport {
+					m.exportModel.setOptions(m.accountsModel.accounts, m.categoriesModel.categories)
 				}
 			}
 		case focusMain:
@@ -660,6 +1156,10 @@ func (m model) updateMain(msg tea.Msg) (tea.Model, tea.Cmd) {
 				var cmd tea.Cmd
 				m.budgetModel, cmd = m.budgetModel.Update(msg)
 				return m, cmd
+			case sectionNetWorth:
+				var cmd tea.Cmd
+				m.netWorthModel, cmd = m.netWorthModel.Update(msg)
+				return m, cmd
 			case sectionCategories:
 				var cmd tea.Cmd
 				m.categoriesModel, cmd = m.categoriesModel.Update(msg)
@@ -672,6 +1172,22 @@ func (m model) updateMain(msg tea.Msg) (tea.Model, tea.Cmd) {
 				var cmd tea.Cmd
 				m.transactionsModel, cmd = m.transactionsModel.Update(msg)
 				return m, cmd
+			case sectionScheduled:
+				var cmd tea.Cmd
+				m.scheduledModel, cmd = m.scheduledModel.Update(msg)
+				return m, cmd
+			case sectionDuplicates:
+				var cmd tea.Cmd
+				m.duplicatesModel, cmd = m.duplicatesModel.Update(msg)
+				return m, cmd
+			case sectionRules:
+				var cmd tea.Cmd
+				m.rulesModel, cmd = m.rulesModel.Update(msg)
+				return m, cmd
+			case sectionExport:
+				var cmd tea.Cmd
+				m.exportModel, cmd = m.exportModel.Update(msg)
+				return m, cmd
 			default:
 				return m, nil
 			}
@@ -739,8 +1255,18 @@ func (m model) mainContentView() string {
 		return m.groupsModel.View()
 	case sectionAccounts:
 		return m.accountsModel.View()
+	case sectionNetWorth:
+		return m.netWorthModel.View()
 	case sectionTransactions:
 		return m.transactionsModel.View()
+	case sectionScheduled:
+		return m.scheduledModel.View()
+	case sectionDuplicates:
+		return m.duplicatesModel.View()
+	case sectionRules:
+		return m.rulesModel.View()
+	case sectionExport:
+		return m.exportModel.View()
 	default:
 		return ""
 	}
@@ -13,35 +13,60 @@ import (
 )
 
 type BudgetCategoryResponse struct {
-	CategoryID   uuid.UUID       `json:"category_id"`
-	CategoryName string          `json:"category_name"`
-	Budget       decimal.Decimal `json:"budget"`
-	TotalSpent   decimal.Decimal `json:"total_spent"`
-	Remaining    decimal.Decimal `json:"remaining"`
-	IsOverspent  bool            `json:"is_overspent"`
+	CategoryID           uuid.UUID       `json:"category_id"`
+	CategoryName         string          `json:"category_name"`
+	Budget               decimal.Decimal `json:"budget"`
+	TotalSpent           decimal.Decimal `json:"total_spent"`
+	Remaining            decimal.Decimal `json:"remaining"`
+	IsOverspent          bool            `json:"is_overspent"`
+	CarriedOver          decimal.Decimal `json:"carried_over"`
+	Assigned             decimal.Decimal `json:"assigned"`
+	Activity             decimal.Decimal `json:"activity"`
+	Available            decimal.Decimal `json:"available"`
+	RolloverOverspending bool            `json:"rollover_overspending"`
 }
 
 type BudgetGroupResponse struct {
-	GroupID        uuid.UUID                `json:"group_id"`
-	GroupName      string                   `json:"group_name"`
-	Categories     []BudgetCategoryResponse `json:"categories"`
-	TotalBudget    decimal.Decimal          `json:"total_budget"`
-	TotalSpent     decimal.Decimal          `json:"total_spent"`
-	TotalRemaining decimal.Decimal          `json:"total_remaining"`
+	GroupID          uuid.UUID                `json:"group_id"`
+	GroupName        string                   `json:"group_name"`
+	Categories       []BudgetCategoryResponse `json:"categories"`
+	TotalBudget      decimal.Decimal          `json:"total_budget"`
+	TotalSpent       decimal.Decimal          `json:"total_spent"`
+	TotalRemaining   decimal.Decimal          `json:"total_remaining"`
+	TotalCarriedOver decimal.Decimal          `json:"total_carried_over"`
+	TotalAssigned    decimal.Decimal          `json:"total_assigned"`
+	TotalActivity    decimal.Decimal          `json:"total_activity"`
+	TotalAvailable   decimal.Decimal          `json:"total_available"`
 }
 
 type BudgetOverviewResponse struct {
-	StartDate           time.Time                `json:"start_date"`
-	EndDate             time.Time                `json:"end_date"`
-	Groups              []BudgetGroupResponse    `json:"groups"`
-	UngroupedCategories []BudgetCategoryResponse `json:"ungrouped_categories"`
-	GrandTotalBudget    decimal.Decimal          `json:"grand_total_budget"`
-	GrandTotalSpent     decimal.Decimal          `json:"grand_total_spent"`
-	GrandTotalRemaining decimal.Decimal          `json:"grand_total_remaining"`
+	StartDate             time.Time                `json:"start_date"`
+	EndDate               time.Time                `json:"end_date"`
+	BaseCurrency          string                   `json:"base_currency"`
+	UnconvertedCurrencies []string                 `json:"unconverted_currencies"`
+	TotalIncome           decimal.Decimal          `json:"total_income"`
+	ReadyToAssign         decimal.Decimal          `json:"ready_to_assign"`
+	Groups                []BudgetGroupResponse    `json:"groups"`
+	UngroupedCategories   []BudgetCategoryResponse `json:"ungrouped_categories"`
+	GrandTotalBudget      decimal.Decimal          `json:"grand_total_budget"`
+	GrandTotalSpent       decimal.Decimal          `json:"grand_total_spent"`
+	GrandTotalRemaining   decimal.Decimal          `json:"grand_total_remaining"`
+	GrandTotalCarriedOver decimal.Decimal          `json:"grand_total_carried_over"`
+	GrandTotalAssigned    decimal.Decimal          `json:"grand_total_assigned"`
+	GrandTotalActivity    decimal.Decimal          `json:"grand_total_activity"`
+	GrandTotalAvailable   decimal.Decimal          `json:"grand_total_available"`
+}
+
+type CategoryBudget struct {
+	CategoryID uuid.UUID       `json:"category_id"`
+	Month      time.Time       `json:"month"`
+	Assigned   decimal.Decimal `json:"assigned"`
+	IsDefault  bool            `json:"is_default"`
 }
 
 type BudgetAPI interface {
-	GetBudgetOverview(ctx context.Context) (*BudgetOverviewResponse, error)
+	GetBudgetOverview(ctx context.Context, month time.Time) (*BudgetOverviewResponse, error)
+	SetCategoryBudget(ctx context.Context, categoryID uuid.UUID, month time.Time, req SetCategoryBudgetRequest) (CategoryBudget, error)
 }
 
 type budgetClient struct {
@@ -52,8 +77,8 @@ func (c *Client) Budget() BudgetAPI {
 	return &budgetClient{client: c}
 }
 
-func (b *budgetClient) GetBudgetOverview(ctx context.Context) (*BudgetOverviewResponse, error) {
-	req, err := b.client.newRequest(ctx, http.MethodGet, "/budget", nil)
+func (b *budgetClient) GetBudgetOverview(ctx context.Context, month time.Time) (*BudgetOverviewResponse, error) {
+	req, err := b.client.newRequest(ctx, http.MethodGet, "/budget?month="+month.Format("2006-01"), nil)
 	if err != nil {
 		return &BudgetOverviewResponse{}, err
 	}
@@ -83,13 +108,74 @@ type budgetLoadedMsg struct {
 	err      error
 }
 
-func loadBudgetCmd(api BudgetAPI) tea.Cmd {
+func loadBudgetCmd(api BudgetAPI, month time.Time) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
-		overview, err := api.GetBudgetOverview(ctx)
+		overview, err := api.GetBudgetOverview(ctx, month)
 		return budgetLoadedMsg{
 			overview: overview,
 			err:      err,
 		}
 	}
 }
+
+type SetCategoryBudgetRequest struct {
+	Assigned decimal.Decimal `json:"assigned"`
+}
+
+func (b *budgetClient) SetCategoryBudget(ctx context.Context, categoryID uuid.UUID, month time.Time, req SetCategoryBudgetRequest) (CategoryBudget, error) {
+	path := "/categories/" + categoryID.String() + "/budgets/" + month.Format("2006-01")
+	httpReq, err := b.client.newJSONRequest(ctx, http.MethodPut, path, req)
+	if err != nil {
+		return CategoryBudget{}, err
+	}
+
+	res, err := b.client.httpClient.Do(httpReq)
+	if err != nil {
+		return CategoryBudget{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return CategoryBudget{}, fmt.Errorf("Failed setting category budget: %s", res.Status)
+	}
+
+	var budget CategoryBudget
+	if err := json.NewDecoder(res.Body).Decode(&budget); err != nil {
+		return CategoryBudget{}, err
+	}
+
+	return budget, nil
+}
+
+type categoryBudgetSetMsg struct {
+	budget CategoryBudget
+	err    error
+}
+
+func setCategoryBudgetCmd(api BudgetAPI, categoryID uuid.UUID, month time.Time, req SetCategoryBudgetRequest) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		budget, err := api.SetCategoryBudget(ctx, categoryID, month, req)
+		return categoryBudgetSetMsg{
+			budget: budget,
+			err:    err,
+		}
+	}
+}
+
+type categoryBudgetSubmittedMsg struct {
+	CategoryID   uuid.UUID
+	Month        time.Time
+	AssignedText string
+}
+
+func submitCategoryBudgetMsg(categoryID uuid.UUID, month time.Time, assigned string) tea.Cmd {
+	return func() tea.Msg {
+		return categoryBudgetSubmittedMsg{
+			CategoryID:   categoryID,
+			Month:        month,
+			AssignedText: assigned,
+		}
+	}
+}
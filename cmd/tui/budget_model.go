@@ -2,28 +2,61 @@ package main
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shopspring/decimal"
 )
 
 type budgetMode int
 
 const (
 	budgetModeOverview budgetMode = iota
+	budgetModeAssign
 )
 
 type budgetModel struct {
-	mode     budgetMode
-	overview *BudgetOverviewResponse
-	errorMsg string
+	mode        budgetMode
+	month       time.Time
+	overview    *BudgetOverviewResponse
+	cursor      int
+	assignInput textinput.Model
+	errorMsg    string
 }
 
 func initialBudgetModel() budgetModel {
+	assign := textinput.New()
+	assign.CharLimit = 16
+	assign.Blur()
+
 	return budgetModel{
-		mode:     budgetModeOverview,
-		overview: nil,
-		errorMsg: "",
+		mode:        budgetModeOverview,
+		month:       currentMonth(),
+		overview:    nil,
+		cursor:      0,
+		assignInput: assign,
+		errorMsg:    "",
+	}
+}
+
+func currentMonth() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (m budgetModel) categories() []BudgetCategoryResponse {
+	if m.overview == nil {
+		return nil
+	}
+
+	var categories []BudgetCategoryResponse
+	for _, group := range m.overview.Groups {
+		categories = append(categories, group.Categories...)
 	}
+	categories = append(categories, m.overview.UngroupedCategories...)
+	return categories
 }
 
 func (m budgetModel) Update(msg tea.Msg) (budgetModel, tea.Cmd) {
@@ -34,34 +67,124 @@ func (m budgetModel) Update(msg tea.Msg) (budgetModel, tea.Cmd) {
 			m.overview = nil
 			return m, nil
 		}
+		if !msg.overview.StartDate.Equal(m.month) {
+			return m, nil
+		}
 
 		m.errorMsg = ""
 		m.overview = msg.overview
+		if m.cursor >= len(m.categories()) {
+			m.cursor = 0
+		}
 		return m, nil
+
+	case categoryBudgetSetMsg:
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+
+		m.errorMsg = ""
+		m.mode = budgetModeOverview
+		m.assignInput.Blur()
+		return m, func() tea.Msg {
+			return budgetReloadRequestedMsg{}
+		}
+
+	case tea.KeyMsg:
+		key := msg.String()
+
+		switch m.mode {
+		case budgetModeOverview:
+			switch key {
+			case "up", "k":
+				if m.cursor > 0 {
+					m.cursor--
+				}
+			case "down", "j":
+				if m.cursor < len(m.categories())-1 {
+					m.cursor++
+				}
+			case "left", "h":
+				return m.changeMonth(m.month.AddDate(0, -1, 0))
+			case "right", "l":
+				return m.changeMonth(m.month.AddDate(0, 1, 0))
+			case "t":
+				return m.changeMonth(currentMonth())
+			case "a":
+				categories := m.categories()
+				if len(categories) > 0 {
+					m.mode = budgetModeAssign
+					m.errorMsg = ""
+					m.assignInput.SetValue(categories[m.cursor].Budget.StringFixed(2))
+					m.assignInput.Focus()
+				}
+			}
+		case budgetModeAssign:
+			switch key {
+			case "esc":
+				m.mode = budgetModeOverview
+				m.errorMsg = ""
+				m.assignInput.Blur()
+				return m, nil
+			case "enter":
+				category := m.categories()[m.cursor]
+				return m, submitCategoryBudgetMsg(category.CategoryID, m.month, m.assignInput.Value())
+			default:
+				var cmd tea.Cmd
+				m.assignInput, cmd = m.assignInput.Update(msg)
+				return m, cmd
+			}
+		}
 	}
 
 	return m, nil
 }
 
+func (m budgetModel) changeMonth(month time.Time) (budgetModel, tea.Cmd) {
+	if month.Equal(m.month) {
+		return m, nil
+	}
+	m.month = month
+	m.overview = nil
+	return m, func() tea.Msg {
+		return budgetReloadRequestedMsg{}
+	}
+}
+
 func (m budgetModel) View() string {
 	if m.overview == nil {
-		return "Loading budget data...\n"
+		s := fmt.Sprintf("Loading budget data for %s...\n\n", m.month.Format("Jan 2006"))
+		s += m.errorView()
+		return s
 	}
+
+	if m.mode == budgetModeAssign {
+		category := m.categories()[m.cursor]
+		s := fmt.Sprintf("Assign Budget - %s\n\n", m.month.Format("Jan 2006"))
+		s += m.errorView()
+		s += fmt.Sprintf("Category: %s\n", category.CategoryName)
+		s += fmt.Sprintf("Assigned: %s\n", m.assignInput.View())
+		s += "\n(Press 'enter' to save, 'esc' to cancel)\n"
+		return s
+	}
+
 	s := fmt.Sprintf("Budget Overview - %s\n\n", m.overview.StartDate.Format("Jan 2006"))
 
 	s += m.errorView()
+	s += m.readyToAssignView()
+	if len(m.overview.UnconvertedCurrencies) > 0 {
+		s += errorStyle.Render(fmt.Sprintf("No exchange rate for %s yet, so those transactions are left out", strings.Join(m.overview.UnconvertedCurrencies, ", "))) + "\n\n"
+	}
 
+	index := 0
 	for _, group := range m.overview.Groups {
 		s += fmt.Sprintf("=== %s ===\n", group.GroupName)
-		s += fmt.Sprintf("Group Total - Budget: $%s | Spent: $%s | Remaining: $%s\n\n", group.TotalBudget.StringFixed(2), group.TotalSpent.StringFixed(2), group.TotalRemaining.StringFixed(2))
+		s += fmt.Sprintf("Group Total - Carried: %s | Assigned: %s | Activity: %s | Available: %s\n\n", m.money(group.TotalCarriedOver), m.money(group.TotalAssigned), m.money(group.TotalActivity), m.money(group.TotalAvailable))
 
 		for _, cat := range group.Categories {
-			overspentTag := ""
-			if cat.IsOverspent {
-				overspentTag = " [OVERSPENT]"
-			}
-			s += fmt.Sprintf("  %s%s\n", cat.CategoryName, overspentTag)
-			s += fmt.Sprintf("    Budget: $%s | Spent: $%s | Remaining: $%s\n\n", cat.Budget.StringFixed(2), cat.TotalSpent.StringFixed(2), cat.Remaining.StringFixed(2))
+			s += m.categoryView(cat, index)
+			index++
 		}
 		s += "\n"
 	}
@@ -70,25 +193,52 @@ func (m budgetModel) View() string {
 		s += "=== Ungrouped Categories ===\n\n"
 
 		for _, cat := range m.overview.UngroupedCategories {
-			overspentTag := ""
-			if cat.IsOverspent {
-				overspentTag = " [OVERSPENT]"
-			}
-			s += fmt.Sprintf("  %s%s\n", cat.CategoryName, overspentTag)
-			s += fmt.Sprintf("    Budget: $%s | Spent: $%s | Remaining: $%s\n\n", cat.Budget.StringFixed(2), cat.TotalSpent.StringFixed(2), cat.Remaining.StringFixed(2))
+			s += m.categoryView(cat, index)
+			index++
 		}
 		s += "\n"
 	}
 
 	s += "===============================\n"
-	s += fmt.Sprintf("TOTAL - Budget: $%s | Spent: $%s | Remaining: $%s\n", m.overview.GrandTotalBudget.StringFixed(2), m.overview.GrandTotalSpent.StringFixed(2), m.overview.GrandTotalRemaining.StringFixed(2))
+	s += fmt.Sprintf("TOTAL - Carried: %s | Assigned: %s | Activity: %s | Available: %s\n", m.money(m.overview.GrandTotalCarriedOver), m.money(m.overview.GrandTotalAssigned), m.money(m.overview.GrandTotalActivity), m.money(m.overview.GrandTotalAvailable))
+	s += "\n(Use 'j'/'k' to move, 'a' to assign this month's budget, 'h'/'l' to change month, 't' to jump to the current month)\n"
 
 	return s
 }
 
+func (m budgetModel) readyToAssignView() string {
+	s := fmt.Sprintf("Ready to Assign: %s", m.money(m.overview.ReadyToAssign))
+	if m.overview.ReadyToAssign.IsNegative() {
+		s = errorStyle.Render(s + " - more is assigned than has come in!")
+	}
+	return s + "\n\n"
+}
+
+func (m budgetModel) categoryView(cat BudgetCategoryResponse, index int) string {
+	cursor := " "
+	if m.cursor == index {
+		cursor = ">"
+	}
+	overspentTag := ""
+	if cat.IsOverspent {
+		overspentTag = " [OVERSPENT]"
+	}
+	s := fmt.Sprintf("%s %s%s\n", cursor, cat.CategoryName, overspentTag)
+	s += fmt.Sprintf("    Carried: %s | Assigned: %s | Activity: %s | Available: %s\n\n", m.money(cat.CarriedOver), m.money(cat.Assigned), m.money(cat.Activity), m.money(cat.Available))
+	return s
+}
+
+func (m budgetModel) money(amount decimal.Decimal) string {
+	return formatMoney(amount, m.overview.BaseCurrency)
+}
+
 func (m budgetModel) errorView() string {
 	if m.errorMsg == "" {
 		return ""
 	}
 	return fmt.Sprintf("Error: %s\n\n", m.errorMsg)
 }
+
+func (m budgetModel) IsEditing() bool {
+	return m.mode == budgetModeAssign
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/google/uuid"
@@ -16,7 +17,7 @@ type CategoriesAPI interface {
 	CreateCategory(ctx context.Context, req CreateCategoryRequest) (Category, error)
 	UpdateCategory(ctx context.Context, id uuid.UUID, req UpdateCategoryRequest) (Category, error)
 	DeleteCategory(ctx context.Context, id uuid.UUID) error
-	ListCategoryTransactions(ctx context.Context, id uuid.UUID) ([]Transaction, error)
+	ListCategoryTransactions(ctx context.Context, id uuid.UUID, cursor string) (TransactionPage, error)
 }
 
 type categoriesClient struct {
@@ -63,50 +64,65 @@ func loadCategoriesCmd(api CategoriesAPI) tea.Cmd {
 	}
 }
 
-func (c *categoriesClient) ListCategoryTransactions(ctx context.Context, id uuid.UUID) ([]Transaction, error) {
-	req, err := c.client.newRequest(ctx, http.MethodGet, "/categories/"+id.String()+"/transactions", nil)
+func (c *categoriesClient) ListCategoryTransactions(ctx context.Context, id uuid.UUID, cursor string) (TransactionPage, error) {
+	path := "/categories/" + id.String() + "/transactions"
+	if cursor != "" {
+		path += "?" + url.Values{"cursor": {cursor}}.Encode()
+	}
+
+	req, err := c.client.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, err
+		return TransactionPage{}, err
 	}
 
 	res, err := c.client.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return TransactionPage{}, err
 	}
 	defer res.Body.Close()
 
-	var catTxs []Transaction
-	if err := json.NewDecoder(res.Body).Decode(&catTxs); err != nil {
-		return nil, err
+	if res.StatusCode != http.StatusOK {
+		return TransactionPage{}, fmt.Errorf("Failed to list category transactions: %s", res.Status)
+	}
+
+	var page TransactionPage
+	if err := json.NewDecoder(res.Body).Decode(&page); err != nil {
+		return TransactionPage{}, err
 	}
 
-	return catTxs, nil
+	return page, nil
 }
 
 type loadCategoryTxsMsg struct {
-	catTxs []Transaction
-	err    error
+	catTxs     []Transaction
+	nextCursor string
+	cursor     string
+	err        error
 }
 
-func loadCategoryTxsCmd(api CategoriesAPI, id uuid.UUID) tea.Cmd {
+func loadCategoryTxsCmd(api CategoriesAPI, id uuid.UUID, cursor string) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.TODO()
-		catTxs, err := api.ListCategoryTransactions(ctx, id)
+		page, err := api.ListCategoryTransactions(ctx, id, cursor)
 		return loadCategoryTxsMsg{
-			catTxs: catTxs,
-			err:    err,
+			catTxs:     page.Transactions,
+			nextCursor: page.NextCursor,
+			cursor:     cursor,
+			err:        err,
 		}
 	}
 }
 
 type loadCategoryTxsSubmittedMsg struct {
 	categoryID uuid.UUID
+	cursor     string
 }
 
-func submitLoadCategoryTxsMsg(id uuid.UUID) tea.Cmd {
+func submitLoadCategoryTxsMsg(id uuid.UUID, cursor string) tea.Cmd {
 	return func() tea.Msg {
 		return loadCategoryTxsSubmittedMsg{
 			categoryID: id,
+			cursor:     cursor,
 		}
 	}
 }
@@ -115,9 +131,11 @@ type categoriesNewRequestedMsg struct{}
 type categoriesEditRequestedMsg struct{}
 
 type CreateCategoryRequest struct {
-	Name    string          `json:"category_name"`
-	Budget  decimal.Decimal `json:"budget"`
-	GroupID uuid.UUID       `json:"group_id"`
+	Name                 string          `json:"category_name"`
+	Budget               decimal.Decimal `json:"budget"`
+	GroupID              uuid.UUID       `json:"group_id"`
+	RolloverOverspending bool            `json:"rollover_overspending"`
+	IsIncome             bool            `json:"is_income"`
 }
 
 func (c *categoriesClient) CreateCategory(ctx context.Context, req CreateCategoryRequest) (Category, error) {
@@ -161,25 +179,31 @@ func createCategoryCmd(api CategoriesAPI, req CreateCategoryRequest) tea.Cmd {
 }
 
 type categoryCreateSubmittedMsg struct {
-	Name       string
-	BudgetText string
-	GroupID    uuid.UUID
+	Name                 string
+	BudgetText           string
+	GroupID              uuid.UUID
+	RolloverOverspending bool
+	IsIncome             bool
 }
 
-func submitCreateCategoryMsg(name, budget string, groupID uuid.UUID) tea.Cmd {
+func submitCreateCategoryMsg(name, budget string, groupID uuid.UUID, rollover, income bool) tea.Cmd {
 	return func() tea.Msg {
 		return categoryCreateSubmittedMsg{
-			Name:       name,
-			BudgetText: budget,
-			GroupID:    groupID,
+			Name:                 name,
+			BudgetText:           budget,
+			GroupID:              groupID,
+			RolloverOverspending: rollover,
+			IsIncome:             income,
 		}
 	}
 }
 
 type UpdateCategoryRequest struct {
-	Name    string          `json:"category_name"`
-	Budget  decimal.Decimal `json:"budget"`
-	GroupID uuid.UUID       `json:"group_id"`
+	Name                 string          `json:"category_name"`
+	Budget               decimal.Decimal `json:"budget"`
+	GroupID              uuid.UUID       `json:"group_id"`
+	RolloverOverspending bool            `json:"rollover_overspending"`
+	IsIncome             bool            `json:"is_income"`
 }
 
 func (c *categoriesClient) UpdateCategory(ctx context.Context, id uuid.UUID, req UpdateCategoryRequest) (Category, error) {
@@ -223,19 +247,23 @@ func updateCategoryCmd(api CategoriesAPI, id uuid.UUID, req UpdateCategoryReques
 }
 
 type categoryUpdateSubmittedMsg struct {
-	CategoryID uuid.UUID
-	Name       string
-	BudgetText string
-	GroupID    uuid.UUID
+	CategoryID           uuid.UUID
+	Name                 string
+	BudgetText           string
+	GroupID              uuid.UUID
+	RolloverOverspending bool
+	IsIncome             bool
 }
 
-func submitUpdateCategoryMsg(id uuid.UUID, name, budget string, groupID uuid.UUID) tea.Cmd {
+func submitUpdateCategoryMsg(id uuid.UUID, name, budget string, groupID uuid.UUID, rollover, income bool) tea.Cmd {
 	return func() tea.Msg {
 		return categoryUpdateSubmittedMsg{
-			CategoryID: id,
-			Name:       name,
-			BudgetText: budget,
-			GroupID:    groupID,
+			CategoryID:           id,
+			Name:                 name,
+			BudgetText:           budget,
+			GroupID:              groupID,
+			RolloverOverspending: rollover,
+			IsIncome:             income,
 		}
 	}
 }
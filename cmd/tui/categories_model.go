@@ -24,6 +24,8 @@ const (
 	catFormFieldName = iota
 	catFormFieldBudget
 	catFormFieldGroup
+	catFormFieldRollover
+	catFormFieldIncome
 	catFormFieldSave
 )
 
@@ -33,16 +35,21 @@ const (
 )
 
 type Category struct {
-	ID           uuid.UUID       `json:"id"`
-	CategoryName string          `json:"category_name"`
-	CreatedAt    time.Time       `json:"created_at"`
-	UpdatedAt    time.Time       `json:"updated_at"`
-	Budget       decimal.Decimal `json:"budget"`
-	UserID       uuid.UUID       `json:"user_id"`
-	GroupID      uuid.UUID       `json:"group_id"`
-	GroupName    string          `json:"group_name"`
+	ID                   uuid.UUID       `json:"id"`
+	CategoryName         string          `json:"category_name"`
+	CreatedAt            time.Time       `json:"created_at"`
+	UpdatedAt            time.Time       `json:"updated_at"`
+	Budget               decimal.Decimal `json:"budget"`
+	UserID               uuid.UUID       `json:"user_id"`
+	GroupID              uuid.UUID       `json:"group_id"`
+	GroupName            string          `json:"group_name"`
+	RolloverOverspending bool            `json:"rollover_overspending"`
+	IsIncome             bool            `json:"is_income"`
 }
 
+// catTxPageSize is how many of a category's transactions are shown at once.
+const catTxPageSize = 15
+
 type catGroupOption struct {
 	ID   uuid.UUID
 	Name string
@@ -52,6 +59,10 @@ type categoriesModel struct {
 	mode            categoriesMode
 	categories      []Category
 	catTxs          []Transaction
+	catTxsNext      string
+	catTxsLoading   bool
+	catTxCursor     int
+	catTxOffset     int
 	cursor          int
 	formEditing     bool
 	formFieldCursor int
@@ -59,6 +70,8 @@ type categoriesModel struct {
 	budgetInput     textinput.Model
 	formGroupIndex  int
 	groupOptions    []catGroupOption
+	formRollover    bool
+	formIncome      bool
 	confirmCursor   int
 	errorMsg        string
 }
@@ -81,6 +94,7 @@ func initialCategoriesModel() categoriesModel {
 		budgetInput:     catBudget,
 		formGroupIndex:  0,
 		groupOptions:    []catGroupOption{},
+		formRollover:    true,
 		confirmCursor:   catConfirmCancel,
 	}
 }
@@ -99,13 +113,24 @@ func (m categoriesModel) Update(msg tea.Msg) (categoriesModel, tea.Cmd) {
 		}
 
 	case loadCategoryTxsMsg:
+		m.catTxsLoading = false
 		if msg.err != nil {
 			m.errorMsg = msg.err.Error()
 			return m, nil
 		}
 		m.errorMsg = ""
-		m.catTxs = msg.catTxs
-		m.mode = categoriesModeDetails
+		if msg.cursor == "" {
+			m.catTxs = msg.catTxs
+			m.catTxCursor = 0
+			m.catTxOffset = 0
+			m.mode = categoriesModeDetails
+		} else if msg.cursor == m.catTxsNext {
+			m.catTxs = append(m.catTxs, msg.catTxs...)
+		} else {
+			// A page from a listing that has since been reloaded.
+			return m, nil
+		}
+		m.catTxsNext = msg.nextCursor
 		return m, nil
 
 	case categoryUpdatedMsg:
@@ -171,7 +196,7 @@ func (m categoriesModel) Update(msg tea.Msg) (categoriesModel, tea.Cmd) {
 				}
 			case "enter":
 				if len(m.categories) > 0 {
-					return m, submitLoadCategoryTxsMsg(m.categories[m.cursor].ID)
+					return m, submitLoadCategoryTxsMsg(m.categories[m.cursor].ID, "")
 				}
 
 			}
@@ -179,6 +204,22 @@ func (m categoriesModel) Update(msg tea.Msg) (categoriesModel, tea.Cmd) {
 			switch key {
 			case "esc":
 				m.mode = categoriesModeList
+			case "up", "k":
+				if m.catTxCursor > 0 {
+					m.catTxCursor--
+				}
+				m.catTxOffset = min(m.catTxOffset, m.catTxCursor)
+			case "down", "j":
+				if m.catTxCursor < len(m.catTxs)-1 {
+					m.catTxCursor++
+				}
+				if m.catTxCursor >= m.catTxOffset+catTxPageSize {
+					m.catTxOffset = m.catTxCursor - catTxPageSize + 1
+				}
+				if m.catTxsNext != "" && !m.catTxsLoading && m.catTxCursor >= len(m.catTxs)-loadMoreThreshold {
+					m.catTxsLoading = true
+					return m, submitLoadCategoryTxsMsg(m.categories[m.cursor].ID, m.catTxsNext)
+				}
 			case "e":
 				return m, func() tea.Msg {
 					return categoriesEditRequestedMsg{}
@@ -240,12 +281,12 @@ func (m categoriesModel) Update(msg tea.Msg) (categoriesModel, tea.Cmd) {
 						name := m.nameInput.Value()
 						budget := m.budgetInput.Value()
 						group := m.groupOptions[m.formGroupIndex].ID
-						return m, submitCreateCategoryMsg(name, budget, group)
+						return m, submitCreateCategoryMsg(name, budget, group, m.formRollover, m.formIncome)
 					case categoriesModeFormEdit:
 						name := m.nameInput.Value()
 						budget := m.budgetInput.Value()
 						group := m.groupOptions[m.formGroupIndex].ID
-						return m, submitUpdateCategoryMsg(m.categories[m.cursor].ID, name, budget, group)
+						return m, submitUpdateCategoryMsg(m.categories[m.cursor].ID, name, budget, group, m.formRollover, m.formIncome)
 					}
 				}
 			default:
@@ -269,6 +310,14 @@ func (m categoriesModel) Update(msg tea.Msg) (categoriesModel, tea.Cmd) {
 							m.formGroupIndex++
 						}
 					}
+				case catFormFieldRollover:
+					if key == "left" || key == "h" || key == "right" || key == "l" {
+						m.formRollover = !m.formRollover
+					}
+				case catFormFieldIncome:
+					if key == "left" || key == "h" || key == "right" || key == "l" {
+						m.formIncome = !m.formIncome
+					}
 				}
 			}
 		case categoriesModeDelete:
@@ -307,6 +356,10 @@ func (m categoriesModel) View() string {
 				cursor = ">"
 			}
 
+			if category.IsIncome {
+				s += fmt.Sprintf("%s Name: %s | Income\n", cursor, category.CategoryName)
+				continue
+			}
 			s += fmt.Sprintf("%s Name: %s | Budget: $%s\n", cursor, category.CategoryName, category.Budget)
 		}
 		s += "\n(Use 'j'/'k' to move, 'enter' to view details, 'n' to create a new category, 'd' to delete category)\n"
@@ -320,16 +373,28 @@ func (m categoriesModel) View() string {
 		if catGroup == "" {
 			catGroup = "No Group"
 		}
-		s += fmt.Sprintf("Group: %s\n\n", catGroup)
+		s += fmt.Sprintf("Group: %s\n", catGroup)
+		s += fmt.Sprintf("Overspending: %s\n", rolloverLabel(cat.RolloverOverspending))
+		s += fmt.Sprintf("Income: %s\n\n", yesNoLabel(cat.IsIncome))
 
 		s += "Transactions\n\n"
-		for _, transaction := range m.catTxs {
-
+		end := min(m.catTxOffset+catTxPageSize, len(m.catTxs))
+		for i := m.catTxOffset; i < end; i++ {
+			transaction := m.catTxs[i]
+			cursor := " "
+			if m.catTxCursor == i {
+				cursor = ">"
+			}
 			dateStr := transaction.TxDate.Format("2006-01-02")
-			s += fmt.Sprintf("%s | %s | %s\n", dateStr, transaction.TxDescription, transaction.Amount)
+			s += fmt.Sprintf("%s %s | %s | %s\n", cursor, dateStr, transaction.TxDescription, transaction.Amount)
+		}
+		if m.catTxsLoading {
+			s += "  Loading more...\n"
+		} else if m.catTxsNext != "" || end < len(m.catTxs) {
+			s += "  More transactions below\n"
 		}
 
-		s += "\n(Press 'esc' to go back, 'e' to edit, 'd' to delete)\n"
+		s += "\n(Use 'j'/'k' to scroll, 'esc' to go back, 'e' to edit, 'd' to delete)\n"
 
 		return s
 	case categoriesModeFormNew, categoriesModeFormEdit:
@@ -350,6 +415,8 @@ func (m categoriesModel) View() string {
 		s += fmt.Sprintf("%s Name: %s\n", currentRow(catFormFieldName), m.nameInput.View())
 		s += fmt.Sprintf("%s Budget: %s\n", currentRow(catFormFieldBudget), m.budgetInput.View())
 		s += fmt.Sprintf("%s Group ('h'/'l' to change): %v\n", currentRow(catFormFieldGroup), m.groupOptions[m.formGroupIndex].Name)
+		s += fmt.Sprintf("%s Overspending ('h'/'l' to change): %s\n", currentRow(catFormFieldRollover), rolloverLabel(m.formRollover))
+		s += fmt.Sprintf("%s Income ('h'/'l' to change): %s\n", currentRow(catFormFieldIncome), yesNoLabel(m.formIncome))
 
 		s += fmt.Sprintf("%s [ Save ]\n", currentRow(catFormFieldSave))
 		s += "\n(Use 'j'/'k' to move, 'enter' to edit field, 'esc' to stop editing, 'esc' again to cancel)\n"
@@ -384,6 +451,20 @@ func (m categoriesModel) errorView() string {
 	return fmt.Sprintf("Error: %s\n\n", m.errorMsg)
 }
 
+func rolloverLabel(rollover bool) string {
+	if rollover {
+		return "Roll over"
+	}
+	return "Reset each month"
+}
+
+func yesNoLabel(value bool) string {
+	if value {
+		return "Yes"
+	}
+	return "No"
+}
+
 func (m categoriesModel) IsEditing() bool {
 	return m.formEditing
 }
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type ImportDuplicate struct {
+	ID                   uuid.UUID       `json:"id"`
+	Amount               decimal.Decimal `json:"amount"`
+	TxDescription        string          `json:"tx_description"`
+	TxDate               time.Time       `json:"tx_date"`
+	AccountID            uuid.UUID       `json:"account_id"`
+	AccountName          string          `json:"account_name"`
+	MatchedTransactionID uuid.UUID       `json:"matched_transaction_id"`
+	MatchedDescription   string          `json:"matched_description"`
+	MatchedDate          *time.Time      `json:"matched_date"`
+	MatchedPosted        bool            `json:"matched_posted"`
+}
+
+type DuplicatesAPI interface {
+	ListDuplicates(ctx context.Context) ([]ImportDuplicate, error)
+	MatchDuplicate(ctx context.Context, id uuid.UUID) error
+	KeepDuplicate(ctx context.Context, id uuid.UUID) error
+}
+
+type duplicatesClient struct {
+	client *Client
+}
+
+func (c *Client) Duplicates() DuplicatesAPI {
+	return &duplicatesClient{client: c}
+}
+
+func (d *duplicatesClient) ListDuplicates(ctx context.Context) ([]ImportDuplicate, error) {
+	req, err := d.client.newRequest(ctx, http.MethodGet, "/duplicates", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := d.client.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Failed getting duplicates: %s", res.Status)
+	}
+
+	var duplicates []ImportDuplicate
+	if err := json.NewDecoder(res.Body).Decode(&duplicates); err != nil {
+		return nil, err
+	}
+
+	return duplicates, nil
+}
+
+type duplicatesReloadRequestedMsg struct{}
+
+type duplicatesLoadedMsg struct {
+	duplicates []ImportDuplicate
+	err        error
+}
+
+func loadDuplicatesCmd(api DuplicatesAPI) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		duplicates, err := api.ListDuplicates(ctx)
+		return duplicatesLoadedMsg{
+			duplicates: duplicates,
+			err:        err,
+		}
+	}
+}
+
+func (d *duplicatesClient) MatchDuplicate(ctx context.Context, id uuid.UUID) error {
+	return d.resolve(ctx, id, "match")
+}
+
+func (d *duplicatesClient) KeepDuplicate(ctx context.Context, id uuid.UUID) error {
+	return d.resolve(ctx, id, "keep")
+}
+
+func (d *duplicatesClient) resolve(ctx context.Context, id uuid.UUID, action string) error {
+	req, err := d.client.newRequest(ctx, http.MethodPost, "/duplicates/"+id.String()+"/"+action, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := d.client.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK {
+		return fmt.Errorf("Failed resolving duplicate: %s", res.Status)
+	}
+
+	return nil
+}
+
+type duplicateResolvedMsg struct {
+	duplicateID uuid.UUID
+	err         error
+}
+
+func matchDuplicateCmd(api DuplicatesAPI, id uuid.UUID) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		err := api.MatchDuplicate(ctx, id)
+		return duplicateResolvedMsg{
+			duplicateID: id,
+			err:         err,
+		}
+	}
+}
+
+func keepDuplicateCmd(api DuplicatesAPI, id uuid.UUID) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		err := api.KeepDuplicate(ctx, id)
+		return duplicateResolvedMsg{
+			duplicateID: id,
+			err:         err,
+		}
+	}
+}
+
+type duplicateMatchSubmittedMsg struct {
+	duplicateID uuid.UUID
+}
+
+func submitMatchDuplicateMsg(id uuid.UUID) tea.Cmd {
+	return func() tea.Msg {
+		return duplicateMatchSubmittedMsg{
+			duplicateID: id,
+		}
+	}
+}
+
+type duplicateKeepSubmittedMsg struct {
+	duplicateID uuid.UUID
+}
+
+func submitKeepDuplicateMsg(id uuid.UUID) tea.Cmd {
+	return func() tea.Msg {
+		return duplicateKeepSubmittedMsg{
+			duplicateID: id,
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type duplicatesModel struct {
+	duplicates []ImportDuplicate
+	cursor     int
+	errorMsg   string
+}
+
+func initialDuplicatesModel() duplicatesModel {
+	return duplicatesModel{
+		duplicates: []ImportDuplicate{},
+		cursor:     0,
+	}
+}
+
+func (m duplicatesModel) Update(msg tea.Msg) (duplicatesModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case duplicatesLoadedMsg:
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+
+		m.duplicates = msg.duplicates
+		if m.cursor >= len(m.duplicates) {
+			m.cursor = 0
+		}
+		return m, nil
+
+	case duplicateResolvedMsg:
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+
+		m.errorMsg = ""
+		filtered := m.duplicates[:0]
+		for _, duplicate := range m.duplicates {
+			if duplicate.ID != msg.duplicateID {
+				filtered = append(filtered, duplicate)
+			}
+		}
+		m.duplicates = filtered
+		if m.cursor >= len(m.duplicates) && m.cursor > 0 {
+			m.cursor = len(m.duplicates) - 1
+		}
+		return m, func() tea.Msg {
+			return transactionsReloadRequestedMsg{}
+		}
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.duplicates)-1 {
+				m.cursor++
+			}
+		case "r":
+			return m, func() tea.Msg {
+				return duplicatesReloadRequestedMsg{}
+			}
+		case "m":
+			if len(m.duplicates) > 0 {
+				return m, submitMatchDuplicateMsg(m.duplicates[m.cursor].ID)
+			}
+		case "i":
+			if len(m.duplicates) > 0 {
+				return m, submitKeepDuplicateMsg(m.duplicates[m.cursor].ID)
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m duplicatesModel) View() string {
+	s := "Review Probable Duplicates\n\n"
+	s += m.errorView()
+
+	if len(m.duplicates) == 0 {
+		s += "No imported transactions need review.\n"
+	}
+
+	for i, duplicate := range m.duplicates {
+		cursor := " "
+		if m.cursor == i {
+			cursor = ">"
+		}
+
+		s += fmt.Sprintf("%s Imported: %s | %s | $%s | %s\n", cursor, duplicate.TxDate.Format("2006-01-02"), duplicate.TxDescription, duplicate.Amount.StringFixed(2), duplicate.AccountName)
+		if duplicate.MatchedDate == nil {
+			s += "    Existing: (deleted)\n\n"
+			continue
+		}
+		posted := "unposted"
+		if duplicate.MatchedPosted {
+			posted = "posted"
+		}
+		s += fmt.Sprintf("    Existing: %s | %s | %s\n\n", duplicate.MatchedDate.Format("2006-01-02"), duplicate.MatchedDescription, posted)
+	}
+
+	s += "\n(Use 'j'/'k' to move, 'm' if it's the same transaction, 'i' to import it as a new one, 'r' to reload)\n"
+	return s
+}
+
+func (m duplicatesModel) errorView() string {
+	if m.errorMsg == "" {
+		return ""
+	}
+	return fmt.Sprintf("Error: %s\n\n", m.errorMsg)
+}
+
+func (m duplicatesModel) IsEditing() bool {
+	return false
+}
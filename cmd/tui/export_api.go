@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+)
+
+type ExportRequest struct {
+	Format     string
+	StartDate  string
+	EndDate    string
+	AccountID  uuid.UUID
+	CategoryID uuid.UUID
+}
+
+type ExportAPI interface {
+	ExportTransactions(ctx context.Context, req ExportRequest, dst io.Writer) (int64, error)
+}
+
+type exportClient struct {
+	client *Client
+}
+
+func (c *Client) Export() ExportAPI {
+	return &exportClient{client: c}
+}
+
+func (e *exportClient) ExportTransactions(ctx context.Context, exportReq ExportRequest, dst io.Writer) (int64, error) {
+	query := url.Values{}
+	query.Set("format", exportReq.Format)
+	if exportReq.StartDate != "" {
+		query.Set("start", exportReq.StartDate)
+	}
+	if exportReq.EndDate != "" {
+		query.Set("end", exportReq.EndDate)
+	}
+	if exportReq.AccountID != uuid.Nil {
+		query.Set("account_id", exportReq.AccountID.String())
+	}
+	if exportReq.CategoryID != uuid.Nil {
+		query.Set("category_id", exportReq.CategoryID.String())
+	}
+
+	req, err := e.client.newRequest(ctx, http.MethodGet, "/export/transactions?"+query.Encode(), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	// Exports stream the whole history, so they don't share the client's
+	// short request timeout.
+	httpClient := &http.Client{Transport: e.client.httpClient.Transport}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("Failed exporting transactions: %s", res.Status)
+	}
+
+	return io.Copy(dst, res.Body)
+}
+
+type exportFinishedMsg struct {
+	path  string
+	bytes int64
+	err   error
+}
+
+// expandPath resolves a leading ~ to the user's home directory.
+func expandPath(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
+
+// exportTransactionsCmd writes the export to path, removing the file again
+// if the download fails part way.
+func exportTransactionsCmd(api ExportAPI, req ExportRequest, path string) tea.Cmd {
+	return func() tea.Msg {
+		path, err := expandPath(path)
+		if err != nil {
+			return exportFinishedMsg{err: err}
+		}
+
+		file, err := os.Create(path)
+		if err != nil {
+			return exportFinishedMsg{err: err}
+		}
+
+		ctx := context.Background()
+		written, err := api.ExportTransactions(ctx, req, file)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(path)
+			return exportFinishedMsg{err: err}
+		}
+
+		return exportFinishedMsg{
+			path:  path,
+			bytes: written,
+		}
+	}
+}
+
+type exportSubmittedMsg struct {
+	Request ExportRequest
+	Path    string
+}
+
+func submitExportMsg(req ExportRequest, path string) tea.Cmd {
+	return func() tea.Msg {
+		return exportSubmittedMsg{
+			Request: req,
+			Path:    path,
+		}
+	}
+}
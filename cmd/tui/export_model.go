@@ -0,0 +1,243 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+)
+
+const (
+	exportFieldFormat = iota
+	exportFieldStart
+	exportFieldEnd
+	exportFieldAccount
+	exportFieldCategory
+	exportFieldPath
+	exportFieldRun
+)
+
+var exportFormats = []string{"csv", "json"}
+
+type exportOption struct {
+	ID   uuid.UUID
+	Name string
+}
+
+type exportModel struct {
+	formEditing     bool
+	formFieldCursor int
+	formatIndex     int
+	startInput      textinput.Model
+	endInput        textinput.Model
+	pathInput       textinput.Model
+	accountIndex    int
+	categoryIndex   int
+	accountOptions  []exportOption
+	categoryOptions []exportOption
+
+	exporting bool
+	statusMsg string
+	errorMsg  string
+}
+
+func newExportInput(charLimit int) textinput.Model {
+	input := textinput.New()
+	input.CharLimit = charLimit
+	input.Blur()
+	return input
+}
+
+func initialExportModel() exportModel {
+	m := exportModel{
+		startInput:      newExportInput(10),
+		endInput:        newExportInput(10),
+		pathInput:       newExportInput(256),
+		accountOptions:  []exportOption{{ID: uuid.Nil, Name: "All accounts"}},
+		categoryOptions: []exportOption{{ID: uuid.Nil, Name: "All categories"}},
+	}
+	m.pathInput.SetValue(m.defaultPath())
+	return m
+}
+
+func (m exportModel) defaultPath() string {
+	return fmt.Sprintf("transactions-%s.%s", time.Now().Format(time.DateOnly), exportFormats[m.formatIndex])
+}
+
+// setOptions refreshes the account and category pickers, keeping the
+// current selections where they still exist.
+func (m *exportModel) setOptions(accounts []Account, categories []Category) {
+	selectedAccount := m.accountOptions[m.accountIndex].ID
+	m.accountOptions = []exportOption{{ID: uuid.Nil, Name: "All accounts"}}
+	m.accountIndex = 0
+	for _, account := range accounts {
+		if account.ID == selectedAccount {
+			m.accountIndex = len(m.accountOptions)
+		}
+		m.accountOptions = append(m.accountOptions, exportOption{ID: account.ID, Name: account.AccountName})
+	}
+
+	selectedCategory := m.categoryOptions[m.categoryIndex].ID
+	m.categoryOptions = []exportOption{{ID: uuid.Nil, Name: "All categories"}}
+	m.categoryIndex = 0
+	for _, category := range categories {
+		if category.ID == selectedCategory {
+			m.categoryIndex = len(m.categoryOptions)
+		}
+		m.categoryOptions = append(m.categoryOptions, exportOption{ID: category.ID, Name: category.CategoryName})
+	}
+}
+
+// setFormat switches formats, following along with the file extension if
+// the path still has the previous one.
+func (m *exportModel) setFormat(index int) {
+	oldExt := "." + exportFormats[m.formatIndex]
+	m.formatIndex = index
+	path := m.pathInput.Value()
+	if strings.HasSuffix(path, oldExt) {
+		m.pathInput.SetValue(strings.TrimSuffix(path, oldExt) + "." + exportFormats[m.formatIndex])
+	}
+}
+
+func (m *exportModel) input(field int) *textinput.Model {
+	switch field {
+	case exportFieldStart:
+		return &m.startInput
+	case exportFieldEnd:
+		return &m.endInput
+	case exportFieldPath:
+		return &m.pathInput
+	}
+	return nil
+}
+
+func (m exportModel) Update(msg tea.Msg) (exportModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case exportFinishedMsg:
+		m.exporting = false
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			m.statusMsg = ""
+			return m, nil
+		}
+		m.errorMsg = ""
+		m.statusMsg = fmt.Sprintf("Exported %d bytes to %s", msg.bytes, msg.path)
+		return m, nil
+
+	case tea.KeyMsg:
+		key := msg.String()
+
+		if m.formEditing {
+			if key == "esc" || key == "enter" {
+				m.formEditing = false
+				m.startInput.Blur()
+				m.endInput.Blur()
+				m.pathInput.Blur()
+				return m, nil
+			}
+
+			if input := m.input(m.formFieldCursor); input != nil {
+				var cmd tea.Cmd
+				*input, cmd = input.Update(msg)
+				return m, cmd
+			}
+		}
+
+		switch key {
+		case "up", "k":
+			if m.formFieldCursor > 0 {
+				m.formFieldCursor--
+			}
+		case "down", "j":
+			if m.formFieldCursor < exportFieldRun {
+				m.formFieldCursor++
+			}
+		case "left", "h":
+			switch {
+			case m.formFieldCursor == exportFieldFormat && m.formatIndex > 0:
+				m.setFormat(m.formatIndex - 1)
+			case m.formFieldCursor == exportFieldAccount && m.accountIndex > 0:
+				m.accountIndex--
+			case m.formFieldCursor == exportFieldCategory && m.categoryIndex > 0:
+				m.categoryIndex--
+			}
+		case "right", "l":
+			switch {
+			case m.formFieldCursor == exportFieldFormat && m.formatIndex < len(exportFormats)-1:
+				m.setFormat(m.formatIndex + 1)
+			case m.formFieldCursor == exportFieldAccount && m.accountIndex < len(m.accountOptions)-1:
+				m.accountIndex++
+			case m.formFieldCursor == exportFieldCategory && m.categoryIndex < len(m.categoryOptions)-1:
+				m.categoryIndex++
+			}
+		case "enter":
+			if input := m.input(m.formFieldCursor); input != nil {
+				m.formEditing = true
+				input.Focus()
+				return m, nil
+			}
+			if m.formFieldCursor != exportFieldRun || m.exporting {
+				return m, nil
+			}
+
+			path := strings.TrimSpace(m.pathInput.Value())
+			if path == "" {
+				m.errorMsg = "Enter a file path to export to"
+				return m, nil
+			}
+
+			m.exporting = true
+			m.errorMsg = ""
+			m.statusMsg = "Exporting..."
+			return m, submitExportMsg(ExportRequest{
+				Format:     exportFormats[m.formatIndex],
+				StartDate:  strings.TrimSpace(m.startInput.Value()),
+				EndDate:    strings.TrimSpace(m.endInput.Value()),
+				AccountID:  m.accountOptions[m.accountIndex].ID,
+				CategoryID: m.categoryOptions[m.categoryIndex].ID,
+			}, path)
+		}
+	}
+
+	return m, nil
+}
+
+func (m exportModel) View() string {
+	currentRow := func(field int) string {
+		if m.formFieldCursor == field {
+			return ">"
+		}
+		return " "
+	}
+
+	s := "Export Transactions\n\n"
+	s += m.errorView()
+	if m.statusMsg != "" {
+		s += m.statusMsg + "\n\n"
+	}
+
+	s += fmt.Sprintf("%s Format ('h'/'l' to change): %s\n", currentRow(exportFieldFormat), strings.ToUpper(exportFormats[m.formatIndex]))
+	s += fmt.Sprintf("%s Start date(YYYY-MM-DD, blank for all): %s\n", currentRow(exportFieldStart), m.startInput.View())
+	s += fmt.Sprintf("%s End date(YYYY-MM-DD, blank for all): %s\n", currentRow(exportFieldEnd), m.endInput.View())
+	s += fmt.Sprintf("%s Account ('h'/'l' to change): %s\n", currentRow(exportFieldAccount), m.accountOptions[m.accountIndex].Name)
+	s += fmt.Sprintf("%s Category ('h'/'l' to change): %s\n", currentRow(exportFieldCategory), m.categoryOptions[m.categoryIndex].Name)
+	s += fmt.Sprintf("%s Save to: %s\n\n", currentRow(exportFieldPath), m.pathInput.View())
+
+	s += fmt.Sprintf("%s [ Export ]\n", currentRow(exportFieldRun))
+	s += "\n(Use 'j'/'k' to move, 'enter' to edit field, 'esc' to stop editing)\n"
+	return s
+}
+
+func (m exportModel) errorView() string {
+	if m.errorMsg == "" {
+		return ""
+	}
+	return fmt.Sprintf("Error: %s\n\n", m.errorMsg)
+}
+
+func (m exportModel) IsEditing() bool {
+	return m.formEditing
+}
@@ -13,8 +13,9 @@ import (
 )
 
 type loginResultMsg struct {
-	token string
-	err   error
+	token        string
+	baseCurrency string
+	err          error
 }
 
 func loginCmd(c *Client, username, password string) tea.Cmd {
@@ -52,13 +53,14 @@ func loginCmd(c *Client, username, password string) tea.Cmd {
 		}
 
 		var respBody struct {
-			Token string `json:"token"`
+			Token        string `json:"token"`
+			BaseCurrency string `json:"base_currency"`
 		}
 		if err := json.NewDecoder(resp.Body).Decode(&respBody); err != nil {
 			return loginResultMsg{err: err}
 		}
 
-		return loginResultMsg{token: respBody.Token, err: nil}
+		return loginResultMsg{token: respBody.Token, baseCurrency: respBody.BaseCurrency, err: nil}
 	}
 }
 
@@ -103,16 +105,29 @@ func (m model) updateLogin(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		m.jwt = msg.token
 		m.client.SetJWT(m.jwt)
+		m.accountsModel.baseCurrency = msg.baseCurrency
 		m.accountsAPI = m.client.Accounts()
+		m.netWorthAPI = m.client.NetWorth()
 		m.transactionsAPI = m.client.Transactions()
 		m.categoriesAPI = m.client.Categories()
 		m.groupsAPI = m.client.Groups()
+		m.scheduledAPI = m.client.Scheduled()
+		m.duplicatesAPI = m.client.Duplicates()
+		m.payeesAPI = m.client.Payees()
+		m.tagsAPI = m.client.Tags()
+		m.rulesAPI = m.client.Rules()
+		m.exportAPI = m.client.Export()
 		var cmds []tea.Cmd
-		cmds = append(cmds, loadBudgetCmd(m.budgetAPI))
+		cmds = append(cmds, loadBudgetCmd(m.budgetAPI, m.budgetModel.month))
 		cmds = append(cmds, loadAccountsCmd(m.accountsAPI))
-		cmds = append(cmds, loadTransactionsCmd(m.transactionsAPI))
+		cmds = append(cmds, loadTransactionsCmd(m.transactionsAPI, TransactionFilter{}, ""))
 		cmds = append(cmds, loadCategoriesCmd(m.categoriesAPI))
 		cmds = append(cmds, loadGroupsCmd(m.groupsAPI))
+		cmds = append(cmds, loadScheduledCmd(m.scheduledAPI, scheduledUpcomingDays))
+		cmds = append(cmds, loadDuplicatesCmd(m.duplicatesAPI))
+		cmds = append(cmds, loadPayeesCmd(m.payeesAPI))
+		cmds = append(cmds, loadTagsCmd(m.tagsAPI))
+		cmds = append(cmds, loadRulesCmd(m.rulesAPI))
 
 		m.screen = screenMain
 		return m, tea.Batch(cmds...)
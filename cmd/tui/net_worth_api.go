@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type NetWorthAccount struct {
+	AccountID   uuid.UUID           `json:"account_id"`
+	AccountName string              `json:"account_name"`
+	AccountType string              `json:"account_type"`
+	Currency    string              `json:"currency"`
+	Balance     decimal.Decimal     `json:"balance"`
+	BaseBalance decimal.NullDecimal `json:"base_balance"`
+}
+
+type NetWorthPeriod struct {
+	StartDate             time.Time         `json:"start_date"`
+	EndDate               time.Time         `json:"end_date"`
+	Assets                []NetWorthAccount `json:"assets"`
+	Liabilities           []NetWorthAccount `json:"liabilities"`
+	TotalAssets           decimal.Decimal   `json:"total_assets"`
+	TotalLiabilities      decimal.Decimal   `json:"total_liabilities"`
+	NetWorth              decimal.Decimal   `json:"net_worth"`
+	UnconvertedCurrencies []string          `json:"unconverted_currencies"`
+}
+
+type NetWorthReport struct {
+	From         time.Time        `json:"from"`
+	To           time.Time        `json:"to"`
+	Interval     string           `json:"interval"`
+	BaseCurrency string           `json:"base_currency"`
+	Periods      []NetWorthPeriod `json:"periods"`
+}
+
+type NetWorthAPI interface {
+	GetNetWorth(ctx context.Context, interval string) (NetWorthReport, error)
+}
+
+type netWorthClient struct {
+	client *Client
+}
+
+func (c *Client) NetWorth() NetWorthAPI {
+	return &netWorthClient{client: c}
+}
+
+// GetNetWorth fetches the server's default range, the last twelve
+// periods, for the interval.
+func (n *netWorthClient) GetNetWorth(ctx context.Context, interval string) (NetWorthReport, error) {
+	path := "/reports/net-worth?" + url.Values{"interval": {interval}}.Encode()
+	req, err := n.client.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return NetWorthReport{}, err
+	}
+
+	res, err := n.client.httpClient.Do(req)
+	if err != nil {
+		return NetWorthReport{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return NetWorthReport{}, fmt.Errorf("Failed getting net worth: %s", res.Status)
+	}
+
+	var report NetWorthReport
+	if err := json.NewDecoder(res.Body).Decode(&report); err != nil {
+		return NetWorthReport{}, err
+	}
+
+	return report, nil
+}
+
+type netWorthReloadRequestedMsg struct{}
+
+type netWorthLoadedMsg struct {
+	report NetWorthReport
+	err    error
+}
+
+func loadNetWorthCmd(api NetWorthAPI, interval string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		report, err := api.GetNetWorth(ctx, interval)
+		return netWorthLoadedMsg{
+			report: report,
+			err:    err,
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shopspring/decimal"
+)
+
+// netWorthIntervals are the period lengths the report can use, cycled
+// with 'i'.
+var netWorthIntervals = []string{"month", "quarter", "year"}
+
+// netWorthChartHeight is how many rows the trend chart takes.
+const netWorthChartHeight = 8
+
+type netWorthModel struct {
+	report   NetWorthReport
+	interval int
+	cursor   int
+	errorMsg string
+}
+
+func initialNetWorthModel() netWorthModel {
+	return netWorthModel{
+		interval: 0,
+		cursor:   0,
+	}
+}
+
+func (m netWorthModel) Update(msg tea.Msg) (netWorthModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case netWorthLoadedMsg:
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+
+		m.errorMsg = ""
+		m.report = msg.report
+		// Start at the latest period, which is at the bottom.
+		m.cursor = max(len(m.report.Periods)-1, 0)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.report.Periods)-1 {
+				m.cursor++
+			}
+		case "i":
+			m.interval = (m.interval + 1) % len(netWorthIntervals)
+			return m, func() tea.Msg {
+				return netWorthReloadRequestedMsg{}
+			}
+		case "r":
+			return m, func() tea.Msg {
+				return netWorthReloadRequestedMsg{}
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m netWorthModel) View() string {
+	s := fmt.Sprintf("Net Worth - by %s\n\n", netWorthIntervals[m.interval])
+	s += m.errorView()
+
+	if len(m.report.Periods) == 0 {
+		s += "No net worth history yet.\n"
+		s += "\n(Use 'i' to change interval, 'r' to reload)\n"
+		return s
+	}
+
+	s += fmt.Sprintf("  %-10s  %14s  %14s  %14s\n", "Period", "Assets", "Liabilities", "Net Worth")
+	for i, period := range m.report.Periods {
+		cursor := " "
+		if m.cursor == i {
+			cursor = ">"
+		}
+		s += fmt.Sprintf("%s %-10s  %14s  %14s  %14s\n",
+			cursor,
+			m.periodLabel(period),
+			m.money(period.TotalAssets),
+			m.money(period.TotalLiabilities),
+			m.money(period.NetWorth))
+	}
+
+	s += "\n" + m.chartView() + "\n"
+	s += m.periodView(m.report.Periods[m.cursor])
+
+	s += "\n(Use 'j'/'k' to pick a period, 'i' to change interval, 'r' to reload)\n"
+	return s
+}
+
+// chartView draws net worth over time as columns of '#', scaled between
+// the lowest and highest values in the report.
+func (m netWorthModel) chartView() string {
+	values := make([]float64, len(m.report.Periods))
+	low, high := m.report.Periods[0].NetWorth, m.report.Periods[0].NetWorth
+	for i, period := range m.report.Periods {
+		values[i] = period.NetWorth.InexactFloat64()
+		low = decimal.Min(low, period.NetWorth)
+		high = decimal.Max(high, period.NetWorth)
+	}
+	lowFloat, span := low.InexactFloat64(), high.Sub(low).InexactFloat64()
+
+	// Each column is as wide as its label, so the axis lines up.
+	width := 0
+	for _, period := range m.report.Periods {
+		width = max(width, len(m.chartLabel(period)))
+	}
+	width++
+
+	axisWidth := max(utf8.RuneCountInString(m.money(high)), utf8.RuneCountInString(m.money(low)))
+
+	s := ""
+	for row := netWorthChartHeight; row >= 1; row-- {
+		axis := ""
+		switch row {
+		case netWorthChartHeight:
+			axis = m.money(high)
+		case 1:
+			axis = m.money(low)
+		}
+		s += fmt.Sprintf("%*s |", axisWidth, axis)
+
+		for _, value := range values {
+			// The lowest value still gets one row so every period shows.
+			height := netWorthChartHeight
+			if span > 0 {
+				height = 1 + int((value-lowFloat)/span*float64(netWorthChartHeight-1)+0.5)
+			}
+			cell := " "
+			if height >= row {
+				cell = "#"
+			}
+			s += " " + strings.Repeat(cell, width-1)
+		}
+		s += "\n"
+	}
+
+	s += strings.Repeat(" ", axisWidth) + " +" + strings.Repeat("-", len(values)*width) + "\n"
+	s += strings.Repeat(" ", axisWidth) + "  "
+	for _, period := range m.report.Periods {
+		s += fmt.Sprintf(" %-*s", width-1, m.chartLabel(period))
+	}
+	return s + "\n"
+}
+
+// periodView lists each account's balance at the end of a period.
+func (m netWorthModel) periodView(period NetWorthPeriod) string {
+	s := fmt.Sprintf("Balances on %s\n", period.EndDate.Format("2006-01-02"))
+	if len(period.UnconvertedCurrencies) > 0 {
+		s += errorStyle.Render(fmt.Sprintf("No exchange rate for %s yet, so those accounts are left out", strings.Join(period.UnconvertedCurrencies, ", "))) + "\n"
+	}
+
+	s += fmt.Sprintf("\nAssets: %s\n", m.money(period.TotalAssets))
+	for _, account := range period.Assets {
+		s += m.accountView(account)
+	}
+	s += fmt.Sprintf("\nLiabilities: %s\n", m.money(period.TotalLiabilities))
+	for _, account := range period.Liabilities {
+		s += m.accountView(account)
+	}
+	return s
+}
+
+func (m netWorthModel) accountView(account NetWorthAccount) string {
+	s := fmt.Sprintf("  %-24s  %14s", truncate(account.AccountName, 24), formatMoney(account.Balance, account.Currency))
+	if account.Currency != m.report.BaseCurrency {
+		if account.BaseBalance.Valid {
+			s += fmt.Sprintf("  (%s)", m.money(account.BaseBalance.Decimal))
+		} else {
+			s += "  (no rate)"
+		}
+	}
+	return s + "\n"
+}
+
+// periodLabel names a period in the table.
+func (m netWorthModel) periodLabel(period NetWorthPeriod) string {
+	switch m.report.Interval {
+	case "quarter":
+		return fmt.Sprintf("%d Q%d", period.EndDate.Year(), (int(period.EndDate.Month())+2)/3)
+	case "year":
+		return period.EndDate.Format("2006")
+	}
+	return period.EndDate.Format("Jan 2006")
+}
+
+// chartLabel is a shorter name for a period, to fit under its column.
+func (m netWorthModel) chartLabel(period NetWorthPeriod) string {
+	switch m.report.Interval {
+	case "quarter":
+		return fmt.Sprintf("Q%d", (int(period.EndDate.Month())+2)/3)
+	case "year":
+		return period.EndDate.Format("2006")
+	}
+	return period.EndDate.Format("Jan")
+}
+
+func (m netWorthModel) money(amount decimal.Decimal) string {
+	return formatMoney(amount, m.report.BaseCurrency)
+}
+
+func (m netWorthModel) errorView() string {
+	if m.errorMsg == "" {
+		return ""
+	}
+	return fmt.Sprintf("Error: %s\n\n", m.errorMsg)
+}
+
+func (m netWorthModel) IsEditing() bool {
+	return false
+}
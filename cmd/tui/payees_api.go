@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+)
+
+type Payee struct {
+	ID                  uuid.UUID `json:"id"`
+	PayeeName           string    `json:"payee_name"`
+	DefaultCategoryID   uuid.UUID `json:"default_category_id"`
+	DefaultCategoryName string    `json:"default_category_name"`
+}
+
+type PayeesAPI interface {
+	ListPayees(ctx context.Context) ([]Payee, error)
+}
+
+type payeesClient struct {
+	client *Client
+}
+
+func (c *Client) Payees() PayeesAPI {
+	return &payeesClient{client: c}
+}
+
+func (p *payeesClient) ListPayees(ctx context.Context) ([]Payee, error) {
+	req, err := p.client.newRequest(ctx, http.MethodGet, "/payees", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := p.client.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Failed getting payees: %s", res.Status)
+	}
+
+	var payees []Payee
+	if err := json.NewDecoder(res.Body).Decode(&payees); err != nil {
+		return nil, err
+	}
+
+	return payees, nil
+}
+
+type payeesLoadedMsg struct {
+	payees []Payee
+	err    error
+}
+
+func loadPayeesCmd(api PayeesAPI) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		payees, err := api.ListPayees(ctx)
+		return payeesLoadedMsg{
+			payees: payees,
+			err:    err,
+		}
+	}
+}
@@ -0,0 +1,365 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type Rule struct {
+	ID                  uuid.UUID        `json:"id"`
+	RuleName            string           `json:"rule_name"`
+	SortOrder           int              `json:"sort_order"`
+	DescriptionContains string           `json:"description_contains"`
+	DescriptionRegex    string           `json:"description_regex"`
+	MinAmount           *decimal.Decimal `json:"min_amount"`
+	MaxAmount           *decimal.Decimal `json:"max_amount"`
+	AccountID           uuid.UUID        `json:"account_id"`
+	AccountName         string           `json:"account_name"`
+	SetCategoryID       uuid.UUID        `json:"set_category_id"`
+	SetCategoryName     string           `json:"set_category_name"`
+	SetDescription      string           `json:"set_description"`
+	SetPosted           *bool            `json:"set_posted"`
+}
+
+type RuleRequest struct {
+	RuleName            string           `json:"rule_name"`
+	DescriptionContains string           `json:"description_contains"`
+	DescriptionRegex    string           `json:"description_regex"`
+	MinAmount           *decimal.Decimal `json:"min_amount"`
+	MaxAmount           *decimal.Decimal `json:"max_amount"`
+	AccountID           uuid.UUID        `json:"account_id"`
+	SetCategoryID       uuid.UUID        `json:"set_category_id"`
+	SetDescription      string           `json:"set_description"`
+	SetPosted           *bool            `json:"set_posted"`
+}
+
+type ApplyRulesResult struct {
+	Checked int `json:"checked"`
+	Updated int `json:"updated"`
+}
+
+type RulesAPI interface {
+	ListRules(ctx context.Context) ([]Rule, error)
+	CreateRule(ctx context.Context, req RuleRequest) (Rule, error)
+	UpdateRule(ctx context.Context, id uuid.UUID, req RuleRequest) (Rule, error)
+	DeleteRule(ctx context.Context, id uuid.UUID) error
+	ReorderRules(ctx context.Context, ruleIDs []uuid.UUID) ([]Rule, error)
+	ApplyRules(ctx context.Context, startDate, endDate time.Time) (ApplyRulesResult, error)
+}
+
+type rulesClient struct {
+	client *Client
+}
+
+func (c *Client) Rules() RulesAPI {
+	return &rulesClient{client: c}
+}
+
+func (r *rulesClient) ListRules(ctx context.Context) ([]Rule, error) {
+	req, err := r.client.newRequest(ctx, http.MethodGet, "/rules", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.client.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Failed getting rules: %s", res.Status)
+	}
+
+	var rules []Rule
+	if err := json.NewDecoder(res.Body).Decode(&rules); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (r *rulesClient) CreateRule(ctx context.Context, req RuleRequest) (Rule, error) {
+	httpReq, err := r.client.newJSONRequest(ctx, http.MethodPost, "/rules", req)
+	if err != nil {
+		return Rule{}, err
+	}
+
+	res, err := r.client.httpClient.Do(httpReq)
+	if err != nil {
+		return Rule{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated {
+		return Rule{}, fmt.Errorf("Failed creating rule: %s", res.Status)
+	}
+
+	var rule Rule
+	if err := json.NewDecoder(res.Body).Decode(&rule); err != nil {
+		return Rule{}, err
+	}
+
+	return rule, nil
+}
+
+func (r *rulesClient) UpdateRule(ctx context.Context, id uuid.UUID, req RuleRequest) (Rule, error) {
+	httpReq, err := r.client.newJSONRequest(ctx, http.MethodPut, "/rules/"+id.String(), req)
+	if err != nil {
+		return Rule{}, err
+	}
+
+	res, err := r.client.httpClient.Do(httpReq)
+	if err != nil {
+		return Rule{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return Rule{}, fmt.Errorf("Failed updating rule: %s", res.Status)
+	}
+
+	var rule Rule
+	if err := json.NewDecoder(res.Body).Decode(&rule); err != nil {
+		return Rule{}, err
+	}
+
+	return rule, nil
+}
+
+func (r *rulesClient) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	req, err := r.client.newRequest(ctx, http.MethodDelete, "/rules/"+id.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.client.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK {
+		return fmt.Errorf("Failed deleting rule: %s", res.Status)
+	}
+
+	return nil
+}
+
+func (r *rulesClient) ReorderRules(ctx context.Context, ruleIDs []uuid.UUID) ([]Rule, error) {
+	body := struct {
+		RuleIDs []uuid.UUID `json:"rule_ids"`
+	}{
+		RuleIDs: ruleIDs,
+	}
+
+	req, err := r.client.newJSONRequest(ctx, http.MethodPut, "/rules/order", body)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.client.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Failed reordering rules: %s", res.Status)
+	}
+
+	var rules []Rule
+	if err := json.NewDecoder(res.Body).Decode(&rules); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (r *rulesClient) ApplyRules(ctx context.Context, startDate, endDate time.Time) (ApplyRulesResult, error) {
+	body := struct {
+		StartDate time.Time `json:"start_date"`
+		EndDate   time.Time `json:"end_date"`
+	}{
+		StartDate: startDate,
+		EndDate:   endDate,
+	}
+
+	req, err := r.client.newJSONRequest(ctx, http.MethodPost, "/rules/apply", body)
+	if err != nil {
+		return ApplyRulesResult{}, err
+	}
+
+	res, err := r.client.httpClient.Do(req)
+	if err != nil {
+		return ApplyRulesResult{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return ApplyRulesResult{}, fmt.Errorf("Failed applying rules: %s", res.Status)
+	}
+
+	var result ApplyRulesResult
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return ApplyRulesResult{}, err
+	}
+
+	return result, nil
+}
+
+type rulesReloadRequestedMsg struct{}
+type rulesNewRequestedMsg struct{}
+type rulesEditRequestedMsg struct{}
+
+type rulesLoadedMsg struct {
+	rules []Rule
+	err   error
+}
+
+func loadRulesCmd(api RulesAPI) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		rules, err := api.ListRules(ctx)
+		return rulesLoadedMsg{
+			rules: rules,
+			err:   err,
+		}
+	}
+}
+
+type ruleSavedMsg struct {
+	rule Rule
+	err  error
+}
+
+func createRuleCmd(api RulesAPI, req RuleRequest) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		rule, err := api.CreateRule(ctx, req)
+		return ruleSavedMsg{
+			rule: rule,
+			err:  err,
+		}
+	}
+}
+
+func updateRuleCmd(api RulesAPI, id uuid.UUID, req RuleRequest) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		rule, err := api.UpdateRule(ctx, id, req)
+		return ruleSavedMsg{
+			rule: rule,
+			err:  err,
+		}
+	}
+}
+
+// ruleSubmittedMsg carries the raw form values; the amounts are parsed by
+// the app model like the other forms. A nil RuleID creates a new rule.
+type ruleSubmittedMsg struct {
+	RuleID              uuid.UUID
+	RuleName            string
+	DescriptionContains string
+	DescriptionRegex    string
+	MinAmountText       string
+	MaxAmountText       string
+	AccountID           uuid.UUID
+	SetCategoryID       uuid.UUID
+	SetDescription      string
+	SetPosted           *bool
+}
+
+func submitRuleMsg(submission ruleSubmittedMsg) tea.Cmd {
+	return func() tea.Msg {
+		return submission
+	}
+}
+
+type ruleDeletedMsg struct {
+	ruleID uuid.UUID
+	err    error
+}
+
+func deleteRuleCmd(api RulesAPI, id uuid.UUID) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		err := api.DeleteRule(ctx, id)
+		return ruleDeletedMsg{
+			ruleID: id,
+			err:    err,
+		}
+	}
+}
+
+type ruleDeleteSubmittedMsg struct {
+	ruleID uuid.UUID
+}
+
+func submitDeleteRuleMsg(id uuid.UUID) tea.Cmd {
+	return func() tea.Msg {
+		return ruleDeleteSubmittedMsg{
+			ruleID: id,
+		}
+	}
+}
+
+func reorderRulesCmd(api RulesAPI, ruleIDs []uuid.UUID) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		rules, err := api.ReorderRules(ctx, ruleIDs)
+		return rulesLoadedMsg{
+			rules: rules,
+			err:   err,
+		}
+	}
+}
+
+type rulesReorderSubmittedMsg struct {
+	ruleIDs []uuid.UUID
+}
+
+func submitReorderRulesMsg(ruleIDs []uuid.UUID) tea.Cmd {
+	return func() tea.Msg {
+		return rulesReorderSubmittedMsg{
+			ruleIDs: ruleIDs,
+		}
+	}
+}
+
+type rulesAppliedMsg struct {
+	result ApplyRulesResult
+	err    error
+}
+
+func applyRulesCmd(api RulesAPI, startDate, endDate time.Time) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		result, err := api.ApplyRules(ctx, startDate, endDate)
+		return rulesAppliedMsg{
+			result: result,
+			err:    err,
+		}
+	}
+}
+
+type rulesApplySubmittedMsg struct {
+	StartDate string
+	EndDate   string
+}
+
+func submitApplyRulesMsg(startDate, endDate string) tea.Cmd {
+	return func() tea.Msg {
+		return rulesApplySubmittedMsg{
+			StartDate: startDate,
+			EndDate:   endDate,
+		}
+	}
+}
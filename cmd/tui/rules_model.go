@@ -0,0 +1,558 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+)
+
+type rulesMode int
+
+const (
+	rulesModeList rulesMode = iota
+	rulesModeFormNew
+	rulesModeFormEdit
+	rulesModeDelete
+	rulesModeApply
+)
+
+const (
+	ruleFormFieldName = iota
+	ruleFormFieldContains
+	ruleFormFieldRegex
+	ruleFormFieldMinAmount
+	ruleFormFieldMaxAmount
+	ruleFormFieldAccount
+	ruleFormFieldCategory
+	ruleFormFieldRename
+	ruleFormFieldPosted
+	ruleFormFieldSave
+)
+
+const (
+	ruleApplyFieldStart = iota
+	ruleApplyFieldEnd
+	ruleApplyFieldRun
+)
+
+const ruleApplyDefaultDays = 30
+
+type ruleOption struct {
+	ID   uuid.UUID
+	Name string
+}
+
+var rulePostedLabels = []string{"Don't change", "Posted", "Unposted"}
+
+type rulesModel struct {
+	mode   rulesMode
+	rules  []Rule
+	cursor int
+
+	formEditing      bool
+	formFieldCursor  int
+	nameInput        textinput.Model
+	containsInput    textinput.Model
+	regexInput       textinput.Model
+	minAmountInput   textinput.Model
+	maxAmountInput   textinput.Model
+	renameInput      textinput.Model
+	formAccountIndex int
+	formCatIndex     int
+	formPostedIndex  int
+	accountOptions   []ruleOption
+	categoryOptions  []ruleOption
+
+	applyStartInput textinput.Model
+	applyEndInput   textinput.Model
+	statusMsg       string
+
+	confirmCursor int
+	errorMsg      string
+}
+
+func newRuleInput() textinput.Model {
+	input := textinput.New()
+	input.CharLimit = 128
+	input.Blur()
+	return input
+}
+
+func initialRulesModel() rulesModel {
+	return rulesModel{
+		rules:           []Rule{},
+		nameInput:       newRuleInput(),
+		containsInput:   newRuleInput(),
+		regexInput:      newRuleInput(),
+		minAmountInput:  newRuleInput(),
+		maxAmountInput:  newRuleInput(),
+		renameInput:     newRuleInput(),
+		applyStartInput: newRuleInput(),
+		applyEndInput:   newRuleInput(),
+		accountOptions:  []ruleOption{},
+		categoryOptions: []ruleOption{},
+		confirmCursor:   confirmCancel,
+	}
+}
+
+// input returns the text input behind a form field, or nil for pickers.
+func (m *rulesModel) input(field int) *textinput.Model {
+	if m.mode == rulesModeApply {
+		switch field {
+		case ruleApplyFieldStart:
+			return &m.applyStartInput
+		case ruleApplyFieldEnd:
+			return &m.applyEndInput
+		}
+		return nil
+	}
+
+	switch field {
+	case ruleFormFieldName:
+		return &m.nameInput
+	case ruleFormFieldContains:
+		return &m.containsInput
+	case ruleFormFieldRegex:
+		return &m.regexInput
+	case ruleFormFieldMinAmount:
+		return &m.minAmountInput
+	case ruleFormFieldMaxAmount:
+		return &m.maxAmountInput
+	case ruleFormFieldRename:
+		return &m.renameInput
+	}
+	return nil
+}
+
+func (m *rulesModel) blurInputs() {
+	for _, input := range []*textinput.Model{&m.nameInput, &m.containsInput, &m.regexInput, &m.minAmountInput, &m.maxAmountInput, &m.renameInput, &m.applyStartInput, &m.applyEndInput} {
+		input.Blur()
+	}
+}
+
+// resetForm fills the form from rule, or clears it for a new rule.
+func (m *rulesModel) resetForm(rule Rule) {
+	m.formEditing = false
+	m.formFieldCursor = ruleFormFieldName
+	m.blurInputs()
+	m.nameInput.SetValue(rule.RuleName)
+	m.containsInput.SetValue(rule.DescriptionContains)
+	m.regexInput.SetValue(rule.DescriptionRegex)
+	m.minAmountInput.SetValue("")
+	if rule.MinAmount != nil {
+		m.minAmountInput.SetValue(rule.MinAmount.String())
+	}
+	m.maxAmountInput.SetValue("")
+	if rule.MaxAmount != nil {
+		m.maxAmountInput.SetValue(rule.MaxAmount.String())
+	}
+	m.renameInput.SetValue(rule.SetDescription)
+
+	m.formAccountIndex = 0
+	for i, option := range m.accountOptions {
+		if option.ID == rule.AccountID {
+			m.formAccountIndex = i
+			break
+		}
+	}
+	m.formCatIndex = 0
+	for i, option := range m.categoryOptions {
+		if option.ID == rule.SetCategoryID {
+			m.formCatIndex = i
+			break
+		}
+	}
+	m.formPostedIndex = 0
+	if rule.SetPosted != nil {
+		if *rule.SetPosted {
+			m.formPostedIndex = 1
+		} else {
+			m.formPostedIndex = 2
+		}
+	}
+	m.errorMsg = ""
+}
+
+func (m rulesModel) submission() ruleSubmittedMsg {
+	submission := ruleSubmittedMsg{
+		RuleName:            m.nameInput.Value(),
+		DescriptionContains: m.containsInput.Value(),
+		DescriptionRegex:    m.regexInput.Value(),
+		MinAmountText:       strings.TrimSpace(m.minAmountInput.Value()),
+		MaxAmountText:       strings.TrimSpace(m.maxAmountInput.Value()),
+		AccountID:           m.accountOptions[m.formAccountIndex].ID,
+		SetCategoryID:       m.categoryOptions[m.formCatIndex].ID,
+		SetDescription:      m.renameInput.Value(),
+	}
+	if m.formPostedIndex > 0 {
+		posted := m.formPostedIndex == 1
+		submission.SetPosted = &posted
+	}
+	if m.mode == rulesModeFormEdit {
+		submission.RuleID = m.rules[m.cursor].ID
+	}
+	return submission
+}
+
+// moveRule swaps the selected rule with its neighbour and submits the new
+// order.
+func (m rulesModel) moveRule(offset int) (rulesModel, tea.Cmd) {
+	target := m.cursor + offset
+	if len(m.rules) < 2 || target < 0 || target >= len(m.rules) {
+		return m, nil
+	}
+
+	ruleIDs := make([]uuid.UUID, len(m.rules))
+	for i, rule := range m.rules {
+		ruleIDs[i] = rule.ID
+	}
+	ruleIDs[m.cursor], ruleIDs[target] = ruleIDs[target], ruleIDs[m.cursor]
+	m.cursor = target
+	return m, submitReorderRulesMsg(ruleIDs)
+}
+
+func (m rulesModel) Update(msg tea.Msg) (rulesModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case rulesLoadedMsg:
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.errorMsg = ""
+		m.rules = msg.rules
+		if m.cursor >= len(m.rules) {
+			m.cursor = 0
+		}
+		return m, nil
+
+	case ruleSavedMsg:
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.errorMsg = ""
+		m.mode = rulesModeList
+		return m, func() tea.Msg {
+			return rulesReloadRequestedMsg{}
+		}
+
+	case ruleDeletedMsg:
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.errorMsg = ""
+		m.mode = rulesModeList
+		filtered := m.rules[:0]
+		for _, rule := range m.rules {
+			if rule.ID != msg.ruleID {
+				filtered = append(filtered, rule)
+			}
+		}
+		m.rules = filtered
+		if m.cursor >= len(m.rules) && m.cursor > 0 {
+			m.cursor = len(m.rules) - 1
+		}
+		return m, nil
+
+	case rulesAppliedMsg:
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.errorMsg = ""
+		m.mode = rulesModeList
+		m.statusMsg = fmt.Sprintf("Checked %d transactions, updated %d", msg.result.Checked, msg.result.Updated)
+		return m, func() tea.Msg {
+			return transactionsReloadRequestedMsg{}
+		}
+
+	case tea.KeyMsg:
+		key := msg.String()
+
+		switch m.mode {
+		case rulesModeList:
+			switch key {
+			case "up", "k":
+				if m.cursor > 0 {
+					m.cursor--
+				}
+			case "down", "j":
+				if m.cursor < len(m.rules)-1 {
+					m.cursor++
+				}
+			case "K":
+				return m.moveRule(-1)
+			case "J":
+				return m.moveRule(1)
+			case "n":
+				m.statusMsg = ""
+				return m, func() tea.Msg {
+					return rulesNewRequestedMsg{}
+				}
+			case "e", "enter":
+				if len(m.rules) > 0 {
+					m.statusMsg = ""
+					return m, func() tea.Msg {
+						return rulesEditRequestedMsg{}
+					}
+				}
+			case "d":
+				if len(m.rules) > 0 {
+					m.mode = rulesModeDelete
+					m.confirmCursor = confirmCancel
+				}
+			case "a":
+				m.mode = rulesModeApply
+				m.formEditing = false
+				m.formFieldCursor = ruleApplyFieldStart
+				m.blurInputs()
+				today := time.Now()
+				m.applyStartInput.SetValue(today.AddDate(0, 0, -ruleApplyDefaultDays).Format(time.DateOnly))
+				m.applyEndInput.SetValue(today.Format(time.DateOnly))
+				m.statusMsg = ""
+				m.errorMsg = ""
+			case "r":
+				return m, func() tea.Msg {
+					return rulesReloadRequestedMsg{}
+				}
+			}
+
+		case rulesModeFormNew, rulesModeFormEdit, rulesModeApply:
+			if m.formEditing {
+				if key == "esc" || key == "enter" {
+					m.formEditing = false
+					m.blurInputs()
+					return m, nil
+				}
+
+				if input := m.input(m.formFieldCursor); input != nil {
+					var cmd tea.Cmd
+					*input, cmd = input.Update(msg)
+					return m, cmd
+				}
+			}
+
+			lastField := ruleFormFieldSave
+			if m.mode == rulesModeApply {
+				lastField = ruleApplyFieldRun
+			}
+
+			switch key {
+			case "esc":
+				m.mode = rulesModeList
+				m.errorMsg = ""
+			case "up", "k":
+				if m.formFieldCursor > 0 {
+					m.formFieldCursor--
+				}
+			case "down", "j":
+				if m.formFieldCursor < lastField {
+					m.formFieldCursor++
+				}
+			case "enter":
+				if input := m.input(m.formFieldCursor); input != nil {
+					m.formEditing = true
+					input.Focus()
+					return m, nil
+				}
+				if m.formFieldCursor != lastField {
+					return m, nil
+				}
+				if m.mode == rulesModeApply {
+					return m, submitApplyRulesMsg(m.applyStartInput.Value(), m.applyEndInput.Value())
+				}
+				return m, submitRuleMsg(m.submission())
+			case "left", "h":
+				switch {
+				case m.mode == rulesModeApply:
+				case m.formFieldCursor == ruleFormFieldAccount && m.formAccountIndex > 0:
+					m.formAccountIndex--
+				case m.formFieldCursor == ruleFormFieldCategory && m.formCatIndex > 0:
+					m.formCatIndex--
+				case m.formFieldCursor == ruleFormFieldPosted && m.formPostedIndex > 0:
+					m.formPostedIndex--
+				}
+			case "right", "l":
+				switch {
+				case m.mode == rulesModeApply:
+				case m.formFieldCursor == ruleFormFieldAccount && m.formAccountIndex < len(m.accountOptions)-1:
+					m.formAccountIndex++
+				case m.formFieldCursor == ruleFormFieldCategory && m.formCatIndex < len(m.categoryOptions)-1:
+					m.formCatIndex++
+				case m.formFieldCursor == ruleFormFieldPosted && m.formPostedIndex < len(rulePostedLabels)-1:
+					m.formPostedIndex++
+				}
+			}
+
+		case rulesModeDelete:
+			switch key {
+			case "esc":
+				m.mode = rulesModeList
+			case "up", "k":
+				if m.confirmCursor > confirmYes {
+					m.confirmCursor--
+				}
+			case "down", "j":
+				if m.confirmCursor < confirmCancel {
+					m.confirmCursor++
+				}
+			case "enter":
+				switch m.confirmCursor {
+				case confirmYes:
+					return m, submitDeleteRuleMsg(m.rules[m.cursor].ID)
+				case confirmCancel:
+					m.mode = rulesModeList
+				}
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m rulesModel) View() string {
+	currentRow := func(field int) string {
+		if m.formFieldCursor == field {
+			return ">"
+		}
+		return " "
+	}
+
+	switch m.mode {
+	case rulesModeList:
+		s := "Rules\n\n"
+		s += m.errorView()
+		if m.statusMsg != "" {
+			s += m.statusMsg + "\n\n"
+		}
+
+		if len(m.rules) == 0 {
+			s += "No rules yet. Rules categorize, rename and post new and imported transactions.\n"
+		}
+
+		for i, rule := range m.rules {
+			cursor := " "
+			if m.cursor == i {
+				cursor = ">"
+			}
+			s += fmt.Sprintf("%s %d. %s\n", cursor, i+1, rule.RuleName)
+			s += fmt.Sprintf("    If %s\n", ruleConditionsSummary(rule))
+			s += fmt.Sprintf("    Then %s\n", ruleActionsSummary(rule))
+		}
+
+		s += "\nRules run top to bottom; later rules override earlier ones.\n"
+		s += "\n(Use 'j'/'k' to move, 'K'/'J' to reorder, 'n' to create a rule, 'e' to edit, 'd' to delete, 'a' to apply to past transactions, 'r' to reload)\n"
+		return s
+
+	case rulesModeFormNew, rulesModeFormEdit:
+		s := "New Rule\n\n"
+		if m.mode == rulesModeFormEdit {
+			s = "Edit Rule\n\n"
+		}
+		s += m.errorView()
+
+		s += fmt.Sprintf("%s Name: %s\n\n", currentRow(ruleFormFieldName), m.nameInput.View())
+		s += "Conditions (leave blank to ignore)\n"
+		s += fmt.Sprintf("%s Description contains: %s\n", currentRow(ruleFormFieldContains), m.containsInput.View())
+		s += fmt.Sprintf("%s Description regex: %s\n", currentRow(ruleFormFieldRegex), m.regexInput.View())
+		s += fmt.Sprintf("%s Min amount: %s\n", currentRow(ruleFormFieldMinAmount), m.minAmountInput.View())
+		s += fmt.Sprintf("%s Max amount: %s\n", currentRow(ruleFormFieldMaxAmount), m.maxAmountInput.View())
+		s += fmt.Sprintf("%s Account ('h'/'l' to change): %s\n\n", currentRow(ruleFormFieldAccount), m.accountOptions[m.formAccountIndex].Name)
+		s += "Actions\n"
+		s += fmt.Sprintf("%s Set category ('h'/'l' to change): %s\n", currentRow(ruleFormFieldCategory), m.categoryOptions[m.formCatIndex].Name)
+		s += fmt.Sprintf("%s Rename to: %s\n", currentRow(ruleFormFieldRename), m.renameInput.View())
+		s += fmt.Sprintf("%s Posted ('h'/'l' to change): %s\n\n", currentRow(ruleFormFieldPosted), rulePostedLabels[m.formPostedIndex])
+
+		s += fmt.Sprintf("%s [ Save ]\n", currentRow(ruleFormFieldSave))
+		s += "\n(Use 'j'/'k' to move, 'enter' to edit field, 'esc' to stop editing, 'esc' again to cancel)\n"
+		return s
+
+	case rulesModeApply:
+		s := "Apply Rules to Past Transactions\n\n"
+		s += m.errorView()
+
+		s += fmt.Sprintf("%s Start date(YYYY-MM-DD): %s\n", currentRow(ruleApplyFieldStart), m.applyStartInput.View())
+		s += fmt.Sprintf("%s End date(YYYY-MM-DD): %s\n\n", currentRow(ruleApplyFieldEnd), m.applyEndInput.View())
+		s += "Transfers, split transactions and transactions that already have a category keep their category.\n\n"
+
+		s += fmt.Sprintf("%s [ Apply ]\n", currentRow(ruleApplyFieldRun))
+		s += "\n(Use 'j'/'k' to move, 'enter' to edit field, 'esc' to cancel)\n"
+		return s
+
+	case rulesModeDelete:
+		s := "Delete Rule\n\n"
+		s += fmt.Sprintf("Are you sure you want to delete rule '%s'?\n", m.rules[m.cursor].RuleName)
+
+		confirmRow := func(field int) string {
+			if m.confirmCursor == field {
+				return ">"
+			}
+			return " "
+		}
+
+		s += fmt.Sprintf("%s [ Yes ]\n", confirmRow(confirmYes))
+		s += fmt.Sprintf("%s [ Cancel ]\n", confirmRow(confirmCancel))
+
+		s += "\n(Use 'j'/'k' to move, 'enter' to select, 'esc' to cancel)"
+		return s
+	}
+
+	return "Unknown rules mode"
+}
+
+func ruleConditionsSummary(rule Rule) string {
+	var conditions []string
+	if rule.DescriptionContains != "" {
+		conditions = append(conditions, fmt.Sprintf("description contains %q", rule.DescriptionContains))
+	}
+	if rule.DescriptionRegex != "" {
+		conditions = append(conditions, fmt.Sprintf("description matches /%s/", rule.DescriptionRegex))
+	}
+	if rule.MinAmount != nil {
+		conditions = append(conditions, fmt.Sprintf("amount >= %s", rule.MinAmount))
+	}
+	if rule.MaxAmount != nil {
+		conditions = append(conditions, fmt.Sprintf("amount <= %s", rule.MaxAmount))
+	}
+	if rule.AccountID != uuid.Nil {
+		conditions = append(conditions, fmt.Sprintf("account is %s", rule.AccountName))
+	}
+	return strings.Join(conditions, " and ")
+}
+
+func ruleActionsSummary(rule Rule) string {
+	var actions []string
+	if rule.SetCategoryID != uuid.Nil {
+		actions = append(actions, fmt.Sprintf("categorize as %s", rule.SetCategoryName))
+	}
+	if rule.SetDescription != "" {
+		actions = append(actions, fmt.Sprintf("rename to %q", rule.SetDescription))
+	}
+	if rule.SetPosted != nil {
+		if *rule.SetPosted {
+			actions = append(actions, "mark posted")
+		} else {
+			actions = append(actions, "mark unposted")
+		}
+	}
+	if len(actions) == 0 {
+		return "(nothing; its category was deleted)"
+	}
+	return strings.Join(actions, ", ")
+}
+
+func (m rulesModel) errorView() string {
+	if m.errorMsg == "" {
+		return ""
+	}
+	return fmt.Sprintf("Error: %s\n\n", m.errorMsg)
+}
+
+func (m rulesModel) IsEditing() bool {
+	return m.formEditing
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type ScheduledTransaction struct {
+	ID              uuid.UUID       `json:"id"`
+	Amount          decimal.Decimal `json:"amount"`
+	TxDescription   string          `json:"tx_description"`
+	Frequency       string          `json:"frequency"`
+	DayOfMonth      int             `json:"day_of_month"`
+	StartDate       time.Time       `json:"start_date"`
+	EndDate         *time.Time      `json:"end_date"`
+	OccurrenceCount int             `json:"occurrence_count"`
+	LastDate        *time.Time      `json:"last_date"`
+	NextDate        *time.Time      `json:"next_date"`
+	AccountID       uuid.UUID       `json:"account_id"`
+	CategoryID      uuid.UUID       `json:"category_id"`
+}
+
+type ScheduledOccurrence struct {
+	ScheduledID   uuid.UUID       `json:"scheduled_id"`
+	Date          time.Time       `json:"date"`
+	Amount        decimal.Decimal `json:"amount"`
+	TxDescription string          `json:"tx_description"`
+	AccountID     uuid.UUID       `json:"account_id"`
+	CategoryID    uuid.UUID       `json:"category_id"`
+	IsNext        bool            `json:"is_next"`
+}
+
+type ScheduledAPI interface {
+	ListUpcoming(ctx context.Context, days int) ([]ScheduledOccurrence, error)
+	EnterNext(ctx context.Context, id uuid.UUID) (ScheduledTransaction, error)
+	SkipNext(ctx context.Context, id uuid.UUID) (ScheduledTransaction, error)
+}
+
+type scheduledClient struct {
+	client *Client
+}
+
+func (c *Client) Scheduled() ScheduledAPI {
+	return &scheduledClient{client: c}
+}
+
+func (s *scheduledClient) ListUpcoming(ctx context.Context, days int) ([]ScheduledOccurrence, error) {
+	req, err := s.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/scheduled/upcoming?days=%d", days), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.client.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Failed getting upcoming scheduled transactions: %s", res.Status)
+	}
+
+	var occurrences []ScheduledOccurrence
+	if err := json.NewDecoder(res.Body).Decode(&occurrences); err != nil {
+		return nil, err
+	}
+
+	return occurrences, nil
+}
+
+type scheduledReloadRequestedMsg struct{}
+
+type scheduledLoadedMsg struct {
+	occurrences []ScheduledOccurrence
+	err         error
+}
+
+func loadScheduledCmd(api ScheduledAPI, days int) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		occurrences, err := api.ListUpcoming(ctx, days)
+		return scheduledLoadedMsg{
+			occurrences: occurrences,
+			err:         err,
+		}
+	}
+}
+
+func (s *scheduledClient) EnterNext(ctx context.Context, id uuid.UUID) (ScheduledTransaction, error) {
+	return s.postOccurrenceAction(ctx, id, "enter")
+}
+
+func (s *scheduledClient) SkipNext(ctx context.Context, id uuid.UUID) (ScheduledTransaction, error) {
+	return s.postOccurrenceAction(ctx, id, "skip")
+}
+
+func (s *scheduledClient) postOccurrenceAction(ctx context.Context, id uuid.UUID, action string) (ScheduledTransaction, error) {
+	req, err := s.client.newRequest(ctx, http.MethodPost, "/scheduled/"+id.String()+"/"+action, nil)
+	if err != nil {
+		return ScheduledTransaction{}, err
+	}
+
+	res, err := s.client.httpClient.Do(req)
+	if err != nil {
+		return ScheduledTransaction{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return ScheduledTransaction{}, fmt.Errorf("Failed to %s scheduled transaction: %s", action, res.Status)
+	}
+
+	var scheduled ScheduledTransaction
+	if err := json.NewDecoder(res.Body).Decode(&scheduled); err != nil {
+		return ScheduledTransaction{}, err
+	}
+
+	return scheduled, nil
+}
+
+type scheduledOccurrenceHandledMsg struct {
+	scheduled ScheduledTransaction
+	entered   bool
+	err       error
+}
+
+func enterScheduledCmd(api ScheduledAPI, id uuid.UUID) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		scheduled, err := api.EnterNext(ctx, id)
+		return scheduledOccurrenceHandledMsg{
+			scheduled: scheduled,
+			entered:   true,
+			err:       err,
+		}
+	}
+}
+
+func skipScheduledCmd(api ScheduledAPI, id uuid.UUID) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		scheduled, err := api.SkipNext(ctx, id)
+		return scheduledOccurrenceHandledMsg{
+			scheduled: scheduled,
+			entered:   false,
+			err:       err,
+		}
+	}
+}
+
+type scheduledEnterSubmittedMsg struct {
+	scheduledID uuid.UUID
+}
+
+func submitEnterScheduledMsg(id uuid.UUID) tea.Cmd {
+	return func() tea.Msg {
+		return scheduledEnterSubmittedMsg{
+			scheduledID: id,
+		}
+	}
+}
+
+type scheduledSkipSubmittedMsg struct {
+	scheduledID uuid.UUID
+}
+
+func submitSkipScheduledMsg(id uuid.UUID) tea.Cmd {
+	return func() tea.Msg {
+		return scheduledSkipSubmittedMsg{
+			scheduledID: id,
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const scheduledUpcomingDays = 30
+
+type scheduledModel struct {
+	occurrences []ScheduledOccurrence
+	cursor      int
+	errorMsg    string
+}
+
+func initialScheduledModel() scheduledModel {
+	return scheduledModel{
+		occurrences: []ScheduledOccurrence{},
+		cursor:      0,
+	}
+}
+
+func (m scheduledModel) Update(msg tea.Msg) (scheduledModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case scheduledLoadedMsg:
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+
+		m.occurrences = msg.occurrences
+		if m.cursor >= len(m.occurrences) {
+			m.cursor = 0
+		}
+		return m, nil
+
+	case scheduledOccurrenceHandledMsg:
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+
+		m.errorMsg = ""
+		cmds := []tea.Cmd{func() tea.Msg {
+			return scheduledReloadRequestedMsg{}
+		}}
+		if msg.entered {
+			cmds = append(cmds, func() tea.Msg {
+				return transactionsReloadRequestedMsg{}
+			})
+		}
+		return m, tea.Batch(cmds...)
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.occurrences)-1 {
+				m.cursor++
+			}
+		case "r":
+			return m, func() tea.Msg {
+				return scheduledReloadRequestedMsg{}
+			}
+		case "e", "s":
+			if len(m.occurrences) == 0 {
+				return m, nil
+			}
+			occurrence := m.occurrences[m.cursor]
+			if !occurrence.IsNext {
+				m.errorMsg = "Only the next occurrence of a schedule can be entered or skipped"
+				return m, nil
+			}
+			m.errorMsg = ""
+			if msg.String() == "e" {
+				return m, submitEnterScheduledMsg(occurrence.ScheduledID)
+			}
+			return m, submitSkipScheduledMsg(occurrence.ScheduledID)
+		}
+	}
+
+	return m, nil
+}
+
+func (m scheduledModel) View() string {
+	s := fmt.Sprintf("Upcoming Scheduled Transactions - next %d days\n\n", scheduledUpcomingDays)
+	s += m.errorView()
+
+	if len(m.occurrences) == 0 {
+		s += "Nothing scheduled.\n"
+	}
+
+	for i, occurrence := range m.occurrences {
+		cursor := " "
+		if m.cursor == i {
+			cursor = ">"
+		}
+		nextTag := ""
+		if occurrence.IsNext {
+			nextTag = " [NEXT]"
+		}
+
+		s += fmt.Sprintf("%s %s | %s | $%s%s\n", cursor, occurrence.Date.Format("2006-01-02"), occurrence.TxDescription, occurrence.Amount.StringFixed(2), nextTag)
+	}
+
+	s += "\n(Use 'j'/'k' to move, 'e' to enter the next occurrence now, 's' to skip it, 'r' to reload)\n"
+	return s
+}
+
+func (m scheduledModel) errorView() string {
+	if m.errorMsg == "" {
+		return ""
+	}
+	return fmt.Sprintf("Error: %s\n\n", m.errorMsg)
+}
+
+func (m scheduledModel) IsEditing() bool {
+	return false
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+)
+
+type Tag struct {
+	ID               uuid.UUID `json:"id"`
+	TagName          string    `json:"tag_name"`
+	TransactionCount int64     `json:"transaction_count"`
+}
+
+type TransactionTag struct {
+	ID      uuid.UUID `json:"id"`
+	TagName string    `json:"tag_name"`
+}
+
+type TagsAPI interface {
+	ListTags(ctx context.Context) ([]Tag, error)
+	CreateTag(ctx context.Context, name string) (Tag, error)
+}
+
+type tagsClient struct {
+	client *Client
+}
+
+func (c *Client) Tags() TagsAPI {
+	return &tagsClient{client: c}
+}
+
+func (t *tagsClient) ListTags(ctx context.Context) ([]Tag, error) {
+	req, err := t.client.newRequest(ctx, http.MethodGet, "/tags", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.client.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Failed getting tags: %s", res.Status)
+	}
+
+	var tags []Tag
+	if err := json.NewDecoder(res.Body).Decode(&tags); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (t *tagsClient) CreateTag(ctx context.Context, name string) (Tag, error) {
+	body := struct {
+		TagName string `json:"tag_name"`
+	}{
+		TagName: name,
+	}
+
+	req, err := t.client.newJSONRequest(ctx, http.MethodPost, "/tags", body)
+	if err != nil {
+		return Tag{}, err
+	}
+
+	res, err := t.client.httpClient.Do(req)
+	if err != nil {
+		return Tag{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusConflict {
+		return Tag{}, fmt.Errorf("Tag %q already exists", name)
+	}
+	if res.StatusCode != http.StatusCreated {
+		return Tag{}, fmt.Errorf("Failed creating tag: %s", res.Status)
+	}
+
+	var tag Tag
+	if err := json.NewDecoder(res.Body).Decode(&tag); err != nil {
+		return Tag{}, err
+	}
+
+	return tag, nil
+}
+
+type tagsLoadedMsg struct {
+	tags []Tag
+	err  error
+}
+
+func loadTagsCmd(api TagsAPI) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		tags, err := api.ListTags(ctx)
+		return tagsLoadedMsg{
+			tags: tags,
+			err:  err,
+		}
+	}
+}
+
+type tagCreatedMsg struct {
+	tag Tag
+	err error
+}
+
+func createTagCmd(api TagsAPI, name string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		tag, err := api.CreateTag(ctx, name)
+		return tagCreatedMsg{
+			tag: tag,
+			err: err,
+		}
+	}
+}
+
+type tagCreateSubmittedMsg struct {
+	TagName string
+}
+
+func submitCreateTagMsg(name string) tea.Cmd {
+	return func() tea.Msg {
+		return tagCreateSubmittedMsg{
+			TagName: name,
+		}
+	}
+}
@@ -3,8 +3,11 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,10 +16,11 @@ import (
 )
 
 type TransactionsAPI interface {
-	ListTransactions(ctx context.Context) ([]Transaction, error)
+	ListTransactions(ctx context.Context, filter TransactionFilter, cursor string) (TransactionPage, error)
 	CreateTransaction(ctx context.Context, req CreateTransactionRequest) (Transaction, error)
 	UpdateTransaction(ctx context.Context, id uuid.UUID, req UpdateTransactionRequest) (Transaction, error)
 	DeleteTransaction(ctx context.Context, id uuid.UUID) error
+	CreateTransfer(ctx context.Context, req CreateTransferRequest) (Transfer, error)
 }
 
 type transactionsClient struct {
@@ -27,51 +31,262 @@ func (c *Client) Transactions() TransactionsAPI {
 	return &transactionsClient{client: c}
 }
 
-func (t *transactionsClient) ListTransactions(ctx context.Context) ([]Transaction, error) {
-	req, err := t.client.newRequest(ctx, http.MethodGet, "/transactions", nil)
+// TransactionFilter narrows the transactions list. Zero values are left
+// out, so an empty filter lists everything.
+type TransactionFilter struct {
+	StartDate  time.Time
+	EndDate    time.Time
+	MinAmount  decimal.NullDecimal
+	MaxAmount  decimal.NullDecimal
+	AccountID  uuid.UUID
+	CategoryID uuid.UUID
+	GroupID    uuid.UUID
+	TagID      uuid.UUID
+	Posted     *bool
+	Search     string
+}
+
+func (f TransactionFilter) IsZero() bool {
+	return f.StartDate.IsZero() &&
+		f.EndDate.IsZero() &&
+		!f.MinAmount.Valid &&
+		!f.MaxAmount.Valid &&
+		f.AccountID == uuid.Nil &&
+		f.CategoryID == uuid.Nil &&
+		f.GroupID == uuid.Nil &&
+		f.TagID == uuid.Nil &&
+		f.Posted == nil &&
+		f.Search == ""
+}
+
+func (f TransactionFilter) query() url.Values {
+	query := url.Values{}
+	if !f.StartDate.IsZero() {
+		query.Set("start", f.StartDate.Format(time.DateOnly))
+	}
+	if !f.EndDate.IsZero() {
+		query.Set("end", f.EndDate.Format(time.DateOnly))
+	}
+	if f.MinAmount.Valid {
+		query.Set("min_amount", f.MinAmount.Decimal.String())
+	}
+	if f.MaxAmount.Valid {
+		query.Set("max_amount", f.MaxAmount.Decimal.String())
+	}
+	if f.AccountID != uuid.Nil {
+		query.Set("account_id", f.AccountID.String())
+	}
+	if f.CategoryID != uuid.Nil {
+		query.Set("category_id", f.CategoryID.String())
+	}
+	if f.GroupID != uuid.Nil {
+		query.Set("group_id", f.GroupID.String())
+	}
+	if f.TagID != uuid.Nil {
+		query.Set("tag_id", f.TagID.String())
+	}
+	if f.Posted != nil {
+		query.Set("posted", fmt.Sprint(*f.Posted))
+	}
+	if f.Search != "" {
+		query.Set("q", f.Search)
+	}
+	return query
+}
+
+// TransactionPage is one page of a transaction listing. NextCursor is
+// empty on the last page.
+type TransactionPage struct {
+	Transactions []Transaction `json:"transactions"`
+	NextCursor   string        `json:"next_cursor"`
+}
+
+// loadMoreThreshold is how close the cursor gets to the bottom of a paged
+// list before the next page is fetched.
+const loadMoreThreshold = 10
+
+func (t *transactionsClient) ListTransactions(ctx context.Context, filter TransactionFilter, cursor string) (TransactionPage, error) {
+	query := filter.query()
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	path := "/transactions"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	req, err := t.client.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, err
+		return TransactionPage{}, err
 	}
 
 	resp, err := t.client.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return TransactionPage{}, err
 	}
 	defer resp.Body.Close()
-	var transactions []Transaction
-	if err := json.NewDecoder(resp.Body).Decode(&transactions); err != nil {
-		return nil, err
+
+	if resp.StatusCode != http.StatusOK {
+		return TransactionPage{}, fmt.Errorf("Failed to list transactions: %s", resp.Status)
 	}
 
-	return transactions, nil
+	var page TransactionPage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return TransactionPage{}, err
+	}
+
+	return page, nil
 }
 
+// transactionsLoadedMsg carries a page of transactions. cursor is the one
+// the page was requested with, so an empty cursor means a fresh list.
 type transactionsLoadedMsg struct {
 	transactions []Transaction
+	nextCursor   string
+	cursor       string
 	err          error
 }
 
-func loadTransactionsCmd(api TransactionsAPI) tea.Cmd {
+func loadTransactionsCmd(api TransactionsAPI, filter TransactionFilter, cursor string) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.TODO()
-		transactions, err := api.ListTransactions(ctx)
+		page, err := api.ListTransactions(ctx, filter, cursor)
 		return transactionsLoadedMsg{
-			transactions: transactions,
+			transactions: page.Transactions,
+			nextCursor:   page.NextCursor,
+			cursor:       cursor,
 			err:          err,
 		}
 	}
 }
 
+type transactionsMoreRequestedMsg struct{}
+
+type transactionsFilterRequestedMsg struct{}
+
+type transactionsFilterSubmittedMsg struct {
+	Search     string
+	StartDate  string
+	EndDate    string
+	MinAmount  string
+	MaxAmount  string
+	AccountID  uuid.UUID
+	CategoryID uuid.UUID
+	GroupID    uuid.UUID
+	TagID      uuid.UUID
+	Posted     *bool
+}
+
+func submitTransactionsFilterMsg(search, startDate, endDate, minAmount, maxAmount string, accountID, categoryID, groupID, tagID uuid.UUID, posted *bool) tea.Cmd {
+	return func() tea.Msg {
+		return transactionsFilterSubmittedMsg{
+			Search:     search,
+			StartDate:  startDate,
+			EndDate:    endDate,
+			MinAmount:  minAmount,
+			MaxAmount:  maxAmount,
+			AccountID:  accountID,
+			CategoryID: categoryID,
+			GroupID:    groupID,
+			TagID:      tagID,
+			Posted:     posted,
+		}
+	}
+}
+
+func parseTransactionFilter(msg transactionsFilterSubmittedMsg) (TransactionFilter, error) {
+	filter := TransactionFilter{
+		AccountID:  msg.AccountID,
+		CategoryID: msg.CategoryID,
+		GroupID:    msg.GroupID,
+		TagID:      msg.TagID,
+		Posted:     msg.Posted,
+		Search:     strings.TrimSpace(msg.Search),
+	}
+
+	if msg.StartDate != "" {
+		startDate, err := time.Parse(time.DateOnly, msg.StartDate)
+		if err != nil {
+			return TransactionFilter{}, fmt.Errorf("invalid start date: %w", err)
+		}
+		filter.StartDate = startDate
+	}
+	if msg.EndDate != "" {
+		endDate, err := time.Parse(time.DateOnly, msg.EndDate)
+		if err != nil {
+			return TransactionFilter{}, fmt.Errorf("invalid end date: %w", err)
+		}
+		filter.EndDate = endDate
+	}
+	if !filter.StartDate.IsZero() && !filter.EndDate.IsZero() && filter.EndDate.Before(filter.StartDate) {
+		return TransactionFilter{}, errors.New("end date is before start date")
+	}
+
+	if msg.MinAmount != "" {
+		minAmount, err := decimal.NewFromString(msg.MinAmount)
+		if err != nil {
+			return TransactionFilter{}, fmt.Errorf("invalid min amount: %w", err)
+		}
+		filter.MinAmount = decimal.NewNullDecimal(minAmount)
+	}
+	if msg.MaxAmount != "" {
+		maxAmount, err := decimal.NewFromString(msg.MaxAmount)
+		if err != nil {
+			return TransactionFilter{}, fmt.Errorf("invalid max amount: %w", err)
+		}
+		filter.MaxAmount = decimal.NewNullDecimal(maxAmount)
+	}
+	if filter.MinAmount.Valid && filter.MaxAmount.Valid && filter.MinAmount.Decimal.GreaterThan(filter.MaxAmount.Decimal) {
+		return TransactionFilter{}, errors.New("min amount is greater than max amount")
+	}
+
+	return filter, nil
+}
+
 type transactionsNewRequestedMsg struct{}
 type transactionsEditRequestedMsg struct{}
 
 type CreateTransactionRequest struct {
 	Amount        decimal.Decimal `json:"amount"`
 	TxDescription string          `json:"tx_description"`
+	Memo          string          `json:"memo"`
 	TxDate        time.Time       `json:"tx_date"`
 	Posted        bool            `json:"posted"`
 	AccountID     uuid.UUID       `json:"account_id"`
 	CategoryID    uuid.UUID       `json:"category_id"`
+	PayeeName     string          `json:"payee_name"`
+	Splits        []SplitRequest  `json:"splits"`
+	TagIDs        []uuid.UUID     `json:"tag_ids"`
+}
+
+type SplitRequest struct {
+	CategoryID uuid.UUID       `json:"category_id"`
+	Amount     decimal.Decimal `json:"amount"`
+	Memo       string          `json:"memo"`
+}
+
+type splitSubmission struct {
+	CategoryID uuid.UUID
+	AmountText string
+	Memo       string
+}
+
+// parseSplitSubmissions always returns a non-nil slice, since the server
+// reads a missing splits field as "keep the current splits".
+func parseSplitSubmissions(submissions []splitSubmission) ([]SplitRequest, error) {
+	splits := []SplitRequest{}
+	for i, submission := range submissions {
+		amount, err := decimal.NewFromString(submission.AmountText)
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount on split line %d: %w", i+1, err)
+		}
+		splits = append(splits, SplitRequest{
+			CategoryID: submission.CategoryID,
+			Amount:     amount,
+			Memo:       submission.Memo,
+		})
+	}
+	return splits, nil
 }
 
 func (t *transactionsClient) CreateTransaction(ctx context.Context, req CreateTransactionRequest) (Transaction, error) {
@@ -117,21 +332,29 @@ func createTransactionCmd(api TransactionsAPI, req CreateTransactionRequest) tea
 type transactionCreateSubmittedMsg struct {
 	AmountText    string
 	TxDescription string
+	Memo          string
+	PayeeName     string
 	TxDate        string
 	Posted        bool
 	AccountID     string
 	CategoryID    string
+	Splits        []splitSubmission
+	TagIDs        []uuid.UUID
 }
 
-func submitCreateTransactionMsg(amountText, txDescription, txDate, accountID, categoryID string, posted bool) tea.Cmd {
+func submitCreateTransactionMsg(amountText, txDescription, memo, payeeName, txDate, accountID, categoryID string, posted bool, splits []splitSubmission, tagIDs []uuid.UUID) tea.Cmd {
 	return func() tea.Msg {
 		return transactionCreateSubmittedMsg{
 			AmountText:    amountText,
 			TxDescription: txDescription,
+			Memo:          memo,
+			PayeeName:     payeeName,
 			TxDate:        txDate,
 			Posted:        posted,
 			AccountID:     accountID,
 			CategoryID:    categoryID,
+			Splits:        splits,
+			TagIDs:        tagIDs,
 		}
 	}
 }
@@ -139,10 +362,14 @@ func submitCreateTransactionMsg(amountText, txDescription, txDate, accountID, ca
 type UpdateTransactionRequest struct {
 	Amount        decimal.Decimal `json:"amount"`
 	TxDescription string          `json:"tx_description"`
+	Memo          string          `json:"memo"`
 	TxDate        time.Time       `json:"tx_date"`
 	Posted        bool            `json:"posted"`
 	AccountID     uuid.UUID       `json:"account_id"`
 	CategoryID    uuid.UUID       `json:"category_id"`
+	PayeeName     string          `json:"payee_name"`
+	Splits        []SplitRequest  `json:"splits"`
+	TagIDs        []uuid.UUID     `json:"tag_ids"`
 }
 
 func (t *transactionsClient) UpdateTransaction(ctx context.Context, id uuid.UUID, req UpdateTransactionRequest) (Transaction, error) {
@@ -189,22 +416,30 @@ type transactionUpdateSubmittedMsg struct {
 	TransactionID uuid.UUID
 	Amount        string
 	Description   string
+	Memo          string
+	PayeeName     string
 	Date          string
 	Posted        bool
 	AccountID     uuid.UUID
 	CategoryID    uuid.UUID
+	Splits        []splitSubmission
+	TagIDs        []uuid.UUID
 }
 
-func submitUpdateTransactionMsg(id uuid.UUID, amount, description, date string, posted bool, accountID, categoryID uuid.UUID) tea.Cmd {
+func submitUpdateTransactionMsg(id uuid.UUID, amount, description, memo, payeeName, date string, posted bool, accountID, categoryID uuid.UUID, splits []splitSubmission, tagIDs []uuid.UUID) tea.Cmd {
 	return func() tea.Msg {
 		return transactionUpdateSubmittedMsg{
 			TransactionID: id,
 			Amount:        amount,
 			Description:   description,
+			Memo:          memo,
+			PayeeName:     payeeName,
 			Date:          date,
 			Posted:        posted,
 			AccountID:     accountID,
 			CategoryID:    categoryID,
+			Splits:        splits,
+			TagIDs:        tagIDs,
 		}
 	}
 }
@@ -256,4 +491,83 @@ func submitDeleteTransactionMsg(id uuid.UUID) tea.Cmd {
 	}
 }
 
+type transactionsTransferRequestedMsg struct{}
+
+type Transfer struct {
+	TransferID uuid.UUID   `json:"transfer_id"`
+	From       Transaction `json:"from"`
+	To         Transaction `json:"to"`
+}
+
+type CreateTransferRequest struct {
+	FromAccountID uuid.UUID       `json:"from_account_id"`
+	ToAccountID   uuid.UUID       `json:"to_account_id"`
+	Amount        decimal.Decimal `json:"amount"`
+	TxDescription string          `json:"tx_description"`
+	TxDate        time.Time       `json:"tx_date"`
+	Posted        bool            `json:"posted"`
+}
+
+func (t *transactionsClient) CreateTransfer(ctx context.Context, req CreateTransferRequest) (Transfer, error) {
+	httpReq, err := t.client.newJSONRequest(ctx, http.MethodPost, "/transfers", req)
+	if err != nil {
+		return Transfer{}, err
+	}
+
+	res, err := t.client.httpClient.Do(httpReq)
+	if err != nil {
+		return Transfer{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated && res.StatusCode != http.StatusOK {
+		return Transfer{}, fmt.Errorf("Failed creating transfer: %s", res.Status)
+	}
+
+	var transfer Transfer
+	if err := json.NewDecoder(res.Body).Decode(&transfer); err != nil {
+		return Transfer{}, err
+	}
+
+	return transfer, nil
+}
+
+type transferCreatedMsg struct {
+	transfer Transfer
+	err      error
+}
+
+func createTransferCmd(api TransactionsAPI, req CreateTransferRequest) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		transfer, err := api.CreateTransfer(ctx, req)
+		return transferCreatedMsg{
+			transfer: transfer,
+			err:      err,
+		}
+	}
+}
+
+type transferCreateSubmittedMsg struct {
+	FromAccountID uuid.UUID
+	ToAccountID   uuid.UUID
+	AmountText    string
+	TxDescription string
+	TxDate        string
+	Posted        bool
+}
+
+func submitCreateTransferMsg(fromAccountID, toAccountID uuid.UUID, amountText, txDescription, txDate string, posted bool) tea.Cmd {
+	return func() tea.Msg {
+		return transferCreateSubmittedMsg{
+			FromAccountID: fromAccountID,
+			ToAccountID:   toAccountID,
+			AmountText:    amountText,
+			TxDescription: txDescription,
+			TxDate:        txDate,
+			Posted:        posted,
+		}
+	}
+}
+
 type transactionsReloadRequestedMsg struct{}
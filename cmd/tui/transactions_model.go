@@ -2,8 +2,10 @@ package main
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -19,35 +21,126 @@ const (
 	transactionsModeFormNew
 	transactionsModeFormEdit
 	transactionsModeDelete
+	transactionsModeFormTransfer
+	transactionsModeSplits
+	transactionsModeFilter
+	transactionsModeTags
 )
 
 const (
 	txFormFieldAmount = iota
 	txFormFieldDescription
+	txFormFieldMemo
+	txFormFieldPayee
 	txFormFieldDate
 	txFormFieldPosted
 	txFormFieldAccount
 	txFormFieldCategory
+	txFormFieldSplits
+	txFormFieldTags
 	txFormFieldSave
 )
 
+const (
+	splitFieldCategory = iota
+	splitFieldAmount
+	splitFieldMemo
+	splitFieldCount
+)
+
+const (
+	transferFormFieldFrom = iota
+	transferFormFieldTo
+	transferFormFieldAmount
+	transferFormFieldDescription
+	transferFormFieldDate
+	transferFormFieldPosted
+	transferFormFieldSave
+)
+
+const (
+	filterFieldSearch = iota
+	filterFieldStart
+	filterFieldEnd
+	filterFieldMinAmount
+	filterFieldMaxAmount
+	filterFieldAccount
+	filterFieldCategory
+	filterFieldGroup
+	filterFieldTag
+	filterFieldPosted
+	filterFieldApply
+	filterFieldClear
+)
+
 const (
 	txConfirmYes = iota
 	txConfirmCancel
 )
 
 type Transaction struct {
-	ID            uuid.UUID       `json:"id"`
-	Amount        decimal.Decimal `json:"amount"`
-	TxDescription string          `json:"tx_description"`
-	TxDate        time.Time       `json:"tx_date"`
-	CreatedAt     time.Time       `json:"created_at"`
-	UpdatedAt     time.Time       `json:"updated_at"`
-	Posted        bool            `json:"posted"`
-	AccountID     uuid.UUID       `json:"account_id"`
-	AccountName   string          `json:"account_name"`
-	CategoryID    uuid.UUID       `json:"category_id"`
-	CategoryName  string          `json:"category_name"`
+	ID              uuid.UUID          `json:"id"`
+	Amount          decimal.Decimal    `json:"amount"`
+	TxDescription   string             `json:"tx_description"`
+	Memo            string             `json:"memo"`
+	TxDate          time.Time          `json:"tx_date"`
+	CreatedAt       time.Time          `json:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+	Posted          bool               `json:"posted"`
+	AccountID       uuid.UUID          `json:"account_id"`
+	AccountName     string             `json:"account_name"`
+	CategoryID      uuid.UUID          `json:"category_id"`
+	CategoryName    string             `json:"category_name"`
+	TransferID      uuid.UUID          `json:"transfer_id"`
+	PayeeID         uuid.UUID          `json:"payee_id"`
+	PayeeName       string             `json:"payee_name"`
+	Reconciled      bool               `json:"reconciled"`
+	Splits          []TransactionSplit `json:"splits"`
+	Tags            []TransactionTag   `json:"tags"`
+	AttachmentCount int64              `json:"attachment_count"`
+}
+
+type TransactionSplit struct {
+	ID           uuid.UUID       `json:"id"`
+	CategoryID   uuid.UUID       `json:"category_id"`
+	CategoryName string          `json:"category_name"`
+	Amount       decimal.Decimal `json:"amount"`
+	Memo         string          `json:"memo"`
+}
+
+type splitLine struct {
+	categoryIndex int
+	amountInput   textinput.Model
+	memoInput     textinput.Model
+}
+
+func newSplitLine(categoryOptions []txCategoryOption, split TransactionSplit) splitLine {
+	amount := textinput.New()
+	amount.CharLimit = 64
+	amount.Blur()
+
+	memo := textinput.New()
+	memo.CharLimit = 64
+	memo.Blur()
+
+	line := splitLine{
+		categoryIndex: 0,
+		amountInput:   amount,
+		memoInput:     memo,
+	}
+
+	for i, option := range categoryOptions {
+		if option.ID == split.CategoryID {
+			line.categoryIndex = i
+			break
+		}
+	}
+	if !split.Amount.IsZero() {
+		line.amountInput.SetValue(split.Amount.String())
+	}
+	line.memoInput.SetValue(split.Memo)
+
+	return line
 }
 
 type txAccountOption struct {
@@ -60,26 +153,78 @@ type txCategoryOption struct {
 	Name string
 }
 
+type txGroupOption struct {
+	ID   uuid.UUID
+	Name string
+}
+
+type txTagOption struct {
+	ID   uuid.UUID
+	Name string
+}
+
 var postedValues = []bool{
 	true,
 	false,
 }
 
+// filterPostedValues are the posted filter choices; nil matches both.
+var filterPostedValues = []*bool{
+	nil,
+	&postedValues[0],
+	&postedValues[1],
+}
+
 type transactionsModel struct {
 	mode         transactionsMode
 	transactions []Transaction
 	cursor       int
+	nextCursor   string
+	loadingMore  bool
 
-	formEditing       bool
-	formFieldCursor   int
-	amountInput       textinput.Model
-	descriptionInput  textinput.Model
-	dateInput         textinput.Model
-	formPostedIndex   int
-	formAccountIndex  int
-	formCategoryIndex int
-	accountOptions    []txAccountOption
-	categoryOptions   []txCategoryOption
+	// accountCurrencies maps account IDs to their currency, for showing
+	// amounts.
+	accountCurrencies map[uuid.UUID]string
+
+	formEditing        bool
+	formFieldCursor    int
+	amountInput        textinput.Model
+	descriptionInput   textinput.Model
+	memoInput          textinput.Model
+	payeeInput         textinput.Model
+	dateInput          textinput.Model
+	formPostedIndex    int
+	formAccountIndex   int
+	formCategoryIndex  int
+	formToAccountIndex int
+	splitLines         []splitLine
+	splitCursor        int
+	splitReturnMode    transactionsMode
+	accountOptions     []txAccountOption
+	categoryOptions    []txCategoryOption
+	payees             []Payee
+	tags               []Tag
+	formTagIDs         map[uuid.UUID]bool
+	tagCursor          int
+	tagReturnMode      transactionsMode
+	tagNameInput       textinput.Model
+
+	filter                TransactionFilter
+	filterFieldCursor     int
+	searchInput           textinput.Model
+	filterStartInput      textinput.Model
+	filterEndInput        textinput.Model
+	filterMinInput        textinput.Model
+	filterMaxInput        textinput.Model
+	filterAccountIndex    int
+	filterCategoryIndex   int
+	filterGroupIndex      int
+	filterTagIndex        int
+	filterPostedIndex     int
+	filterAccountOptions  []txAccountOption
+	filterCategoryOptions []txCategoryOption
+	filterGroupOptions    []txGroupOption
+	filterTagOptions      []txTagOption
 
 	confirmCursor int
 	errorMsg      string
@@ -94,10 +239,33 @@ func initialTransactionsModel() transactionsModel {
 	txDescription.CharLimit = 64
 	txDescription.Blur()
 
+	txMemo := textinput.New()
+	txMemo.CharLimit = 256
+	txMemo.Blur()
+
+	// The app uses tab to switch focus, so suggestions are accepted with
+	// the right arrow instead.
+	txPayee := textinput.New()
+	txPayee.CharLimit = 64
+	txPayee.ShowSuggestions = true
+	txPayee.KeyMap.AcceptSuggestion = key.NewBinding(key.WithKeys("right"))
+	txPayee.Blur()
+
 	txDate := textinput.New()
 	txDate.CharLimit = 64
 	txDate.Blur()
 
+	tagName := textinput.New()
+	tagName.CharLimit = 64
+	tagName.Blur()
+
+	newFilterInput := func() textinput.Model {
+		input := textinput.New()
+		input.CharLimit = 64
+		input.Blur()
+		return input
+	}
+
 	return transactionsModel{
 		transactions:      []Transaction{},
 		cursor:            0,
@@ -105,18 +273,69 @@ func initialTransactionsModel() transactionsModel {
 		formFieldCursor:   txFormFieldAmount,
 		amountInput:       txAmount,
 		descriptionInput:  txDescription,
+		memoInput:         txMemo,
+		payeeInput:        txPayee,
 		dateInput:         txDate,
+		tagNameInput:      tagName,
+		formTagIDs:        map[uuid.UUID]bool{},
 		formPostedIndex:   0,
 		formAccountIndex:  0,
 		formCategoryIndex: 0,
 		accountOptions:    []txAccountOption{},
 		categoryOptions:   []txCategoryOption{},
 		confirmCursor:     txConfirmCancel,
+		searchInput:       newFilterInput(),
+		filterStartInput:  newFilterInput(),
+		filterEndInput:    newFilterInput(),
+		filterMinInput:    newFilterInput(),
+		filterMaxInput:    newFilterInput(),
 	}
 }
 
 func (m transactionsModel) Update(msg tea.Msg) (transactionsModel, tea.Cmd) {
 	switch msg := msg.(type) {
+	case payeesLoadedMsg:
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.payees = msg.payees
+		names := make([]string, len(msg.payees))
+		for i, payee := range msg.payees {
+			names[i] = payee.PayeeName
+		}
+		m.payeeInput.SetSuggestions(names)
+		return m, nil
+
+	case tagsLoadedMsg:
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.tags = msg.tags
+		if m.tagCursor >= len(m.tags) {
+			m.tagCursor = max(len(m.tags)-1, 0)
+		}
+		return m, nil
+
+	case tagCreatedMsg:
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.errorMsg = ""
+		m.tags = append(m.tags, msg.tag)
+		m.formTagIDs[msg.tag.ID] = true
+		m.tagCursor = len(m.tags) - 1
+		return m, nil
+
+	case transactionsLoadedMsg:
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			m.loadingMore = false
+		}
+		return m, nil
+
 	case transactionCreatedMsg:
 		if msg.err != nil {
 			m.errorMsg = msg.err.Error()
@@ -138,17 +357,42 @@ func (m transactionsModel) Update(msg tea.Msg) (transactionsModel, tea.Cmd) {
 			return transactionsReloadRequestedMsg{}
 		}
 
+	case transferCreatedMsg:
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.errorMsg = ""
+		m.mode = transactionsModeList
+		return m, func() tea.Msg {
+			return transactionsReloadRequestedMsg{}
+		}
+
 	case transactionDeletedMsg:
 		if msg.err != nil {
 			m.errorMsg = msg.err.Error()
 			return m, nil
 		}
 
+		// Deleting one side of a transfer deletes the other side too, so
+		// drop every row that shares its transfer.
+		transferID := uuid.Nil
+		for _, transaction := range m.transactions {
+			if transaction.ID == msg.transactionID {
+				transferID = transaction.TransferID
+				break
+			}
+		}
+
 		filtered := m.transactions[:0]
 		for _, transaction := range m.transactions {
-			if transaction.ID != msg.transactionID {
-				filtered = append(filtered, transaction)
+			if transaction.ID == msg.transactionID {
+				continue
+			}
+			if transferID != uuid.Nil && transaction.TransferID == transferID {
+				continue
 			}
+			filtered = append(filtered, transaction)
 		}
 		m.transactions = filtered
 
@@ -180,17 +424,122 @@ func (m transactionsModel) Update(msg tea.Msg) (transactionsModel, tea.Cmd) {
 				if m.cursor < len(m.transactions)-1 {
 					m.cursor++
 				}
+				cmd := m.loadMore()
+				return m, cmd
 			case "n":
 				return m, func() tea.Msg {
 					return transactionsNewRequestedMsg{}
 				}
+			case "t":
+				return m, func() tea.Msg {
+					return transactionsTransferRequestedMsg{}
+				}
 			case "d":
 				if len(m.transactions) > 0 {
 					m.mode = transactionsModeDelete
 					m.confirmCursor = txConfirmCancel
 				}
+			case "/":
+				return m, func() tea.Msg {
+					return transactionsFilterRequestedMsg{}
+				}
+			case "c":
+				if !m.filter.IsZero() {
+					m.clearFilterInputs()
+					return m, m.submitFilter()
+				}
 			case "enter":
-				m.mode = transactionsModeDetails
+				if len(m.transactions) > 0 {
+					m.mode = transactionsModeDetails
+				}
+			}
+		case transactionsModeFilter:
+			if m.formEditing {
+				if key == "esc" || key == "enter" {
+					m.formEditing = false
+					m.blurFilterInputs()
+					return m, nil
+				}
+
+				input := m.filterInput(m.filterFieldCursor)
+				if input != nil {
+					var cmd tea.Cmd
+					*input, cmd = input.Update(msg)
+					return m, cmd
+				}
+			}
+
+			switch key {
+			case "esc":
+				m.mode = transactionsModeList
+				m.errorMsg = ""
+			case "up", "k":
+				if m.filterFieldCursor > filterFieldSearch {
+					m.filterFieldCursor--
+				}
+			case "down", "j":
+				if m.filterFieldCursor < filterFieldClear {
+					m.filterFieldCursor++
+				}
+			case "left", "h":
+				switch m.filterFieldCursor {
+				case filterFieldAccount:
+					if m.filterAccountIndex > 0 {
+						m.filterAccountIndex--
+					}
+				case filterFieldCategory:
+					if m.filterCategoryIndex > 0 {
+						m.filterCategoryIndex--
+					}
+				case filterFieldGroup:
+					if m.filterGroupIndex > 0 {
+						m.filterGroupIndex--
+					}
+				case filterFieldTag:
+					if m.filterTagIndex > 0 {
+						m.filterTagIndex--
+					}
+				case filterFieldPosted:
+					if m.filterPostedIndex > 0 {
+						m.filterPostedIndex--
+					}
+				}
+			case "right", "l":
+				switch m.filterFieldCursor {
+				case filterFieldAccount:
+					if m.filterAccountIndex < len(m.filterAccountOptions)-1 {
+						m.filterAccountIndex++
+					}
+				case filterFieldCategory:
+					if m.filterCategoryIndex < len(m.filterCategoryOptions)-1 {
+						m.filterCategoryIndex++
+					}
+				case filterFieldGroup:
+					if m.filterGroupIndex < len(m.filterGroupOptions)-1 {
+						m.filterGroupIndex++
+					}
+				case filterFieldTag:
+					if m.filterTagIndex < len(m.filterTagOptions)-1 {
+						m.filterTagIndex++
+					}
+				case filterFieldPosted:
+					if m.filterPostedIndex < len(filterPostedValues)-1 {
+						m.filterPostedIndex++
+					}
+				}
+			case "enter":
+				switch m.filterFieldCursor {
+				case filterFieldApply:
+					return m, m.submitFilter()
+				case filterFieldClear:
+					m.clearFilterInputs()
+					return m, m.submitFilter()
+				default:
+					if input := m.filterInput(m.filterFieldCursor); input != nil {
+						m.formEditing = true
+						input.Focus()
+					}
+				}
 			}
 		case transactionsModeDetails:
 			switch key {
@@ -206,11 +555,16 @@ func (m transactionsModel) Update(msg tea.Msg) (transactionsModel, tea.Cmd) {
 			}
 		case transactionsModeFormNew, transactionsModeFormEdit:
 			if m.formEditing {
-				if key == "esc" {
+				if key == "esc" || (key == "enter" && m.formFieldCursor == txFormFieldPayee) {
 					m.formEditing = false
 					m.amountInput.Blur()
 					m.descriptionInput.Blur()
+					m.memoInput.Blur()
+					m.payeeInput.Blur()
 					m.dateInput.Blur()
+					if m.formFieldCursor == txFormFieldPayee {
+						m.applyPayeeDefaults()
+					}
 					return m, nil
 				}
 
@@ -223,6 +577,14 @@ func (m transactionsModel) Update(msg tea.Msg) (transactionsModel, tea.Cmd) {
 					var cmd tea.Cmd
 					m.descriptionInput, cmd = m.descriptionInput.Update(msg)
 					return m, cmd
+				case txFormFieldMemo:
+					var cmd tea.Cmd
+					m.memoInput, cmd = m.memoInput.Update(msg)
+					return m, cmd
+				case txFormFieldPayee:
+					var cmd tea.Cmd
+					m.payeeInput, cmd = m.payeeInput.Update(msg)
+					return m, cmd
 				case txFormFieldDate:
 					var cmd tea.Cmd
 					m.dateInput, cmd = m.dateInput.Update(msg)
@@ -245,10 +607,12 @@ func (m transactionsModel) Update(msg tea.Msg) (transactionsModel, tea.Cmd) {
 				}
 			case "enter":
 				switch m.formFieldCursor {
-				case txFormFieldAmount, txFormFieldDescription, txFormFieldDate:
+				case txFormFieldAmount, txFormFieldDescription, txFormFieldMemo, txFormFieldPayee, txFormFieldDate:
 					m.formEditing = true
 					m.amountInput.Blur()
 					m.descriptionInput.Blur()
+					m.memoInput.Blur()
+					m.payeeInput.Blur()
 					m.dateInput.Blur()
 
 					switch m.formFieldCursor {
@@ -256,6 +620,10 @@ func (m transactionsModel) Update(msg tea.Msg) (transactionsModel, tea.Cmd) {
 						m.amountInput.Focus()
 					case txFormFieldDescription:
 						m.descriptionInput.Focus()
+					case txFormFieldMemo:
+						m.memoInput.Focus()
+					case txFormFieldPayee:
+						m.payeeInput.Focus()
 					case txFormFieldDate:
 						m.dateInput.Focus()
 					}
@@ -267,17 +635,39 @@ func (m transactionsModel) Update(msg tea.Msg) (transactionsModel, tea.Cmd) {
 						date := m.dateInput.Value()
 						account := m.accountOptions[m.formAccountIndex].ID
 						category := m.categoryOptions[m.formCategoryIndex].ID
+						payee := m.payeeInput.Value()
 						posted := postedValues[m.formPostedIndex]
-						return m, submitCreateTransactionMsg(amount, description, date, account.String(), category.String(), posted)
+						memo := m.memoInput.Value()
+						return m, submitCreateTransactionMsg(amount, description, memo, payee, date, account.String(), category.String(), posted, m.splitSubmissions(), m.selectedTagIDs())
 					case transactionsModeFormEdit:
 						amount := m.amountInput.Value()
 						description := m.descriptionInput.Value()
 						date := m.dateInput.Value()
 						account := m.accountOptions[m.formAccountIndex].ID
 						category := m.categoryOptions[m.formCategoryIndex].ID
+						payee := m.payeeInput.Value()
 						posted := postedValues[m.formPostedIndex]
-						return m, submitUpdateTransactionMsg(m.transactions[m.cursor].ID, amount, description, date, posted, account, category)
+						memo := m.memoInput.Value()
+						return m, submitUpdateTransactionMsg(m.transactions[m.cursor].ID, amount, description, memo, payee, date, posted, account, category, m.splitSubmissions(), m.selectedTagIDs())
 					}
+				case txFormFieldSplits:
+					if len(m.categoryOptions) == 0 {
+						return m, nil
+					}
+					m.splitReturnMode = m.mode
+					m.mode = transactionsModeSplits
+					m.splitCursor = 0
+					if len(m.splitLines) == 0 {
+						first := newSplitLine(m.categoryOptions, TransactionSplit{})
+						first.categoryIndex = m.formCategoryIndex
+						first.amountInput.SetValue(m.amountInput.Value())
+						m.splitLines = []splitLine{first, newSplitLine(m.categoryOptions, TransactionSplit{})}
+					}
+				case txFormFieldTags:
+					m.tagReturnMode = m.mode
+					m.mode = transactionsModeTags
+					m.tagCursor = 0
+					m.errorMsg = ""
 				}
 			default:
 				switch m.formFieldCursor {
@@ -289,6 +679,14 @@ func (m transactionsModel) Update(msg tea.Msg) (transactionsModel, tea.Cmd) {
 					var cmd tea.Cmd
 					m.descriptionInput, cmd = m.descriptionInput.Update(msg)
 					return m, cmd
+				case txFormFieldMemo:
+					var cmd tea.Cmd
+					m.memoInput, cmd = m.memoInput.Update(msg)
+					return m, cmd
+				case txFormFieldPayee:
+					var cmd tea.Cmd
+					m.payeeInput, cmd = m.payeeInput.Update(msg)
+					return m, cmd
 				case txFormFieldDate:
 					var cmd tea.Cmd
 					m.dateInput, cmd = m.dateInput.Update(msg)
@@ -328,6 +726,227 @@ func (m transactionsModel) Update(msg tea.Msg) (transactionsModel, tea.Cmd) {
 					}
 				}
 			}
+		case transactionsModeSplits:
+			line := m.splitCursor / splitFieldCount
+			field := m.splitCursor % splitFieldCount
+
+			if m.formEditing {
+				if key == "esc" || key == "enter" {
+					m.formEditing = false
+					m.splitLines[line].amountInput.Blur()
+					m.splitLines[line].memoInput.Blur()
+					return m, nil
+				}
+
+				var cmd tea.Cmd
+				switch field {
+				case splitFieldAmount:
+					m.splitLines[line].amountInput, cmd = m.splitLines[line].amountInput.Update(msg)
+				case splitFieldMemo:
+					m.splitLines[line].memoInput, cmd = m.splitLines[line].memoInput.Update(msg)
+				}
+				return m, cmd
+			}
+
+			switch key {
+			case "esc":
+				m.mode = m.splitReturnMode
+			case "up", "k":
+				if m.splitCursor > 0 {
+					m.splitCursor--
+				}
+			case "down", "j":
+				if m.splitCursor < len(m.splitLines)*splitFieldCount-1 {
+					m.splitCursor++
+				}
+			case "left", "h":
+				if field == splitFieldCategory && m.splitLines[line].categoryIndex > 0 {
+					m.splitLines[line].categoryIndex--
+				}
+			case "right", "l":
+				if field == splitFieldCategory && m.splitLines[line].categoryIndex < len(m.categoryOptions)-1 {
+					m.splitLines[line].categoryIndex++
+				}
+			case "enter":
+				switch field {
+				case splitFieldAmount:
+					m.formEditing = true
+					m.splitLines[line].amountInput.Focus()
+				case splitFieldMemo:
+					m.formEditing = true
+					m.splitLines[line].memoInput.Focus()
+				}
+			case "a":
+				m.splitLines = append(m.splitLines, newSplitLine(m.categoryOptions, TransactionSplit{}))
+				m.splitCursor = (len(m.splitLines) - 1) * splitFieldCount
+			case "x":
+				m.splitLines = append(m.splitLines[:line], m.splitLines[line+1:]...)
+				if len(m.splitLines) == 0 {
+					m.mode = m.splitReturnMode
+					m.splitCursor = 0
+				} else if m.splitCursor >= len(m.splitLines)*splitFieldCount {
+					m.splitCursor = (len(m.splitLines) - 1) * splitFieldCount
+				}
+			}
+		case transactionsModeTags:
+			if m.formEditing {
+				switch key {
+				case "esc":
+					m.formEditing = false
+					m.tagNameInput.Blur()
+					m.tagNameInput.SetValue("")
+					return m, nil
+				case "enter":
+					name := strings.TrimSpace(m.tagNameInput.Value())
+					m.formEditing = false
+					m.tagNameInput.Blur()
+					m.tagNameInput.SetValue("")
+					if name == "" {
+						return m, nil
+					}
+					// Pick the existing tag rather than asking the server to
+					// create a duplicate.
+					for i, tag := range m.tags {
+						if strings.EqualFold(tag.TagName, name) {
+							m.formTagIDs[tag.ID] = true
+							m.tagCursor = i
+							return m, nil
+						}
+					}
+					return m, submitCreateTagMsg(name)
+				}
+
+				var cmd tea.Cmd
+				m.tagNameInput, cmd = m.tagNameInput.Update(msg)
+				return m, cmd
+			}
+
+			switch key {
+			case "esc":
+				m.mode = m.tagReturnMode
+				m.errorMsg = ""
+			case "up", "k":
+				if m.tagCursor > 0 {
+					m.tagCursor--
+				}
+			case "down", "j":
+				if m.tagCursor < len(m.tags)-1 {
+					m.tagCursor++
+				}
+			case "enter", " ":
+				if len(m.tags) > 0 {
+					id := m.tags[m.tagCursor].ID
+					if m.formTagIDs[id] {
+						delete(m.formTagIDs, id)
+					} else {
+						m.formTagIDs[id] = true
+					}
+				}
+			case "a":
+				m.formEditing = true
+				m.tagNameInput.Focus()
+			}
+		case transactionsModeFormTransfer:
+			if m.formEditing {
+				if key == "esc" {
+					m.formEditing = false
+					m.amountInput.Blur()
+					m.descriptionInput.Blur()
+					m.dateInput.Blur()
+					return m, nil
+				}
+
+				switch m.formFieldCursor {
+				case transferFormFieldAmount:
+					var cmd tea.Cmd
+					m.amountInput, cmd = m.amountInput.Update(msg)
+					return m, cmd
+				case transferFormFieldDescription:
+					var cmd tea.Cmd
+					m.descriptionInput, cmd = m.descriptionInput.Update(msg)
+					return m, cmd
+				case transferFormFieldDate:
+					var cmd tea.Cmd
+					m.dateInput, cmd = m.dateInput.Update(msg)
+					return m, cmd
+				}
+			}
+
+			switch key {
+			case "esc":
+				m.mode = transactionsModeList
+				m.errorMsg = ""
+				return m, nil
+			case "up", "k":
+				if m.formFieldCursor > transferFormFieldFrom {
+					m.formFieldCursor--
+				}
+			case "down", "j":
+				if m.formFieldCursor < transferFormFieldSave {
+					m.formFieldCursor++
+				}
+			case "enter":
+				switch m.formFieldCursor {
+				case transferFormFieldAmount, transferFormFieldDescription, transferFormFieldDate:
+					m.formEditing = true
+					m.amountInput.Blur()
+					m.descriptionInput.Blur()
+					m.dateInput.Blur()
+
+					switch m.formFieldCursor {
+					case transferFormFieldAmount:
+						m.amountInput.Focus()
+					case transferFormFieldDescription:
+						m.descriptionInput.Focus()
+					case transferFormFieldDate:
+						m.dateInput.Focus()
+					}
+				case transferFormFieldSave:
+					from := m.accountOptions[m.formAccountIndex].ID
+					to := m.accountOptions[m.formToAccountIndex].ID
+					amount := m.amountInput.Value()
+					description := m.descriptionInput.Value()
+					date := m.dateInput.Value()
+					posted := postedValues[m.formPostedIndex]
+					return m, submitCreateTransferMsg(from, to, amount, description, date, posted)
+				}
+			default:
+				switch m.formFieldCursor {
+				case transferFormFieldFrom:
+					if key == "left" || key == "h" {
+						if m.formAccountIndex > 0 {
+							m.formAccountIndex--
+						}
+					}
+					if key == "right" || key == "l" {
+						if m.formAccountIndex < len(m.accountOptions)-1 {
+							m.formAccountIndex++
+						}
+					}
+				case transferFormFieldTo:
+					if key == "left" || key == "h" {
+						if m.formToAccountIndex > 0 {
+							m.formToAccountIndex--
+						}
+					}
+					if key == "right" || key == "l" {
+						if m.formToAccountIndex < len(m.accountOptions)-1 {
+							m.formToAccountIndex++
+						}
+					}
+				case transferFormFieldPosted:
+					if key == "left" || key == "h" {
+						if m.formPostedIndex > 0 {
+							m.formPostedIndex--
+						}
+					}
+					if key == "right" || key == "l" {
+						if m.formPostedIndex < len(postedValues)-1 {
+							m.formPostedIndex++
+						}
+					}
+				}
+			}
 		case transactionsModeDelete:
 			switch key {
 			case "esc":
@@ -357,25 +976,67 @@ func (m transactionsModel) View() string {
 	switch m.mode {
 	case transactionsModeList:
 		s := "Transactions\n\n"
+		s += m.errorView()
+		if !m.filter.IsZero() {
+			s += fmt.Sprintf("Filter: %s\n\n", m.filterSummary())
+		}
+		if len(m.transactions) == 0 {
+			s += "No transactions found.\n"
+		}
 		for i, transaction := range m.transactions {
 			cursor := " "
 			if m.cursor == i {
 				cursor = ">"
 			}
 			dateStr := transaction.TxDate.Format("2006-01-02")
-			s += fmt.Sprintf("%s %s | %s | %s\n", cursor, dateStr, transaction.TxDescription, transaction.Amount)
+			transferTag := ""
+			if transaction.TransferID != uuid.Nil {
+				transferTag = " [Transfer]"
+			}
+			description := transaction.TxDescription
+			if transaction.PayeeName != "" {
+				description = transaction.PayeeName + " - " + description
+			}
+			s += fmt.Sprintf("%s %s | %s | %s%s%s\n", cursor, dateStr, description, m.money(transaction, transaction.Amount), transferTag, tagList(transaction.Tags))
+		}
+		if m.loadingMore {
+			s += "  Loading more...\n"
+		} else if m.nextCursor != "" {
+			s += "  More transactions below\n"
 		}
-		s += "\n(Use 'j'/'k' to move, 'enter' to view details, 'n' to create a new transaction, 'd' to delete transaction)\n"
+		s += "\n(Use 'j'/'k' to move, 'enter' to view details, 'n' to create a new transaction, 't' to transfer between accounts, 'd' to delete transaction, '/' to filter, 'c' to clear the filter)\n"
 		return s
 	case transactionsModeDetails:
 		tx := m.transactions[m.cursor]
 		s := "Transaction Details\n\n"
-		s += fmt.Sprintf("Amount: %s\n", tx.Amount.String())
+		s += fmt.Sprintf("Amount: %s\n", m.money(tx, tx.Amount))
 		s += fmt.Sprintf("Description: %s\n", tx.TxDescription)
+		if tx.Memo != "" {
+			s += fmt.Sprintf("Memo: %s\n", tx.Memo)
+		}
+		if tx.PayeeName != "" {
+			s += fmt.Sprintf("Payee: %s\n", tx.PayeeName)
+		}
 		s += fmt.Sprintf("Date: %s\n", tx.TxDate.Format("2006-01-02"))
 		s += fmt.Sprintf("Posted: %v\n", tx.Posted)
 		s += fmt.Sprintf("Account: %s\n", tx.AccountName)
-		s += fmt.Sprintf("Category: %s\n\n", tx.CategoryName)
+		if tx.TransferID != uuid.Nil {
+			s += "Category: Transfer (edits and deletes apply to both accounts)\n\n"
+		} else if len(tx.Splits) > 0 {
+			s += "Category: (split)\n"
+			for _, split := range tx.Splits {
+				s += fmt.Sprintf("  %s | %s | %s\n", split.CategoryName, m.money(tx, split.Amount), split.Memo)
+			}
+			s += "\n"
+		} else {
+			s += fmt.Sprintf("Category: %s\n\n", tx.CategoryName)
+		}
+		if len(tx.Tags) > 0 {
+			s += fmt.Sprintf("Tags:%s\n\n", tagList(tx.Tags))
+		}
+		if tx.AttachmentCount > 0 {
+			s += fmt.Sprintf("Attachments: %d\n\n", tx.AttachmentCount)
+		}
 
 		s += "(Press 'esc' to go back, 'e' to edit, 'd' to delete)\n"
 
@@ -397,18 +1058,135 @@ func (m transactionsModel) View() string {
 
 		s += fmt.Sprintf("%s Amount: %s\n", currentRow(txFormFieldAmount), m.amountInput.View())
 		s += fmt.Sprintf("%s Description: %s\n", currentRow(txFormFieldDescription), m.descriptionInput.View())
+		s += fmt.Sprintf("%s Memo: %s\n", currentRow(txFormFieldMemo), m.memoInput.View())
+		s += fmt.Sprintf("%s Payee ('right' to complete): %s\n", currentRow(txFormFieldPayee), m.payeeInput.View())
 		s += fmt.Sprintf("%s Date(YYYY-MM-DD): %s\n", currentRow(txFormFieldDate), m.dateInput.View())
 		s += fmt.Sprintf("%s Posted ('h'/'l' to change): %v\n", currentRow(txFormFieldPosted), postedValues[m.formPostedIndex])
 		s += fmt.Sprintf("%s Account ('h'/'l' to change): %s\n", currentRow(txFormFieldAccount), m.accountOptions[m.formAccountIndex].Name)
-		s += fmt.Sprintf("%s Category ('h'/'l' to change): %s\n\n", currentRow(txFormFieldCategory), m.categoryOptions[m.formCategoryIndex].Name)
+		if len(m.splitLines) > 0 {
+			s += fmt.Sprintf("%s Category: (split)\n", currentRow(txFormFieldCategory))
+		} else {
+			s += fmt.Sprintf("%s Category ('h'/'l' to change): %s\n", currentRow(txFormFieldCategory), m.categoryOptions[m.formCategoryIndex].Name)
+		}
+		s += fmt.Sprintf("%s Splits ('enter' to edit): %s\n", currentRow(txFormFieldSplits), m.splitSummary())
+		s += fmt.Sprintf("%s Tags ('enter' to pick): %s\n\n", currentRow(txFormFieldTags), m.tagSummary())
 
 		s += fmt.Sprintf("%s [ Save ]\n", currentRow(txFormFieldSave))
 		s += "\n(Use 'j'/'k' to move, 'enter' to edit field, 'esc' to stop editing, 'esc' again to cancel)\n"
 
+		return s
+	case transactionsModeSplits:
+		s := "Split Transaction\n\n"
+
+		s += m.errorView()
+
+		s += fmt.Sprintf("Amount: %s | Unassigned: %s\n\n", m.amountInput.Value(), m.unassignedSplitAmount())
+
+		currentRow := func(index int) string {
+			if m.splitCursor == index {
+				return ">"
+			}
+			return " "
+		}
+
+		for i, line := range m.splitLines {
+			row := i * splitFieldCount
+			s += fmt.Sprintf("%s Line %d Category ('h'/'l' to change): %s\n", currentRow(row+splitFieldCategory), i+1, m.categoryOptions[line.categoryIndex].Name)
+			s += fmt.Sprintf("%s Line %d Amount: %s\n", currentRow(row+splitFieldAmount), i+1, line.amountInput.View())
+			s += fmt.Sprintf("%s Line %d Memo: %s\n\n", currentRow(row+splitFieldMemo), i+1, line.memoInput.View())
+		}
+
+		s += "(Use 'j'/'k' to move, 'enter' to edit field, 'a' to add a line, 'x' to remove a line, 'esc' to return to the form)\n"
+
+		return s
+	case transactionsModeTags:
+		s := "Tag Transaction\n\n"
+
+		s += m.errorView()
+
+		if len(m.tags) == 0 {
+			s += "No tags yet.\n"
+		}
+		for i, tag := range m.tags {
+			cursor := " "
+			if m.tagCursor == i {
+				cursor = ">"
+			}
+			checked := "[ ]"
+			if m.formTagIDs[tag.ID] {
+				checked = "[x]"
+			}
+			s += fmt.Sprintf("%s %s %s\n", cursor, checked, tag.TagName)
+		}
+		if m.formEditing {
+			s += fmt.Sprintf("\nNew tag: %s\n", m.tagNameInput.View())
+		}
+
+		s += "\n(Use 'j'/'k' to move, 'space'/'enter' to toggle, 'a' to add a tag, 'esc' to return to the form)\n"
+
+		return s
+	case transactionsModeFormTransfer:
+		s := "New Transfer\n\n"
+
+		s += m.errorView()
+
+		currentRow := func(field int) string {
+			if m.formFieldCursor == field {
+				return ">"
+			}
+			return " "
+		}
+
+		s += fmt.Sprintf("%s From ('h'/'l' to change): %s\n", currentRow(transferFormFieldFrom), m.accountOptions[m.formAccountIndex].Name)
+		s += fmt.Sprintf("%s To ('h'/'l' to change): %s\n", currentRow(transferFormFieldTo), m.accountOptions[m.formToAccountIndex].Name)
+		s += fmt.Sprintf("%s Amount: %s\n", currentRow(transferFormFieldAmount), m.amountInput.View())
+		s += fmt.Sprintf("%s Description: %s\n", currentRow(transferFormFieldDescription), m.descriptionInput.View())
+		s += fmt.Sprintf("%s Date(YYYY-MM-DD): %s\n", currentRow(transferFormFieldDate), m.dateInput.View())
+		s += fmt.Sprintf("%s Posted ('h'/'l' to change): %v\n\n", currentRow(transferFormFieldPosted), postedValues[m.formPostedIndex])
+
+		s += fmt.Sprintf("%s [ Save ]\n", currentRow(transferFormFieldSave))
+		s += "\n(Use 'j'/'k' to move, 'enter' to edit field, 'esc' to stop editing, 'esc' again to cancel)\n"
+
+		return s
+	case transactionsModeFilter:
+		s := "Filter Transactions\n\n"
+
+		s += m.errorView()
+
+		currentRow := func(field int) string {
+			if m.filterFieldCursor == field {
+				return ">"
+			}
+			return " "
+		}
+
+		posted := "Any"
+		if p := filterPostedValues[m.filterPostedIndex]; p != nil {
+			posted = fmt.Sprint(*p)
+		}
+
+		s += fmt.Sprintf("%s Search: %s\n", currentRow(filterFieldSearch), m.searchInput.View())
+		s += fmt.Sprintf("%s From(YYYY-MM-DD): %s\n", currentRow(filterFieldStart), m.filterStartInput.View())
+		s += fmt.Sprintf("%s To(YYYY-MM-DD): %s\n", currentRow(filterFieldEnd), m.filterEndInput.View())
+		s += fmt.Sprintf("%s Min Amount: %s\n", currentRow(filterFieldMinAmount), m.filterMinInput.View())
+		s += fmt.Sprintf("%s Max Amount: %s\n", currentRow(filterFieldMaxAmount), m.filterMaxInput.View())
+		s += fmt.Sprintf("%s Account ('h'/'l' to change): %s\n", currentRow(filterFieldAccount), m.filterAccountOptions[m.filterAccountIndex].Name)
+		s += fmt.Sprintf("%s Category ('h'/'l' to change): %s\n", currentRow(filterFieldCategory), m.filterCategoryOptions[m.filterCategoryIndex].Name)
+		s += fmt.Sprintf("%s Group ('h'/'l' to change): %s\n", currentRow(filterFieldGroup), m.filterGroupOptions[m.filterGroupIndex].Name)
+		s += fmt.Sprintf("%s Tag ('h'/'l' to change): %s\n", currentRow(filterFieldTag), m.filterTagOptions[m.filterTagIndex].Name)
+		s += fmt.Sprintf("%s Posted ('h'/'l' to change): %s\n\n", currentRow(filterFieldPosted), posted)
+
+		s += fmt.Sprintf("%s [ Apply ]\n", currentRow(filterFieldApply))
+		s += fmt.Sprintf("%s [ Clear ]\n", currentRow(filterFieldClear))
+		s += "\n(Amounts keep their sign, so spending is negative. Use 'j'/'k' to move, 'enter' to edit field, 'esc' to stop editing, 'esc' again to cancel)\n"
+
 		return s
 	case transactionsModeDelete:
 		s := "Delete Transaction\n\n"
 		s += fmt.Sprintf("Are you sure you want to delete transaction '%s'?\n", m.transactions[m.cursor].TxDescription)
+		if m.transactions[m.cursor].TransferID != uuid.Nil {
+			s += "This is a transfer; both sides will be deleted.\n"
+		}
 
 		currentRow := func(field int) string {
 			if m.confirmCursor == field {
@@ -435,6 +1213,256 @@ func (m transactionsModel) errorView() string {
 	return fmt.Sprintf("Error: %s\n\n", m.errorMsg)
 }
 
+// applyPayeeDefaults pre-fills the category picker from the chosen payee's
+// default category. Split transactions keep their per-line categories.
+func (m *transactionsModel) applyPayeeDefaults() {
+	if len(m.splitLines) > 0 {
+		return
+	}
+
+	name := strings.TrimSpace(m.payeeInput.Value())
+	for _, payee := range m.payees {
+		if !strings.EqualFold(payee.PayeeName, name) || payee.DefaultCategoryID == uuid.Nil {
+			continue
+		}
+		for i, option := range m.categoryOptions {
+			if option.ID == payee.DefaultCategoryID {
+				m.formCategoryIndex = i
+				return
+			}
+		}
+	}
+}
+
+func (m transactionsModel) splitSubmissions() []splitSubmission {
+	var splits []splitSubmission
+	for _, line := range m.splitLines {
+		splits = append(splits, splitSubmission{
+			CategoryID: m.categoryOptions[line.categoryIndex].ID,
+			AmountText: line.amountInput.Value(),
+			Memo:       line.memoInput.Value(),
+		})
+	}
+	return splits
+}
+
+func (m transactionsModel) splitSummary() string {
+	if len(m.splitLines) == 0 {
+		return "none"
+	}
+	return fmt.Sprintf("%d lines, %s unassigned", len(m.splitLines), m.unassignedSplitAmount())
+}
+
+func (m transactionsModel) unassignedSplitAmount() string {
+	amount, err := decimal.NewFromString(m.amountInput.Value())
+	if err != nil {
+		return "?"
+	}
+	for _, line := range m.splitLines {
+		lineAmount, err := decimal.NewFromString(line.amountInput.Value())
+		if err != nil {
+			continue
+		}
+		amount = amount.Sub(lineAmount)
+	}
+	return amount.String()
+}
+
+// selectedTagIDs lists the picked tags. It is never nil, so saving an edit
+// with no tags picked clears them.
+func (m transactionsModel) selectedTagIDs() []uuid.UUID {
+	tagIDs := []uuid.UUID{}
+	for id, picked := range m.formTagIDs {
+		if picked {
+			tagIDs = append(tagIDs, id)
+		}
+	}
+	return tagIDs
+}
+
+func (m transactionsModel) tagSummary() string {
+	var names []string
+	for _, tag := range m.tags {
+		if m.formTagIDs[tag.ID] {
+			names = append(names, tag.TagName)
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
+
+// tagList formats a transaction's tags for the list and details views.
+// money shows an amount of tx in its account's currency.
+func (m transactionsModel) money(tx Transaction, amount decimal.Decimal) string {
+	return formatMoney(amount, m.accountCurrencies[tx.AccountID])
+}
+
+func tagList(tags []TransactionTag) string {
+	s := ""
+	for _, tag := range tags {
+		s += " #" + tag.TagName
+	}
+	return s
+}
+
+// loadMore asks for the next page once the cursor nears the bottom of the
+// loaded transactions.
+func (m *transactionsModel) loadMore() tea.Cmd {
+	if m.nextCursor == "" || m.loadingMore || m.cursor < len(m.transactions)-loadMoreThreshold {
+		return nil
+	}
+	m.loadingMore = true
+	return func() tea.Msg {
+		return transactionsMoreRequestedMsg{}
+	}
+}
+
+// openFilterForm shows the filter form with the current filter selected.
+// The option lists must already be loaded.
+func (m *transactionsModel) openFilterForm() {
+	m.filterAccountIndex = 0
+	for i, option := range m.filterAccountOptions {
+		if option.ID == m.filter.AccountID {
+			m.filterAccountIndex = i
+		}
+	}
+	m.filterCategoryIndex = 0
+	for i, option := range m.filterCategoryOptions {
+		if option.ID == m.filter.CategoryID {
+			m.filterCategoryIndex = i
+		}
+	}
+	m.filterGroupIndex = 0
+	for i, option := range m.filterGroupOptions {
+		if option.ID == m.filter.GroupID {
+			m.filterGroupIndex = i
+		}
+	}
+	m.filterTagIndex = 0
+	for i, option := range m.filterTagOptions {
+		if option.ID == m.filter.TagID {
+			m.filterTagIndex = i
+		}
+	}
+
+	m.mode = transactionsModeFilter
+	m.filterFieldCursor = filterFieldSearch
+	m.formEditing = false
+	m.blurFilterInputs()
+	m.errorMsg = ""
+}
+
+func (m *transactionsModel) filterInput(field int) *textinput.Model {
+	switch field {
+	case filterFieldSearch:
+		return &m.searchInput
+	case filterFieldStart:
+		return &m.filterStartInput
+	case filterFieldEnd:
+		return &m.filterEndInput
+	case filterFieldMinAmount:
+		return &m.filterMinInput
+	case filterFieldMaxAmount:
+		return &m.filterMaxInput
+	}
+	return nil
+}
+
+func (m *transactionsModel) blurFilterInputs() {
+	for field := filterFieldSearch; field <= filterFieldMaxAmount; field++ {
+		m.filterInput(field).Blur()
+	}
+}
+
+func (m *transactionsModel) clearFilterInputs() {
+	for field := filterFieldSearch; field <= filterFieldMaxAmount; field++ {
+		m.filterInput(field).SetValue("")
+	}
+	m.filterAccountIndex = 0
+	m.filterCategoryIndex = 0
+	m.filterGroupIndex = 0
+	m.filterTagIndex = 0
+	m.filterPostedIndex = 0
+}
+
+func (m transactionsModel) submitFilter() tea.Cmd {
+	var accountID, categoryID, groupID, tagID uuid.UUID
+	if len(m.filterAccountOptions) > 0 {
+		accountID = m.filterAccountOptions[m.filterAccountIndex].ID
+	}
+	if len(m.filterCategoryOptions) > 0 {
+		categoryID = m.filterCategoryOptions[m.filterCategoryIndex].ID
+	}
+	if len(m.filterGroupOptions) > 0 {
+		groupID = m.filterGroupOptions[m.filterGroupIndex].ID
+	}
+	if len(m.filterTagOptions) > 0 {
+		tagID = m.filterTagOptions[m.filterTagIndex].ID
+	}
+	return submitTransactionsFilterMsg(
+		m.searchInput.Value(),
+		strings.TrimSpace(m.filterStartInput.Value()),
+		strings.TrimSpace(m.filterEndInput.Value()),
+		strings.TrimSpace(m.filterMinInput.Value()),
+		strings.TrimSpace(m.filterMaxInput.Value()),
+		accountID,
+		categoryID,
+		groupID,
+		tagID,
+		filterPostedValues[m.filterPostedIndex],
+	)
+}
+
+// filterSummary describes the active filter for the list header.
+func (m transactionsModel) filterSummary() string {
+	var parts []string
+	if m.filter.Search != "" {
+		parts = append(parts, fmt.Sprintf("%q", m.filter.Search))
+	}
+	if !m.filter.StartDate.IsZero() {
+		parts = append(parts, "from "+m.filter.StartDate.Format(time.DateOnly))
+	}
+	if !m.filter.EndDate.IsZero() {
+		parts = append(parts, "to "+m.filter.EndDate.Format(time.DateOnly))
+	}
+	if m.filter.MinAmount.Valid {
+		parts = append(parts, "min "+m.filter.MinAmount.Decimal.String())
+	}
+	if m.filter.MaxAmount.Valid {
+		parts = append(parts, "max "+m.filter.MaxAmount.Decimal.String())
+	}
+	for _, option := range m.filterAccountOptions {
+		if option.ID != uuid.Nil && option.ID == m.filter.AccountID {
+			parts = append(parts, "account "+option.Name)
+		}
+	}
+	for _, option := range m.filterCategoryOptions {
+		if option.ID != uuid.Nil && option.ID == m.filter.CategoryID {
+			parts = append(parts, "category "+option.Name)
+		}
+	}
+	for _, option := range m.filterGroupOptions {
+		if option.ID != uuid.Nil && option.ID == m.filter.GroupID {
+			parts = append(parts, "group "+option.Name)
+		}
+	}
+	for _, option := range m.filterTagOptions {
+		if option.ID != uuid.Nil && option.ID == m.filter.TagID {
+			parts = append(parts, "tag "+option.Name)
+		}
+	}
+	if m.filter.Posted != nil {
+		if *m.filter.Posted {
+			parts = append(parts, "posted")
+		} else {
+			parts = append(parts, "unposted")
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
 func (m transactionsModel) IsEditing() bool {
 	return m.formEditing
 }
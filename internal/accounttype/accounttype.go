@@ -0,0 +1,96 @@
+// Package accounttype lists the kinds of account a budget can hold and
+// which of them are owed rather than owned.
+package accounttype
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+type Type string
+
+const (
+	Checking       Type = "checking"
+	Savings        Type = "savings"
+	Cash           Type = "cash"
+	CreditCard     Type = "credit_card"
+	LineOfCredit   Type = "line_of_credit"
+	Loan           Type = "loan"
+	Mortgage       Type = "mortgage"
+	Investment     Type = "investment"
+	OtherAsset     Type = "other_asset"
+	OtherLiability Type = "other_liability"
+)
+
+// Types lists every account type, assets first.
+var Types = []Type{
+	Checking,
+	Savings,
+	Cash,
+	Investment,
+	OtherAsset,
+	CreditCard,
+	LineOfCredit,
+	Loan,
+	Mortgage,
+	OtherLiability,
+}
+
+var ErrInvalidType = errors.New("account type must be checking, savings, cash, investment, other_asset, credit_card, line_of_credit, loan, mortgage or other_liability")
+
+// InitialBalanceDescription marks the transaction that holds an account's
+// starting balance.
+const InitialBalanceDescription = "Initial balance"
+
+// aliases are names older clients sent, or people typed, before types
+// were checked. The 023 migration folds the same names.
+var aliases = map[Type]Type{
+	"chequing":      Checking,
+	"saving":        Savings,
+	"wallet":        Cash,
+	"investing":     Investment,
+	"investments":   Investment,
+	"brokerage":     Investment,
+	"retirement":    Investment,
+	"asset":         OtherAsset,
+	"credit":        CreditCard,
+	"cc":            CreditCard,
+	"card":          CreditCard,
+	"creditcard":    CreditCard,
+	"heloc":         LineOfCredit,
+	"loc":           LineOfCredit,
+	"credit_line":   LineOfCredit,
+	"auto_loan":     Loan,
+	"car_loan":      Loan,
+	"student_loan":  Loan,
+	"personal_loan": Loan,
+	"home_loan":     Mortgage,
+	"liability":     OtherLiability,
+	"debt":          OtherLiability,
+}
+
+// Parse accepts a type in any case, with spaces or dashes for the
+// underscores, so "Credit Card" is CreditCard. Common older names such as
+// "credit" or "heloc" are accepted too.
+func Parse(s string) (Type, error) {
+	t := Type(strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(s))))
+	if alias, ok := aliases[t]; ok {
+		t = alias
+	}
+	if !slices.Contains(Types, t) {
+		return "", ErrInvalidType
+	}
+	return t, nil
+}
+
+// IsLiability reports whether the account's balance is owed. Liability
+// balances are negative, so a card with $500 charged on it has a balance
+// of -500.
+func (t Type) IsLiability() bool {
+	switch t {
+	case CreditCard, LineOfCredit, Loan, Mortgage, OtherLiability:
+		return true
+	}
+	return false
+}
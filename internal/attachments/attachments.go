@@ -0,0 +1,77 @@
+// Package attachments keeps the files attached to transactions in a local
+// directory. Each file is named after its attachment ID, so the database
+// row is the only place its original name is kept.
+package attachments
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+)
+
+var ErrTooLarge = errors.New("attachment is too large")
+
+type Store struct {
+	dir string
+}
+
+// NewStore uses dir for attachment files, creating it if needed.
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("couldn't create attachments directory: %w", err)
+	}
+	return &Store{dir: dir}, nil
+}
+
+func (s *Store) path(id uuid.UUID) string {
+	return filepath.Join(s.dir, id.String())
+}
+
+// Save writes up to maxSize bytes from r as the attachment's file and
+// returns its size and SHA-256 checksum. The file only appears once it is
+// fully written, so a failed upload leaves nothing behind.
+func (s *Store) Save(id uuid.UUID, r io.Reader, maxSize int64) (int64, string, error) {
+	tmp, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return 0, "", err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return 0, "", err
+	}
+	if size > maxSize {
+		return 0, "", ErrTooLarge
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, "", err
+	}
+	if err := os.Rename(tmp.Name(), s.path(id)); err != nil {
+		return 0, "", err
+	}
+
+	return size, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (s *Store) Open(id uuid.UUID) (*os.File, error) {
+	return os.Open(s.path(id))
+}
+
+// Remove deletes the attachment's file. A file that is already gone isn't
+// an error.
+func (s *Store) Remove(id uuid.UUID) error {
+	err := os.Remove(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,474 @@
+// Package backup defines the JSON archive a user's budget is backed up to
+// and restored from.
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jkk290/budget-tui/internal/accounttype"
+	"github.com/jkk290/budget-tui/internal/currency"
+	"github.com/shopspring/decimal"
+)
+
+// FormatName marks a file as a budget backup.
+const FormatName = "budget-tui-backup"
+
+// SchemaVersion is the archive layout this build writes and reads. Bump it
+// whenever a field is added, removed or changes meaning, and teach Upgrade
+// how to bring older archives forward.
+const SchemaVersion = 6
+
+// Archive holds everything a user owns. IDs are kept as-is so a restore
+// recreates the same budget, and a nil UUID means "none".
+type Archive struct {
+	Format                string                 `json:"format"`
+	SchemaVersion         int                    `json:"schema_version"`
+	CreatedAt             time.Time              `json:"created_at"`
+	BaseCurrency          string                 `json:"base_currency"`
+	Groups                []Group                `json:"groups"`
+	Categories            []Category             `json:"categories"`
+	CategoryBudgets       []CategoryBudget       `json:"category_budgets"`
+	Accounts              []Account              `json:"accounts"`
+	Reconciliations       []Reconciliation       `json:"reconciliations"`
+	Payees                []Payee                `json:"payees"`
+	ScheduledTransactions []ScheduledTransaction `json:"scheduled_transactions"`
+	Transactions          []Transaction          `json:"transactions"`
+	TransactionSplits     []TransactionSplit     `json:"transaction_splits"`
+	Rules                 []Rule                 `json:"rules"`
+	Tags                  []Tag                  `json:"tags"`
+	TransactionTags       []TransactionTag       `json:"transaction_tags"`
+	ExchangeRates         []ExchangeRate         `json:"exchange_rates"`
+}
+
+type Group struct {
+	ID        uuid.UUID `json:"id"`
+	GroupName string    `json:"group_name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Category struct {
+	ID                   uuid.UUID       `json:"id"`
+	CategoryName         string          `json:"category_name"`
+	Budget               decimal.Decimal `json:"budget"`
+	GroupID              uuid.UUID       `json:"group_id"`
+	RolloverOverspending bool            `json:"rollover_overspending"`
+	IsIncome             bool            `json:"is_income"`
+	CreatedAt            time.Time       `json:"created_at"`
+	UpdatedAt            time.Time       `json:"updated_at"`
+}
+
+type CategoryBudget struct {
+	CategoryID uuid.UUID       `json:"category_id"`
+	Month      time.Time       `json:"month"`
+	Assigned   decimal.Decimal `json:"assigned"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+type Account struct {
+	ID          uuid.UUID `json:"id"`
+	AccountName string    `json:"account_name"`
+	AccountType string    `json:"account_type"`
+	Currency    string    `json:"currency"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type Reconciliation struct {
+	ID               uuid.UUID       `json:"id"`
+	AccountID        uuid.UUID       `json:"account_id"`
+	StatementDate    time.Time       `json:"statement_date"`
+	StatementBalance decimal.Decimal `json:"statement_balance"`
+	TransactionCount int32           `json:"transaction_count"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+}
+
+type Payee struct {
+	ID                uuid.UUID `json:"id"`
+	PayeeName         string    `json:"payee_name"`
+	DefaultCategoryID uuid.UUID `json:"default_category_id"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+type ScheduledTransaction struct {
+	ID              uuid.UUID       `json:"id"`
+	Amount          decimal.Decimal `json:"amount"`
+	TxDescription   string          `json:"tx_description"`
+	Frequency       string          `json:"frequency"`
+	DayOfMonth      int32           `json:"day_of_month"`
+	StartDate       time.Time       `json:"start_date"`
+	EndDate         *time.Time      `json:"end_date"`
+	OccurrenceCount int32           `json:"occurrence_count"`
+	LastDate        *time.Time      `json:"last_date"`
+	AccountID       uuid.UUID       `json:"account_id"`
+	CategoryID      uuid.UUID       `json:"category_id"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+type Transaction struct {
+	ID               uuid.UUID       `json:"id"`
+	Amount           decimal.Decimal `json:"amount"`
+	TxDescription    string          `json:"tx_description"`
+	Memo             string          `json:"memo"`
+	TxDate           time.Time       `json:"tx_date"`
+	Posted           bool            `json:"posted"`
+	AccountID        uuid.UUID       `json:"account_id"`
+	CategoryID       uuid.UUID       `json:"category_id"`
+	TransferID       uuid.UUID       `json:"transfer_id"`
+	ScheduledID      uuid.UUID       `json:"scheduled_id"`
+	ScheduledDate    *time.Time      `json:"scheduled_date"`
+	PayeeID          uuid.UUID       `json:"payee_id"`
+	ReconciliationID uuid.UUID       `json:"reconciliation_id"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+}
+
+type TransactionSplit struct {
+	ID            uuid.UUID       `json:"id"`
+	TransactionID uuid.UUID       `json:"transaction_id"`
+	CategoryID    uuid.UUID       `json:"category_id"`
+	Amount        decimal.Decimal `json:"amount"`
+	Memo          string          `json:"memo"`
+	SortOrder     int32           `json:"sort_order"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// Rule is stored in the order rules run.
+type Rule struct {
+	ID                  uuid.UUID        `json:"id"`
+	RuleName            string           `json:"rule_name"`
+	DescriptionContains string           `json:"description_contains"`
+	DescriptionRegex    string           `json:"description_regex"`
+	MinAmount           *decimal.Decimal `json:"min_amount"`
+	MaxAmount           *decimal.Decimal `json:"max_amount"`
+	AccountID           uuid.UUID        `json:"account_id"`
+	SetCategoryID       uuid.UUID        `json:"set_category_id"`
+	SetDescription      string           `json:"set_description"`
+	SetPosted           *bool            `json:"set_posted"`
+	CreatedAt           time.Time        `json:"created_at"`
+	UpdatedAt           time.Time        `json:"updated_at"`
+}
+
+type Tag struct {
+	ID        uuid.UUID `json:"id"`
+	TagName   string    `json:"tag_name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type TransactionTag struct {
+	TransactionID uuid.UUID `json:"transaction_id"`
+	TagID         uuid.UUID `json:"tag_id"`
+}
+
+type ExchangeRate struct {
+	ID           uuid.UUID       `json:"id"`
+	FromCurrency string          `json:"from_currency"`
+	ToCurrency   string          `json:"to_currency"`
+	Rate         decimal.Decimal `json:"rate"`
+	RateDate     time.Time       `json:"rate_date"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+var (
+	ErrNotBackup         = errors.New("file is not a budget backup")
+	ErrUnsupportedSchema = errors.New("unsupported backup schema version")
+)
+
+// Upgrade checks the archive's schema version and brings archives written
+// by older builds up to SchemaVersion. Archives from newer builds are
+// rejected rather than half-restored.
+func (a *Archive) Upgrade() error {
+	if a.Format != FormatName {
+		return ErrNotBackup
+	}
+	if a.SchemaVersion < 1 || a.SchemaVersion > SchemaVersion {
+		return fmt.Errorf("%w: archive is version %d, this server reads up to version %d", ErrUnsupportedSchema, a.SchemaVersion, SchemaVersion)
+	}
+
+	// Version 2 added reconciliations. Version 1 archives have none, so
+	// every transaction comes back unreconciled.
+	if a.SchemaVersion < 2 {
+		a.Reconciliations = nil
+		for i := range a.Transactions {
+			a.Transactions[i].ReconciliationID = uuid.Nil
+		}
+	}
+
+	// Version 3 added tags.
+	if a.SchemaVersion < 3 {
+		a.Tags = nil
+		a.TransactionTags = nil
+	}
+
+	// Version 4 added transaction memos. Older archives decode with empty
+	// memos, which is what they had.
+
+	// Version 5 added currencies. Everything before was in dollars.
+	if a.SchemaVersion < 5 {
+		a.BaseCurrency = currency.Default
+		for i := range a.Accounts {
+			a.Accounts[i].Currency = currency.Default
+		}
+		a.ExchangeRates = nil
+	}
+
+	// Version 6 limited account types to a fixed set, and made liability
+	// balances negative.
+	if a.SchemaVersion < 6 {
+		if err := a.upgradeAccountTypes(); err != nil {
+			return err
+		}
+	}
+
+	a.SchemaVersion = SchemaVersion
+	return nil
+}
+
+// upgradeAccountTypes maps free text account types to the fixed set, the
+// same way the 023 migration does. Types it can't place are an error
+// rather than a guess, since guessing wrong counts a debt as an asset.
+//
+// Liability starting balances used to be saved as entered, so positive
+// ones are flipped to what's owed, and later statement balances move with
+// them.
+func (a *Archive) upgradeAccountTypes() error {
+	liabilities := map[uuid.UUID]bool{}
+	var unknown []string
+	for i, account := range a.Accounts {
+		t, err := accounttype.Parse(account.AccountType)
+		if err != nil {
+			unknown = append(unknown, fmt.Sprintf("%q", account.AccountType))
+			continue
+		}
+		a.Accounts[i].AccountType = string(t)
+		liabilities[account.ID] = t.IsLiability()
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown account types %s; change them to one of the supported types and try again", strings.Join(unknown, ", "))
+	}
+
+	for i, tx := range a.Transactions {
+		if !liabilities[tx.AccountID] || tx.TxDescription != accounttype.InitialBalanceDescription ||
+			tx.CategoryID != uuid.Nil || tx.TransferID != uuid.Nil || !tx.Amount.IsPositive() {
+			continue
+		}
+		a.Transactions[i].Amount = tx.Amount.Neg()
+		for j, reconciliation := range a.Reconciliations {
+			if reconciliation.AccountID == tx.AccountID && !reconciliation.StatementDate.Before(tx.TxDate) {
+				a.Reconciliations[j].StatementBalance = reconciliation.StatementBalance.Sub(tx.Amount.Mul(decimal.NewFromInt(2)))
+			}
+		}
+	}
+	return nil
+}
+
+// idSet collects the IDs of one kind of record, rejecting nil and repeated
+// IDs.
+type idSet map[uuid.UUID]bool
+
+func (s idSet) add(kind string, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return fmt.Errorf("%s is missing an id", kind)
+	}
+	if s[id] {
+		return fmt.Errorf("%s %s appears more than once", kind, id)
+	}
+	s[id] = true
+	return nil
+}
+
+// ref checks that an optional reference points at a record in the set.
+func (s idSet) ref(kind string, id uuid.UUID, field string, ref uuid.UUID) error {
+	if ref != uuid.Nil && !s[ref] {
+		return fmt.Errorf("%s %s: %s %s is not in the backup", kind, id, field, ref)
+	}
+	return nil
+}
+
+// checkCurrency accepts only codes already in the form the server stores.
+func checkCurrency(code string) error {
+	normalized, err := currency.Normalize(code)
+	if err != nil {
+		return err
+	}
+	if normalized != code {
+		return fmt.Errorf("invalid currency %q, expected upper case", code)
+	}
+	return nil
+}
+
+// Validate checks that every record has a unique ID and that every
+// reference points at a record in the archive, so a restore can't fail
+// half way on a foreign key.
+func (a *Archive) Validate() error {
+	groups, categories, accounts := idSet{}, idSet{}, idSet{}
+	reconciliations, payees, scheduled := idSet{}, idSet{}, idSet{}
+	transactions, splits, rules := idSet{}, idSet{}, idSet{}
+	tags, rates := idSet{}, idSet{}
+
+	if err := checkCurrency(a.BaseCurrency); err != nil {
+		return fmt.Errorf("base currency: %w", err)
+	}
+	for _, group := range a.Groups {
+		if err := groups.add("group", group.ID); err != nil {
+			return err
+		}
+	}
+	for _, category := range a.Categories {
+		if err := categories.add("category", category.ID); err != nil {
+			return err
+		}
+		if err := groups.ref("category", category.ID, "group", category.GroupID); err != nil {
+			return err
+		}
+	}
+	months := make(map[uuid.UUID]map[time.Time]bool)
+	for _, budget := range a.CategoryBudgets {
+		if budget.CategoryID == uuid.Nil || !categories[budget.CategoryID] {
+			return fmt.Errorf("category budget for %s: category is not in the backup", budget.CategoryID)
+		}
+		if months[budget.CategoryID] == nil {
+			months[budget.CategoryID] = make(map[time.Time]bool)
+		}
+		if months[budget.CategoryID][budget.Month] {
+			return fmt.Errorf("category budget for %s in %s appears more than once", budget.CategoryID, budget.Month.Format("2006-01"))
+		}
+		months[budget.CategoryID][budget.Month] = true
+	}
+	for _, account := range a.Accounts {
+		if err := accounts.add("account", account.ID); err != nil {
+			return err
+		}
+		if err := checkCurrency(account.Currency); err != nil {
+			return fmt.Errorf("account %s: %w", account.ID, err)
+		}
+		if accountType, err := accounttype.Parse(account.AccountType); err != nil || string(accountType) != account.AccountType {
+			return fmt.Errorf("account %s: %w", account.ID, accounttype.ErrInvalidType)
+		}
+	}
+	reconciledAccounts := make(map[uuid.UUID]uuid.UUID)
+	for _, reconciliation := range a.Reconciliations {
+		if err := reconciliations.add("reconciliation", reconciliation.ID); err != nil {
+			return err
+		}
+		if !accounts[reconciliation.AccountID] {
+			return fmt.Errorf("reconciliation %s: account %s is not in the backup", reconciliation.ID, reconciliation.AccountID)
+		}
+		reconciledAccounts[reconciliation.ID] = reconciliation.AccountID
+	}
+	for _, payee := range a.Payees {
+		if err := payees.add("payee", payee.ID); err != nil {
+			return err
+		}
+		if err := categories.ref("payee", payee.ID, "default category", payee.DefaultCategoryID); err != nil {
+			return err
+		}
+	}
+	for _, s := range a.ScheduledTransactions {
+		if err := scheduled.add("scheduled transaction", s.ID); err != nil {
+			return err
+		}
+		if !accounts[s.AccountID] {
+			return fmt.Errorf("scheduled transaction %s: account %s is not in the backup", s.ID, s.AccountID)
+		}
+		if err := categories.ref("scheduled transaction", s.ID, "category", s.CategoryID); err != nil {
+			return err
+		}
+	}
+	for _, tx := range a.Transactions {
+		if err := transactions.add("transaction", tx.ID); err != nil {
+			return err
+		}
+		if !accounts[tx.AccountID] {
+			return fmt.Errorf("transaction %s: account %s is not in the backup", tx.ID, tx.AccountID)
+		}
+		if err := categories.ref("transaction", tx.ID, "category", tx.CategoryID); err != nil {
+			return err
+		}
+		if err := payees.ref("transaction", tx.ID, "payee", tx.PayeeID); err != nil {
+			return err
+		}
+		if err := scheduled.ref("transaction", tx.ID, "scheduled transaction", tx.ScheduledID); err != nil {
+			return err
+		}
+		if err := reconciliations.ref("transaction", tx.ID, "reconciliation", tx.ReconciliationID); err != nil {
+			return err
+		}
+		if tx.ReconciliationID != uuid.Nil && reconciledAccounts[tx.ReconciliationID] != tx.AccountID {
+			return fmt.Errorf("transaction %s: reconciliation %s belongs to another account", tx.ID, tx.ReconciliationID)
+		}
+	}
+	for _, split := range a.TransactionSplits {
+		if err := splits.add("transaction split", split.ID); err != nil {
+			return err
+		}
+		if !transactions[split.TransactionID] {
+			return fmt.Errorf("transaction split %s: transaction %s is not in the backup", split.ID, split.TransactionID)
+		}
+		if err := categories.ref("transaction split", split.ID, "category", split.CategoryID); err != nil {
+			return err
+		}
+	}
+	for _, rule := range a.Rules {
+		if err := rules.add("rule", rule.ID); err != nil {
+			return err
+		}
+		if err := accounts.ref("rule", rule.ID, "account", rule.AccountID); err != nil {
+			return err
+		}
+		if err := categories.ref("rule", rule.ID, "category", rule.SetCategoryID); err != nil {
+			return err
+		}
+	}
+	tagNames := make(map[string]bool)
+	for _, tag := range a.Tags {
+		if err := tags.add("tag", tag.ID); err != nil {
+			return err
+		}
+		name := strings.ToLower(tag.TagName)
+		if tagNames[name] {
+			return fmt.Errorf("tag %q appears more than once", tag.TagName)
+		}
+		tagNames[name] = true
+	}
+	tagged := make(map[TransactionTag]bool)
+	for _, link := range a.TransactionTags {
+		if !transactions[link.TransactionID] {
+			return fmt.Errorf("transaction tag: transaction %s is not in the backup", link.TransactionID)
+		}
+		if !tags[link.TagID] {
+			return fmt.Errorf("transaction tag: tag %s is not in the backup", link.TagID)
+		}
+		if tagged[link] {
+			return fmt.Errorf("transaction %s is tagged %s more than once", link.TransactionID, link.TagID)
+		}
+		tagged[link] = true
+	}
+	for _, rate := range a.ExchangeRates {
+		if err := rates.add("exchange rate", rate.ID); err != nil {
+			return err
+		}
+		if err := checkCurrency(rate.FromCurrency); err != nil {
+			return fmt.Errorf("exchange rate %s: %w", rate.ID, err)
+		}
+		if err := checkCurrency(rate.ToCurrency); err != nil {
+			return fmt.Errorf("exchange rate %s: %w", rate.ID, err)
+		}
+		if rate.FromCurrency == rate.ToCurrency || !rate.Rate.IsPositive() {
+			return fmt.Errorf("exchange rate %s: rate must convert between two currencies at a positive rate", rate.ID)
+		}
+	}
+
+	return nil
+}
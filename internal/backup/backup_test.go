@@ -0,0 +1,167 @@
+package backup
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jkk290/budget-tui/internal/accounttype"
+	"github.com/shopspring/decimal"
+)
+
+func date(month time.Month, day int) time.Time {
+	return time.Date(2026, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestUpgradeFlipsLiabilityOpeningBalances(t *testing.T) {
+	card := Account{ID: uuid.New(), AccountName: "Visa", AccountType: "Credit Card", Currency: "USD"}
+	checking := Account{ID: uuid.New(), AccountName: "Checking", AccountType: "checking", Currency: "USD"}
+
+	a := &Archive{
+		Format:        FormatName,
+		SchemaVersion: 5,
+		BaseCurrency:  "USD",
+		Accounts:      []Account{card, checking},
+		Transactions: []Transaction{
+			{ID: uuid.New(), AccountID: card.ID, TxDescription: accounttype.InitialBalanceDescription, Amount: decimal.RequireFromString("500"), TxDate: date(1, 1), Posted: true},
+			{ID: uuid.New(), AccountID: card.ID, TxDescription: "Groceries", Amount: decimal.RequireFromString("-50"), TxDate: date(1, 10), Posted: true},
+			{ID: uuid.New(), AccountID: checking.ID, TxDescription: accounttype.InitialBalanceDescription, Amount: decimal.RequireFromString("1000"), TxDate: date(1, 1), Posted: true},
+		},
+		Reconciliations: []Reconciliation{
+			// Dated before the opening balance, so it never included it.
+			{ID: uuid.New(), AccountID: card.ID, StatementDate: date(1, 1).AddDate(0, 0, -1), StatementBalance: decimal.Zero},
+			{ID: uuid.New(), AccountID: card.ID, StatementDate: date(1, 31), StatementBalance: decimal.RequireFromString("450")},
+			{ID: uuid.New(), AccountID: checking.ID, StatementDate: date(1, 31), StatementBalance: decimal.RequireFromString("1000")},
+		},
+	}
+
+	if err := a.Upgrade(); err != nil {
+		t.Fatal(err)
+	}
+
+	if a.SchemaVersion != SchemaVersion {
+		t.Errorf("SchemaVersion = %d, want %d", a.SchemaVersion, SchemaVersion)
+	}
+	if a.Accounts[0].AccountType != string(accounttype.CreditCard) {
+		t.Errorf("card type = %q, want %q", a.Accounts[0].AccountType, accounttype.CreditCard)
+	}
+
+	wantAmounts := []string{"-500", "-50", "1000"}
+	for i, want := range wantAmounts {
+		if got := a.Transactions[i].Amount; !got.Equal(decimal.RequireFromString(want)) {
+			t.Errorf("transaction %d (%s) amount = %s, want %s", i, a.Transactions[i].TxDescription, got, want)
+		}
+	}
+
+	// The card statement owed 450: 500 opening less 50 spent.
+	wantBalances := []string{"0", "-550", "1000"}
+	for i, want := range wantBalances {
+		if got := a.Reconciliations[i].StatementBalance; !got.Equal(decimal.RequireFromString(want)) {
+			t.Errorf("reconciliation %d statement balance = %s, want %s", i, got, want)
+		}
+	}
+}
+
+func TestUpgradeKeepsCategorizedLiabilityEntries(t *testing.T) {
+	loan := Account{ID: uuid.New(), AccountName: "Car", AccountType: "loan", Currency: "USD"}
+	a := &Archive{
+		Format:        FormatName,
+		SchemaVersion: 5,
+		BaseCurrency:  "USD",
+		Accounts:      []Account{loan},
+		Transactions: []Transaction{
+			// Only an uncategorized, non-transfer opening entry is a
+			// starting balance.
+			{ID: uuid.New(), AccountID: loan.ID, TxDescription: accounttype.InitialBalanceDescription, Amount: decimal.RequireFromString("200"), CategoryID: uuid.New()},
+			{ID: uuid.New(), AccountID: loan.ID, TxDescription: accounttype.InitialBalanceDescription, Amount: decimal.RequireFromString("300"), TransferID: uuid.New()},
+			{ID: uuid.New(), AccountID: loan.ID, TxDescription: accounttype.InitialBalanceDescription, Amount: decimal.RequireFromString("-400")},
+		},
+	}
+
+	if err := a.Upgrade(); err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []string{"200", "300", "-400"} {
+		if got := a.Transactions[i].Amount; !got.Equal(decimal.RequireFromString(want)) {
+			t.Errorf("transaction %d amount = %s, want %s", i, got, want)
+		}
+	}
+}
+
+func TestUpgradeLeavesCurrentArchives(t *testing.T) {
+	card := Account{ID: uuid.New(), AccountName: "Visa", AccountType: string(accounttype.CreditCard), Currency: "USD"}
+	a := &Archive{
+		Format:        FormatName,
+		SchemaVersion: SchemaVersion,
+		BaseCurrency:  "USD",
+		Accounts:      []Account{card},
+		Transactions: []Transaction{
+			// A refund that left the card in credit.
+			{ID: uuid.New(), AccountID: card.ID, TxDescription: accounttype.InitialBalanceDescription, Amount: decimal.RequireFromString("25"), TxDate: date(1, 1)},
+		},
+		Reconciliations: []Reconciliation{
+			{ID: uuid.New(), AccountID: card.ID, StatementDate: date(1, 31), StatementBalance: decimal.RequireFromString("25")},
+		},
+	}
+
+	if err := a.Upgrade(); err != nil {
+		t.Fatal(err)
+	}
+	if got := a.Transactions[0].Amount; !got.Equal(decimal.RequireFromString("25")) {
+		t.Errorf("amount = %s, want it left alone", got)
+	}
+	if got := a.Reconciliations[0].StatementBalance; !got.Equal(decimal.RequireFromString("25")) {
+		t.Errorf("statement balance = %s, want it left alone", got)
+	}
+}
+
+func TestUpgradeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		archive Archive
+		wantErr string
+		is      error
+	}{
+		{
+			name:    "not a backup",
+			archive: Archive{Format: "something-else", SchemaVersion: 5},
+			is:      ErrNotBackup,
+		},
+		{
+			name:    "newer schema",
+			archive: Archive{Format: FormatName, SchemaVersion: SchemaVersion + 1},
+			is:      ErrUnsupportedSchema,
+		},
+		{
+			name:    "missing schema",
+			archive: Archive{Format: FormatName},
+			is:      ErrUnsupportedSchema,
+		},
+		{
+			name: "unknown account types",
+			archive: Archive{Format: FormatName, SchemaVersion: 5, BaseCurrency: "USD", Accounts: []Account{
+				{ID: uuid.New(), AccountType: "Piggy bank", Currency: "USD"},
+				{ID: uuid.New(), AccountType: "checking", Currency: "USD"},
+				{ID: uuid.New(), AccountType: "crypto", Currency: "USD"},
+			}},
+			wantErr: `unknown account types "Piggy bank", "crypto"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.archive.Upgrade()
+			if err == nil {
+				t.Fatal("Upgrade succeeded, want an error")
+			}
+			if tt.is != nil && !errors.Is(err, tt.is) {
+				t.Errorf("Upgrade error = %v, want %v", err, tt.is)
+			}
+			if tt.wantErr != "" && !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Upgrade error = %v, want it to name %s", err, tt.wantErr)
+			}
+		})
+	}
+}
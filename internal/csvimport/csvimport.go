@@ -0,0 +1,222 @@
+package csvimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const DefaultDateFormat = "2006-01-02"
+
+// Mapping describes where each transaction field lives in a bank's CSV
+// export. Columns are referenced by their header name.
+type Mapping struct {
+	SkipRows          int    `json:"skip_rows"`
+	DateColumn        string `json:"date_column"`
+	DateFormat        string `json:"date_format"`
+	AmountColumn      string `json:"amount_column"`
+	DebitColumn       string `json:"debit_column"`
+	CreditColumn      string `json:"credit_column"`
+	DescriptionColumn string `json:"description_column"`
+	InvertAmounts     bool   `json:"invert_amounts"`
+}
+
+func (m Mapping) Validate() error {
+	if m.SkipRows < 0 {
+		return errors.New("skip_rows can't be negative")
+	}
+	if m.DateColumn == "" || m.DescriptionColumn == "" {
+		return errors.New("date_column and description_column are required")
+	}
+	if m.AmountColumn == "" && m.DebitColumn == "" && m.CreditColumn == "" {
+		return errors.New("amount_column or debit_column/credit_column is required")
+	}
+	if m.AmountColumn != "" && (m.DebitColumn != "" || m.CreditColumn != "") {
+		return errors.New("use either amount_column or debit_column/credit_column, not both")
+	}
+	return nil
+}
+
+type Row struct {
+	Line        int             `json:"line"`
+	Date        time.Time       `json:"date"`
+	Amount      decimal.Decimal `json:"amount"`
+	Description string          `json:"description"`
+	Error       string          `json:"error,omitempty"`
+}
+
+// Parse reads every data row of a CSV file using the mapping. Rows that
+// can't be parsed are returned with Error set rather than failing the whole
+// file, since bank exports often end with summary lines.
+func Parse(r io.Reader, m Mapping) ([]Row, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	dateFormat := m.DateFormat
+	if dateFormat == "" {
+		dateFormat = DefaultDateFormat
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.LazyQuotes = true
+
+	// The reader skips blank lines, so keep each record's real line number
+	// for error messages.
+	var records [][]string
+	var lines []int
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read CSV: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		records = append(records, record)
+		lines = append(lines, line)
+	}
+	if len(records) <= m.SkipRows {
+		return nil, errors.New("CSV has no header row")
+	}
+
+	header := records[m.SkipRows]
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = i
+	}
+
+	index := func(name string) (int, error) {
+		if name == "" {
+			return -1, nil
+		}
+		i, ok := columns[name]
+		if !ok {
+			return -1, fmt.Errorf("column %q not found in header", name)
+		}
+		return i, nil
+	}
+
+	dateIndex, err := index(m.DateColumn)
+	if err != nil {
+		return nil, err
+	}
+	descriptionIndex, err := index(m.DescriptionColumn)
+	if err != nil {
+		return nil, err
+	}
+	amountIndex, err := index(m.AmountColumn)
+	if err != nil {
+		return nil, err
+	}
+	debitIndex, err := index(m.DebitColumn)
+	if err != nil {
+		return nil, err
+	}
+	creditIndex, err := index(m.CreditColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []Row{}
+	for i, record := range records[m.SkipRows+1:] {
+		if isBlank(record) {
+			continue
+		}
+
+		row := Row{Line: lines[m.SkipRows+i+1]}
+		if err := parseRecord(&row, record, dateFormat, dateIndex, descriptionIndex, amountIndex, debitIndex, creditIndex); err != nil {
+			row.Error = err.Error()
+		} else if m.InvertAmounts {
+			row.Amount = row.Amount.Neg()
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func parseRecord(row *Row, record []string, dateFormat string, dateIndex, descriptionIndex, amountIndex, debitIndex, creditIndex int) error {
+	field := func(i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	date, err := time.Parse(dateFormat, field(dateIndex))
+	if err != nil {
+		return fmt.Errorf("invalid date %q", field(dateIndex))
+	}
+	row.Date = date
+
+	row.Description = field(descriptionIndex)
+	if row.Description == "" {
+		return errors.New("missing description")
+	}
+
+	if amountIndex >= 0 {
+		amount, err := ParseAmount(field(amountIndex))
+		if err != nil {
+			return err
+		}
+		row.Amount = amount
+	} else {
+		debit, err := ParseAmount(field(debitIndex))
+		if err != nil {
+			return err
+		}
+		credit, err := ParseAmount(field(creditIndex))
+		if err != nil {
+			return err
+		}
+		row.Amount = credit.Abs().Sub(debit.Abs())
+	}
+
+	if row.Amount.IsZero() {
+		return errors.New("amount is zero")
+	}
+	return nil
+}
+
+// ParseAmount accepts the formats banks commonly use: currency symbols,
+// thousands separators and accounting-style parentheses for negatives. An
+// empty cell is zero.
+func ParseAmount(s string) (decimal.Decimal, error) {
+	cleaned := strings.TrimSpace(s)
+	if cleaned == "" {
+		return decimal.Zero, nil
+	}
+
+	negative := false
+	if strings.HasPrefix(cleaned, "(") && strings.HasSuffix(cleaned, ")") {
+		negative = true
+		cleaned = cleaned[1 : len(cleaned)-1]
+	}
+	cleaned = strings.NewReplacer("$", "", ",", "", " ", "").Replace(cleaned)
+
+	amount, err := decimal.NewFromString(cleaned)
+	if err != nil {
+		return decimal.Decimal{}, fmt.Errorf("invalid amount %q", s)
+	}
+	if negative {
+		amount = amount.Neg()
+	}
+	return amount, nil
+}
+
+func isBlank(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,187 @@
+package csvimport
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"", "0", false},
+		{"  ", "0", false},
+		{"12.34", "12.34", false},
+		{"-12.34", "-12.34", false},
+		{"$1,234.56", "1234.56", false},
+		{"-$1,234.56", "-1234.56", false},
+		{"(45.00)", "-45", false},
+		{"($1,000.00)", "-1000", false},
+		{" 7 ", "7", false},
+		{"1 000.50", "1000.5", false},
+		{"abc", "", true},
+		{"12.34.56", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseAmount(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseAmount(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !got.Equal(decimal.RequireFromString(tt.want)) {
+			t.Errorf("ParseAmount(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMappingValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping Mapping
+		wantErr bool
+	}{
+		{"amount column", Mapping{DateColumn: "Date", DescriptionColumn: "Memo", AmountColumn: "Amount"}, false},
+		{"debit and credit columns", Mapping{DateColumn: "Date", DescriptionColumn: "Memo", DebitColumn: "Out", CreditColumn: "In"}, false},
+		{"debit column only", Mapping{DateColumn: "Date", DescriptionColumn: "Memo", DebitColumn: "Out"}, false},
+		{"negative skip rows", Mapping{SkipRows: -1, DateColumn: "Date", DescriptionColumn: "Memo", AmountColumn: "Amount"}, true},
+		{"missing date column", Mapping{DescriptionColumn: "Memo", AmountColumn: "Amount"}, true},
+		{"missing description column", Mapping{DateColumn: "Date", AmountColumn: "Amount"}, true},
+		{"no amount columns", Mapping{DateColumn: "Date", DescriptionColumn: "Memo"}, true},
+		{"amount and debit columns", Mapping{DateColumn: "Date", DescriptionColumn: "Memo", AmountColumn: "Amount", DebitColumn: "Out"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.mapping.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Run("amount column", func(t *testing.T) {
+		csv := "\ufeffDate, Description ,Amount\n" +
+			"2026-01-05,Coffee,-4.50\n" +
+			"\n" +
+			"2026-01-06,Paycheck,\"$1,200.00\"\n"
+		rows, err := Parse(strings.NewReader(csv), Mapping{DateColumn: "Date", DescriptionColumn: "Description", AmountColumn: "Amount"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []Row{
+			{Line: 2, Date: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), Amount: decimal.RequireFromString("-4.50"), Description: "Coffee"},
+			{Line: 4, Date: time.Date(2026, 1, 6, 0, 0, 0, 0, time.UTC), Amount: decimal.RequireFromString("1200"), Description: "Paycheck"},
+		}
+		checkRows(t, rows, want)
+	})
+
+	t.Run("debit and credit columns with skipped rows and a date format", func(t *testing.T) {
+		csv := "Account statement\n" +
+			"Generated 2026-02-01\n" +
+			"Posted,Payee,Withdrawal,Deposit\n" +
+			"01/15/2026,Rent,1500.00,\n" +
+			"01/20/2026,Refund,,(25.00)\n"
+		rows, err := Parse(strings.NewReader(csv), Mapping{
+			SkipRows:          2,
+			DateColumn:        "Posted",
+			DateFormat:        "01/02/2006",
+			DescriptionColumn: "Payee",
+			DebitColumn:       "Withdrawal",
+			CreditColumn:      "Deposit",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []Row{
+			{Line: 4, Date: time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC), Amount: decimal.RequireFromString("-1500"), Description: "Rent"},
+			// Credits count as money in whatever sign the bank used.
+			{Line: 5, Date: time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC), Amount: decimal.RequireFromString("25"), Description: "Refund"},
+		}
+		checkRows(t, rows, want)
+	})
+
+	t.Run("inverted amounts", func(t *testing.T) {
+		csv := "Date,Description,Amount\n2026-03-01,Card payment,120.00\n"
+		rows, err := Parse(strings.NewReader(csv), Mapping{DateColumn: "Date", DescriptionColumn: "Description", AmountColumn: "Amount", InvertAmounts: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkRows(t, rows, []Row{
+			{Line: 2, Date: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), Amount: decimal.RequireFromString("-120"), Description: "Card payment"},
+		})
+	})
+
+	t.Run("bad rows are reported without failing the file", func(t *testing.T) {
+		csv := "Date,Description,Amount\n" +
+			"2026-01-05,Coffee,-4.50\n" +
+			"yesterday,Tea,-3.00\n" +
+			"2026-01-06,,-3.00\n" +
+			"2026-01-07,Lunch,lots\n" +
+			"2026-01-08,Nothing,0.00\n" +
+			"Total,,-4.50\n"
+		rows, err := Parse(strings.NewReader(csv), Mapping{DateColumn: "Date", DescriptionColumn: "Description", AmountColumn: "Amount"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantErrors := []string{"", `invalid date "yesterday"`, "missing description", `invalid amount "lots"`, "amount is zero", `invalid date "Total"`}
+		if len(rows) != len(wantErrors) {
+			t.Fatalf("got %d rows, want %d", len(rows), len(wantErrors))
+		}
+		for i, want := range wantErrors {
+			if rows[i].Error != want {
+				t.Errorf("row %d error = %q, want %q", i, rows[i].Error, want)
+			}
+		}
+	})
+
+	t.Run("short rows read missing cells as empty", func(t *testing.T) {
+		csv := "Date,Description,Withdrawal,Deposit\n2026-01-05,Coffee,4.50\n"
+		rows, err := Parse(strings.NewReader(csv), Mapping{DateColumn: "Date", DescriptionColumn: "Description", DebitColumn: "Withdrawal", CreditColumn: "Deposit"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkRows(t, rows, []Row{
+			{Line: 2, Date: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), Amount: decimal.RequireFromString("-4.50"), Description: "Coffee"},
+		})
+	})
+
+	failures := []struct {
+		name    string
+		csv     string
+		mapping Mapping
+	}{
+		{"invalid mapping", "Date,Description,Amount\n", Mapping{DateColumn: "Date"}},
+		{"missing column", "Date,Description\n2026-01-05,Coffee\n", Mapping{DateColumn: "Date", DescriptionColumn: "Description", AmountColumn: "Amount"}},
+		{"no header after skipped rows", "one\ntwo\n", Mapping{SkipRows: 2, DateColumn: "Date", DescriptionColumn: "Description", AmountColumn: "Amount"}},
+	}
+	for _, tt := range failures {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(tt.csv), tt.mapping); err == nil {
+				t.Error("Parse succeeded, want an error")
+			}
+		})
+	}
+}
+
+func checkRows(t *testing.T, got, want []Row) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d rows, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		if g.Error != "" {
+			t.Errorf("row %d: unexpected error %q", i, g.Error)
+		}
+		if g.Line != w.Line || !g.Date.Equal(w.Date) || !g.Amount.Equal(w.Amount) || g.Description != w.Description {
+			t.Errorf("row %d = %+v, want %+v", i, g, w)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Package currency validates currency codes and reads exchange rates from
+// CSV files.
+package currency
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Default is the currency of accounts and users created before currencies
+// were tracked.
+const Default = "USD"
+
+// Normalize upper-cases an ISO 4217 style code and checks that it is three
+// letters. It doesn't check the code against the list of real currencies,
+// so made-up codes for things like points or crypto are allowed.
+func Normalize(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 {
+		return "", fmt.Errorf("invalid currency %q, expected a three letter code", code)
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return "", fmt.Errorf("invalid currency %q, expected a three letter code", code)
+		}
+	}
+	return code, nil
+}
+
+// Rate is one unit of From expressed in To, in effect from Date until the
+// next rate for the same pair.
+type Rate struct {
+	Date time.Time
+	From string
+	To   string
+	Rate decimal.Decimal
+}
+
+// ParseRates reads a CSV file with a header row naming the date, from, to
+// and rate columns, in any order. Dates are YYYY-MM-DD. The whole file is
+// rejected if any row is invalid, so a bad export can't be half-loaded.
+func ParseRates(r io.Reader) ([]Rate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("CSV has no header row")
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	indexes := make([]int, 4)
+	for i, name := range []string{"date", "from", "to", "rate"} {
+		index, ok := columns[name]
+		if !ok {
+			return nil, fmt.Errorf("column %q not found in header", name)
+		}
+		indexes[i] = index
+	}
+
+	rates := []Rate{}
+	for i, record := range records[1:] {
+		field := func(column int) string {
+			index := indexes[column]
+			if index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		rate, err := parseRate(field(0), field(1), field(2), field(3))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+2, err)
+		}
+		rates = append(rates, rate)
+	}
+
+	return rates, nil
+}
+
+// Check normalizes the currency codes and makes sure the rate converts
+// between two different currencies at a positive rate.
+func (r *Rate) Check() error {
+	from, err := Normalize(r.From)
+	if err != nil {
+		return err
+	}
+	to, err := Normalize(r.To)
+	if err != nil {
+		return err
+	}
+	if from == to {
+		return errors.New("from and to must be different currencies")
+	}
+	if !r.Rate.IsPositive() {
+		return errors.New("rate must be positive")
+	}
+	r.From = from
+	r.To = to
+	return nil
+}
+
+func parseRate(date, from, to, rate string) (Rate, error) {
+	rateDate, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return Rate{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", date)
+	}
+	value, err := decimal.NewFromString(rate)
+	if err != nil {
+		return Rate{}, fmt.Errorf("invalid rate %q", rate)
+	}
+
+	parsed := Rate{
+		Date: rateDate,
+		From: from,
+		To:   to,
+		Rate: value,
+	}
+	if err := parsed.Check(); err != nil {
+		return Rate{}, err
+	}
+	return parsed, nil
+}
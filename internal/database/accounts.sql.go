@@ -13,16 +13,17 @@ import (
 )
 
 const addAccount = `-- name: AddAccount :one
-INSERT INTO accounts (id, account_name, account_type, created_at, updated_at, user_id)
+INSERT INTO accounts (id, account_name, account_type, created_at, updated_at, user_id, currency)
 VALUES (
     $1,
     $2,
     $3,
     $4,
     $5,
-    $6
+    $6,
+    $7
 )
-RETURNING id, account_name, account_type, created_at, updated_at, user_id
+RETURNING id, account_name, account_type, created_at, updated_at, user_id, currency
 `
 
 type AddAccountParams struct {
@@ -32,6 +33,7 @@ type AddAccountParams struct {
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	UserID      uuid.UUID
+	Currency    string
 }
 
 func (q *Queries) AddAccount(ctx context.Context, arg AddAccountParams) (Account, error) {
@@ -42,6 +44,7 @@ func (q *Queries) AddAccount(ctx context.Context, arg AddAccountParams) (Account
 		arg.CreatedAt,
 		arg.UpdatedAt,
 		arg.UserID,
+		arg.Currency,
 	)
 	var i Account
 	err := row.Scan(
@@ -51,6 +54,7 @@ func (q *Queries) AddAccount(ctx context.Context, arg AddAccountParams) (Account
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.UserID,
+		&i.Currency,
 	)
 	return i, err
 }
@@ -66,7 +70,7 @@ func (q *Queries) DeleteAccount(ctx context.Context, id uuid.UUID) error {
 }
 
 const getAccountByID = `-- name: GetAccountByID :one
-SELECT id, account_name, account_type, created_at, updated_at, user_id FROM accounts
+SELECT id, account_name, account_type, created_at, updated_at, user_id, currency FROM accounts
 WHERE id = $1
 `
 
@@ -80,12 +84,13 @@ func (q *Queries) GetAccountByID(ctx context.Context, id uuid.UUID) (Account, er
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.UserID,
+		&i.Currency,
 	)
 	return i, err
 }
 
 const getAccountsByUserID = `-- name: GetAccountsByUserID :many
-SELECT id, account_name, account_type, created_at, updated_at, user_id FROM accounts
+SELECT id, account_name, account_type, created_at, updated_at, user_id, currency FROM accounts
 WHERE user_id = $1
 `
 
@@ -105,6 +110,7 @@ func (q *Queries) GetAccountsByUserID(ctx context.Context, userID uuid.UUID) ([]
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.UserID,
+			&i.Currency,
 		); err != nil {
 			return nil, err
 		}
@@ -124,7 +130,7 @@ UPDATE accounts
 SET account_name = $2,
 updated_at = NOW()
 where id = $1
-RETURNING id, account_name, account_type, created_at, updated_at, user_id
+RETURNING id, account_name, account_type, created_at, updated_at, user_id, currency
 `
 
 type UpdateAccountInfoParams struct {
@@ -142,6 +148,7 @@ func (q *Queries) UpdateAccountInfo(ctx context.Context, arg UpdateAccountInfoPa
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.UserID,
+		&i.Currency,
 	)
 	return i, err
 }
@@ -14,7 +14,7 @@ import (
 )
 
 const createCategory = `-- name: CreateCategory :one
-INSERT INTO categories (id, category_name, created_at, updated_at, budget, user_id, group_id)
+INSERT INTO categories (id, category_name, created_at, updated_at, budget, user_id, group_id, rollover_overspending, is_income)
 VALUES (
     $1,
     $2,
@@ -22,19 +22,23 @@ VALUES (
     $4,
     $5,
     $6,
-    $7
+    $7,
+    $8,
+    $9
 )
-RETURNING id, category_name, created_at, updated_at, budget, user_id, group_id
+RETURNING id, category_name, created_at, updated_at, budget, user_id, group_id, rollover_overspending, is_income
 `
 
 type CreateCategoryParams struct {
-	ID           uuid.UUID
-	CategoryName string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
-	Budget       decimal.Decimal
-	UserID       uuid.UUID
-	GroupID      uuid.NullUUID
+	ID                   uuid.UUID
+	CategoryName         string
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+	Budget               decimal.Decimal
+	UserID               uuid.UUID
+	GroupID              uuid.NullUUID
+	RolloverOverspending bool
+	IsIncome             bool
 }
 
 func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error) {
@@ -46,6 +50,8 @@ func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams)
 		arg.Budget,
 		arg.UserID,
 		arg.GroupID,
+		arg.RolloverOverspending,
+		arg.IsIncome,
 	)
 	var i Category
 	err := row.Scan(
@@ -56,6 +62,8 @@ func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams)
 		&i.Budget,
 		&i.UserID,
 		&i.GroupID,
+		&i.RolloverOverspending,
+		&i.IsIncome,
 	)
 	return i, err
 }
@@ -71,7 +79,7 @@ func (q *Queries) DeleteCategory(ctx context.Context, id uuid.UUID) error {
 }
 
 const getCategoriesByUser = `-- name: GetCategoriesByUser :many
-SELECT id, category_name, created_at, updated_at, budget, user_id, group_id FROM categories
+SELECT id, category_name, created_at, updated_at, budget, user_id, group_id, rollover_overspending, is_income FROM categories
 WHERE user_id = $1
 `
 
@@ -92,6 +100,8 @@ func (q *Queries) GetCategoriesByUser(ctx context.Context, userID uuid.UUID) ([]
 			&i.Budget,
 			&i.UserID,
 			&i.GroupID,
+			&i.RolloverOverspending,
+			&i.IsIncome,
 		); err != nil {
 			return nil, err
 		}
@@ -107,7 +117,7 @@ func (q *Queries) GetCategoriesByUser(ctx context.Context, userID uuid.UUID) ([]
 }
 
 const getCategoryByID = `-- name: GetCategoryByID :one
-SELECT id, category_name, created_at, updated_at, budget, user_id, group_id FROM categories
+SELECT id, category_name, created_at, updated_at, budget, user_id, group_id, rollover_overspending, is_income FROM categories
 WHERE id = $1
 `
 
@@ -122,6 +132,8 @@ func (q *Queries) GetCategoryByID(ctx context.Context, id uuid.UUID) (Category,
 		&i.Budget,
 		&i.UserID,
 		&i.GroupID,
+		&i.RolloverOverspending,
+		&i.IsIncome,
 	)
 	return i, err
 }
@@ -131,16 +143,20 @@ UPDATE categories
 SET category_name = $2,
 budget = $3,
 group_id = $4,
+rollover_overspending = $5,
+is_income = $6,
 updated_at = NOW()
 WHERE id = $1
-RETURNING id, category_name, created_at, updated_at, budget, user_id, group_id
+RETURNING id, category_name, created_at, updated_at, budget, user_id, group_id, rollover_overspending, is_income
 `
 
 type UpdateCategoryParams struct {
-	ID           uuid.UUID
-	CategoryName string
-	Budget       decimal.Decimal
-	GroupID      uuid.NullUUID
+	ID                   uuid.UUID
+	CategoryName         string
+	Budget               decimal.Decimal
+	GroupID              uuid.NullUUID
+	RolloverOverspending bool
+	IsIncome             bool
 }
 
 func (q *Queries) UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (Category, error) {
@@ -149,6 +165,8 @@ func (q *Queries) UpdateCategory(ctx context.Context, arg UpdateCategoryParams)
 		arg.CategoryName,
 		arg.Budget,
 		arg.GroupID,
+		arg.RolloverOverspending,
+		arg.IsIncome,
 	)
 	var i Category
 	err := row.Scan(
@@ -159,6 +177,8 @@ func (q *Queries) UpdateCategory(ctx context.Context, arg UpdateCategoryParams)
 		&i.Budget,
 		&i.UserID,
 		&i.GroupID,
+		&i.RolloverOverspending,
+		&i.IsIncome,
 	)
 	return i, err
 }
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/shopspring/decimal"
 )
 
@@ -29,6 +30,212 @@ func (q *Queries) GetAccountBalance(ctx context.Context, id uuid.UUID) (int64, e
 	return account_balance, err
 }
 
+const getAccountPeriodTotals = `-- name: GetAccountPeriodTotals :many
+SELECT transactions.account_id,
+date_trunc($1::text, transactions.tx_date)::timestamp AS period_start,
+SUM(transactions.amount)::numeric AS amount
+FROM transactions
+INNER JOIN accounts
+ON accounts.id = transactions.account_id
+WHERE accounts.user_id = $2
+AND transactions.tx_date >= $3
+AND transactions.tx_date < $4
+GROUP BY transactions.account_id, period_start
+ORDER BY period_start
+`
+
+type GetAccountPeriodTotalsParams struct {
+	Interval  string
+	UserID    uuid.UUID
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+type GetAccountPeriodTotalsRow struct {
+	AccountID   uuid.UUID
+	PeriodStart time.Time
+	Amount      decimal.Decimal
+}
+
+func (q *Queries) GetAccountPeriodTotals(ctx context.Context, arg GetAccountPeriodTotalsParams) ([]GetAccountPeriodTotalsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAccountPeriodTotals,
+		arg.Interval,
+		arg.UserID,
+		arg.StartDate,
+		arg.EndDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAccountPeriodTotalsRow
+	for rows.Next() {
+		var i GetAccountPeriodTotalsRow
+		if err := rows.Scan(&i.AccountID, &i.PeriodStart, &i.Amount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAccountRegister = `-- name: GetAccountRegister :many
+SELECT transactions.id, transactions.amount, transactions.tx_description, transactions.tx_date, transactions.created_at, transactions.updated_at, transactions.posted, transactions.account_id, transactions.category_id, transactions.transfer_id, transactions.scheduled_id, transactions.scheduled_date, transactions.payee_id, transactions.reconciliation_id, transactions.memo,
+categories.category_name,
+payees.payee_name,
+transfer_accounts.account_name AS transfer_account_name,
+EXISTS (
+    SELECT 1 FROM transaction_splits
+    WHERE transaction_splits.transaction_id = transactions.id
+) AS is_split
+FROM transactions
+LEFT JOIN categories
+ON categories.id = transactions.category_id
+LEFT JOIN payees
+ON payees.id = transactions.payee_id
+LEFT JOIN transactions AS transfer_legs
+ON transfer_legs.transfer_id = transactions.transfer_id
+AND transfer_legs.id <> transactions.id
+LEFT JOIN accounts AS transfer_accounts
+ON transfer_accounts.id = transfer_legs.account_id
+WHERE transactions.account_id = $1
+AND ($2::timestamp IS NULL OR transactions.tx_date >= $2)
+AND ($3::timestamp IS NULL OR transactions.tx_date < $3)
+ORDER BY transactions.tx_date, transactions.created_at, transactions.id
+`
+
+type GetAccountRegisterParams struct {
+	AccountID uuid.UUID
+	StartDate sql.NullTime
+	EndDate   sql.NullTime
+}
+
+type GetAccountRegisterRow struct {
+	ID                  uuid.UUID
+	Amount              decimal.Decimal
+	TxDescription       string
+	TxDate              time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	Posted              bool
+	AccountID           uuid.UUID
+	CategoryID          uuid.NullUUID
+	TransferID          uuid.NullUUID
+	ScheduledID         uuid.NullUUID
+	ScheduledDate       sql.NullTime
+	PayeeID             uuid.NullUUID
+	ReconciliationID    uuid.NullUUID
+	Memo                string
+	CategoryName        sql.NullString
+	PayeeName           sql.NullString
+	TransferAccountName sql.NullString
+	IsSplit             bool
+}
+
+func (q *Queries) GetAccountRegister(ctx context.Context, arg GetAccountRegisterParams) ([]GetAccountRegisterRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAccountRegister, arg.AccountID, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAccountRegisterRow
+	for rows.Next() {
+		var i GetAccountRegisterRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Amount,
+			&i.TxDescription,
+			&i.TxDate,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Posted,
+			&i.AccountID,
+			&i.CategoryID,
+			&i.TransferID,
+			&i.ScheduledID,
+			&i.ScheduledDate,
+			&i.PayeeID,
+			&i.ReconciliationID,
+			&i.Memo,
+			&i.CategoryName,
+			&i.PayeeName,
+			&i.TransferAccountName,
+			&i.IsSplit,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTransactionSplitsForTransactions = `-- name: GetTransactionSplitsForTransactions :many
+SELECT transaction_splits.id, transaction_splits.transaction_id, transaction_splits.category_id, transaction_splits.amount, transaction_splits.memo, transaction_splits.sort_order, transaction_splits.created_at, transaction_splits.updated_at,
+categories.category_name
+FROM transaction_splits
+LEFT JOIN categories
+ON categories.id = transaction_splits.category_id
+WHERE transaction_splits.transaction_id = ANY($1::uuid[])
+ORDER BY transaction_splits.transaction_id, transaction_splits.sort_order
+`
+
+type GetTransactionSplitsForTransactionsRow struct {
+	ID            uuid.UUID
+	TransactionID uuid.UUID
+	CategoryID    uuid.NullUUID
+	Amount        decimal.Decimal
+	Memo          string
+	SortOrder     int32
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	CategoryName  sql.NullString
+}
+
+func (q *Queries) GetTransactionSplitsForTransactions(ctx context.Context, transactionIds []uuid.UUID) ([]GetTransactionSplitsForTransactionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getTransactionSplitsForTransactions, pq.Array(transactionIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTransactionSplitsForTransactionsRow
+	for rows.Next() {
+		var i GetTransactionSplitsForTransactionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TransactionID,
+			&i.CategoryID,
+			&i.Amount,
+			&i.Memo,
+			&i.SortOrder,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.CategoryName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTransactionUserID = `-- name: GetTransactionUserID :one
 SELECT accounts.user_id AS user_id FROM transactions
 INNER JOIN accounts
@@ -44,26 +251,38 @@ func (q *Queries) GetTransactionUserID(ctx context.Context, id uuid.UUID) (uuid.
 }
 
 const getUserAccountsBalances = `-- name: GetUserAccountsBalances :many
-SELECT accounts.id, accounts.account_name, accounts.account_type, accounts.created_at, accounts.updated_at, accounts.user_id, (SUM(transactions.amount * 100))::bigint AS account_balance_cents
+SELECT accounts.id, accounts.account_name, accounts.account_type, accounts.created_at, accounts.updated_at, accounts.user_id, accounts.currency,
+COALESCE(SUM(transactions.amount) FILTER (WHERE transactions.posted AND transactions.tx_date < $1), 0)::numeric AS cleared_balance,
+COALESCE(SUM(transactions.amount) FILTER (WHERE NOT transactions.posted AND transactions.tx_date < $1), 0)::numeric AS uncleared_balance,
+COALESCE(SUM(transactions.amount) FILTER (WHERE transactions.tx_date >= $1), 0)::numeric AS future_amount
 FROM accounts
-INNER JOIN transactions
+LEFT JOIN transactions
 ON transactions.account_id = accounts.id
-WHERE accounts.user_id = $1
+WHERE accounts.user_id = $2
 GROUP BY accounts.id
+ORDER BY accounts.created_at
 `
 
+type GetUserAccountsBalancesParams struct {
+	AsOf   time.Time
+	UserID uuid.UUID
+}
+
 type GetUserAccountsBalancesRow struct {
-	ID                  uuid.UUID
-	AccountName         string
-	AccountType         string
-	CreatedAt           time.Time
-	UpdatedAt           time.Time
-	UserID              uuid.UUID
-	AccountBalanceCents int64
+	ID               uuid.UUID
+	AccountName      string
+	AccountType      string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	UserID           uuid.UUID
+	Currency         string
+	ClearedBalance   decimal.Decimal
+	UnclearedBalance decimal.Decimal
+	FutureAmount     decimal.Decimal
 }
 
-func (q *Queries) GetUserAccountsBalances(ctx context.Context, userID uuid.UUID) ([]GetUserAccountsBalancesRow, error) {
-	rows, err := q.db.QueryContext(ctx, getUserAccountsBalances, userID)
+func (q *Queries) GetUserAccountsBalances(ctx context.Context, arg GetUserAccountsBalancesParams) ([]GetUserAccountsBalancesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getUserAccountsBalances, arg.AsOf, arg.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -78,7 +297,10 @@ func (q *Queries) GetUserAccountsBalances(ctx context.Context, userID uuid.UUID)
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.UserID,
-			&i.AccountBalanceCents,
+			&i.Currency,
+			&i.ClearedBalance,
+			&i.UnclearedBalance,
+			&i.FutureAmount,
 		); err != nil {
 			return nil, err
 		}
@@ -97,38 +319,45 @@ const getUserBudgetOverviewForMonth = `-- name: GetUserBudgetOverviewForMonth :m
 SELECT categories.id AS category_id,
 categories.category_name,
 categories.budget,
+categories.rollover_overspending,
+categories.created_at,
 categories.group_id,
 groups.group_name,
-COALESCE(SUM(-transactions.amount), 0)::numeric AS total_spent
+COALESCE(SUM(-converted_transaction_lines.amount), 0)::numeric AS total_spent
 FROM categories
 LEFT JOIN groups ON groups.id = categories.group_id
-LEFT JOIN transactions
-ON transactions.category_id = categories.id
-AND transactions.tx_date >= $2
-AND transactions.tx_date < $3
-WHERE categories.user_id = $1
+LEFT JOIN converted_transaction_lines
+ON converted_transaction_lines.category_id = categories.id
+AND converted_transaction_lines.tx_date >= $1
+AND converted_transaction_lines.tx_date < $2
+AND converted_transaction_lines.transfer_id IS NULL
+WHERE categories.user_id = $3
+AND categories.is_income = false
 GROUP BY categories.id, categories.category_name, categories.budget,
+categories.rollover_overspending, categories.created_at,
 categories.group_id, groups.group_name
 ORDER BY groups.group_name NULLS LAST, categories.category_name
 `
 
 type GetUserBudgetOverviewForMonthParams struct {
-	UserID   uuid.UUID
-	TxDate   time.Time
-	TxDate_2 time.Time
+	StartDate time.Time
+	EndDate   time.Time
+	UserID    uuid.UUID
 }
 
 type GetUserBudgetOverviewForMonthRow struct {
-	CategoryID   uuid.UUID
-	CategoryName string
-	Budget       decimal.Decimal
-	GroupID      uuid.NullUUID
-	GroupName    sql.NullString
-	TotalSpent   decimal.Decimal
+	CategoryID           uuid.UUID
+	CategoryName         string
+	Budget               decimal.Decimal
+	RolloverOverspending bool
+	CreatedAt            time.Time
+	GroupID              uuid.NullUUID
+	GroupName            sql.NullString
+	TotalSpent           decimal.Decimal
 }
 
 func (q *Queries) GetUserBudgetOverviewForMonth(ctx context.Context, arg GetUserBudgetOverviewForMonthParams) ([]GetUserBudgetOverviewForMonthRow, error) {
-	rows, err := q.db.QueryContext(ctx, getUserBudgetOverviewForMonth, arg.UserID, arg.TxDate, arg.TxDate_2)
+	rows, err := q.db.QueryContext(ctx, getUserBudgetOverviewForMonth, arg.StartDate, arg.EndDate, arg.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -140,6 +369,8 @@ func (q *Queries) GetUserBudgetOverviewForMonth(ctx context.Context, arg GetUser
 			&i.CategoryID,
 			&i.CategoryName,
 			&i.Budget,
+			&i.RolloverOverspending,
+			&i.CreatedAt,
 			&i.GroupID,
 			&i.GroupName,
 			&i.TotalSpent,
@@ -158,7 +389,7 @@ func (q *Queries) GetUserBudgetOverviewForMonth(ctx context.Context, arg GetUser
 }
 
 const getUserCategoriesDetailed = `-- name: GetUserCategoriesDetailed :many
-SELECT categories.id, categories.category_name, categories.created_at, categories.updated_at, categories.budget, categories.user_id, categories.group_id,
+SELECT categories.id, categories.category_name, categories.created_at, categories.updated_at, categories.budget, categories.user_id, categories.group_id, categories.rollover_overspending, categories.is_income,
 groups.group_name
 FROM categories
 LEFT JOIN groups
@@ -167,14 +398,16 @@ WHERE categories.user_id = $1
 `
 
 type GetUserCategoriesDetailedRow struct {
-	ID           uuid.UUID
-	CategoryName string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
-	Budget       decimal.Decimal
-	UserID       uuid.UUID
-	GroupID      uuid.NullUUID
-	GroupName    sql.NullString
+	ID                   uuid.UUID
+	CategoryName         string
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+	Budget               decimal.Decimal
+	UserID               uuid.UUID
+	GroupID              uuid.NullUUID
+	RolloverOverspending bool
+	IsIncome             bool
+	GroupName            sql.NullString
 }
 
 func (q *Queries) GetUserCategoriesDetailed(ctx context.Context, userID uuid.UUID) ([]GetUserCategoriesDetailedRow, error) {
@@ -194,6 +427,8 @@ func (q *Queries) GetUserCategoriesDetailed(ctx context.Context, userID uuid.UUI
 			&i.Budget,
 			&i.UserID,
 			&i.GroupID,
+			&i.RolloverOverspending,
+			&i.IsIncome,
 			&i.GroupName,
 		); err != nil {
 			return nil, err
@@ -209,35 +444,308 @@ func (q *Queries) GetUserCategoriesDetailed(ctx context.Context, userID uuid.UUI
 	return items, nil
 }
 
-const getUserTransactions = `-- name: GetUserTransactions :many
-SELECT transactions.id, transactions.amount, transactions.tx_description, transactions.tx_date, transactions.created_at, transactions.updated_at, transactions.posted, transactions.account_id, transactions.category_id,
-accounts.account_name,
-categories.category_name
+const getUserCategoryMonthlyActivity = `-- name: GetUserCategoryMonthlyActivity :many
+SELECT categories.id AS category_id,
+date_trunc('month', converted_transaction_lines.tx_date)::date AS month,
+COALESCE(SUM(converted_transaction_lines.amount), 0)::numeric AS activity
+FROM converted_transaction_lines
+INNER JOIN categories
+ON categories.id = converted_transaction_lines.category_id
+WHERE categories.user_id = $1
+AND converted_transaction_lines.tx_date < $2
+AND converted_transaction_lines.transfer_id IS NULL
+AND categories.is_income = false
+GROUP BY categories.id, date_trunc('month', converted_transaction_lines.tx_date)
+ORDER BY month
+`
+
+type GetUserCategoryMonthlyActivityParams struct {
+	UserID  uuid.UUID
+	EndDate time.Time
+}
+
+type GetUserCategoryMonthlyActivityRow struct {
+	CategoryID uuid.UUID
+	Month      time.Time
+	Activity   decimal.Decimal
+}
+
+func (q *Queries) GetUserCategoryMonthlyActivity(ctx context.Context, arg GetUserCategoryMonthlyActivityParams) ([]GetUserCategoryMonthlyActivityRow, error) {
+	rows, err := q.db.QueryContext(ctx, getUserCategoryMonthlyActivity, arg.UserID, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserCategoryMonthlyActivityRow
+	for rows.Next() {
+		var i GetUserCategoryMonthlyActivityRow
+		if err := rows.Scan(
+			&i.CategoryID,
+			&i.Month,
+			&i.Activity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserFirstTransactionDate = `-- name: GetUserFirstTransactionDate :one
+SELECT COALESCE(MIN(transactions.tx_date), NOW())::timestamp AS first_tx_date
 FROM transactions
 INNER JOIN accounts
 ON accounts.id = transactions.account_id
+WHERE accounts.user_id = $1
+`
+
+func (q *Queries) GetUserFirstTransactionDate(ctx context.Context, userID uuid.UUID) (time.Time, error) {
+	row := q.db.QueryRowContext(ctx, getUserFirstTransactionDate, userID)
+	var first_tx_date time.Time
+	err := row.Scan(&first_tx_date)
+	return first_tx_date, err
+}
+
+const getUserTotalIncome = `-- name: GetUserTotalIncome :one
+SELECT COALESCE(SUM(converted_transaction_lines.amount), 0)::numeric AS total_income
+FROM converted_transaction_lines
 INNER JOIN categories
-ON categories.id = transactions.category_id
+ON categories.id = converted_transaction_lines.category_id
+WHERE categories.user_id = $1
+AND categories.is_income = true
+AND converted_transaction_lines.tx_date < $2
+AND converted_transaction_lines.transfer_id IS NULL
+`
+
+type GetUserTotalIncomeParams struct {
+	UserID  uuid.UUID
+	EndDate time.Time
+}
+
+func (q *Queries) GetUserTotalIncome(ctx context.Context, arg GetUserTotalIncomeParams) (decimal.Decimal, error) {
+	row := q.db.QueryRowContext(ctx, getUserTotalIncome, arg.UserID, arg.EndDate)
+	var total_income decimal.Decimal
+	err := row.Scan(&total_income)
+	return total_income, err
+}
+
+const getUserTransactionIDs = `-- name: GetUserTransactionIDs :many
+SELECT transactions.id
+FROM transactions
+INNER JOIN accounts
+ON accounts.id = transactions.account_id
 WHERE accounts.user_id = $1
-ORDER BY transactions.tx_date DESC
+AND transactions.id = ANY($2::uuid[])
 `
 
-type GetUserTransactionsRow struct {
+type GetUserTransactionIDsParams struct {
+	UserID         uuid.UUID
+	TransactionIds []uuid.UUID
+}
+
+func (q *Queries) GetUserTransactionIDs(ctx context.Context, arg GetUserTransactionIDsParams) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, getUserTransactionIDs, arg.UserID, pq.Array(arg.TransactionIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserTransactionSplits = `-- name: GetUserTransactionSplits :many
+SELECT transaction_splits.id, transaction_splits.transaction_id, transaction_splits.category_id, transaction_splits.amount, transaction_splits.memo, transaction_splits.sort_order, transaction_splits.created_at, transaction_splits.updated_at,
+categories.category_name
+FROM transaction_splits
+INNER JOIN transactions
+ON transactions.id = transaction_splits.transaction_id
+INNER JOIN accounts
+ON accounts.id = transactions.account_id
+LEFT JOIN categories
+ON categories.id = transaction_splits.category_id
+WHERE accounts.user_id = $1
+ORDER BY transaction_splits.transaction_id, transaction_splits.sort_order
+`
+
+type GetUserTransactionSplitsRow struct {
 	ID            uuid.UUID
+	TransactionID uuid.UUID
+	CategoryID    uuid.NullUUID
 	Amount        decimal.Decimal
-	TxDescription string
-	TxDate        time.Time
+	Memo          string
+	SortOrder     int32
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
-	Posted        bool
-	AccountID     uuid.UUID
-	CategoryID    uuid.NullUUID
-	AccountName   string
-	CategoryName  string
+	CategoryName  sql.NullString
+}
+
+func (q *Queries) GetUserTransactionSplits(ctx context.Context, userID uuid.UUID) ([]GetUserTransactionSplitsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getUserTransactionSplits, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserTransactionSplitsRow
+	for rows.Next() {
+		var i GetUserTransactionSplitsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TransactionID,
+			&i.CategoryID,
+			&i.Amount,
+			&i.Memo,
+			&i.SortOrder,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.CategoryName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserTransactions = `-- name: GetUserTransactions :many
+SELECT transactions.id, transactions.amount, transactions.tx_description, transactions.tx_date, transactions.created_at, transactions.updated_at, transactions.posted, transactions.account_id, transactions.category_id, transactions.transfer_id, transactions.scheduled_id, transactions.scheduled_date, transactions.payee_id, transactions.reconciliation_id, transactions.memo,
+accounts.account_name,
+categories.category_name,
+payees.payee_name
+FROM transactions
+INNER JOIN accounts
+ON accounts.id = transactions.account_id
+LEFT JOIN categories
+ON categories.id = transactions.category_id
+LEFT JOIN payees
+ON payees.id = transactions.payee_id
+WHERE accounts.user_id = $1
+AND ($2::timestamp IS NULL OR transactions.tx_date >= $2)
+AND ($3::timestamp IS NULL OR transactions.tx_date < $3)
+AND ($4::numeric IS NULL OR transactions.amount >= $4)
+AND ($5::numeric IS NULL OR transactions.amount <= $5)
+AND ($6::uuid[] IS NULL OR transactions.account_id = ANY($6::uuid[]))
+AND (
+    $7::uuid[] IS NULL
+    OR transactions.category_id = ANY($7::uuid[])
+    OR EXISTS (
+        SELECT 1 FROM transaction_splits
+        WHERE transaction_splits.transaction_id = transactions.id
+        AND transaction_splits.category_id = ANY($7::uuid[])
+    )
+)
+AND (
+    $8::uuid IS NULL
+    OR categories.group_id = $8
+    OR EXISTS (
+        SELECT 1 FROM transaction_splits
+        INNER JOIN categories AS split_categories
+        ON split_categories.id = transaction_splits.category_id
+        WHERE transaction_splits.transaction_id = transactions.id
+        AND split_categories.group_id = $8
+    )
+)
+AND ($9::boolean IS NULL OR transactions.posted = $9)
+AND (
+    $10::text IS NULL
+    OR transactions.tx_description ILIKE '%' || $10 || '%'
+    OR payees.payee_name ILIKE '%' || $10 || '%'
+)
+AND (
+    $11::uuid[] IS NULL
+    OR EXISTS (
+        SELECT 1 FROM transaction_tags
+        WHERE transaction_tags.transaction_id = transactions.id
+        AND transaction_tags.tag_id = ANY($11::uuid[])
+    )
+)
+AND (
+    $12::timestamp IS NULL
+    OR (transactions.tx_date, transactions.id) < ($12, $13::uuid)
+)
+ORDER BY transactions.tx_date DESC, transactions.id DESC
+LIMIT $14
+`
+
+type GetUserTransactionsParams struct {
+	UserID      uuid.UUID
+	StartDate   sql.NullTime
+	EndDate     sql.NullTime
+	MinAmount   decimal.NullDecimal
+	MaxAmount   decimal.NullDecimal
+	AccountIds  []uuid.UUID
+	CategoryIds []uuid.UUID
+	GroupID     uuid.NullUUID
+	Posted      sql.NullBool
+	Search      sql.NullString
+	TagIds      []uuid.UUID
+	CursorDate  sql.NullTime
+	CursorID    uuid.NullUUID
+	RowLimit    int32
 }
 
-func (q *Queries) GetUserTransactions(ctx context.Context, userID uuid.UUID) ([]GetUserTransactionsRow, error) {
-	rows, err := q.db.QueryContext(ctx, getUserTransactions, userID)
+type GetUserTransactionsRow struct {
+	ID               uuid.UUID
+	Amount           decimal.Decimal
+	TxDescription    string
+	TxDate           time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	Posted           bool
+	AccountID        uuid.UUID
+	CategoryID       uuid.NullUUID
+	TransferID       uuid.NullUUID
+	ScheduledID      uuid.NullUUID
+	ScheduledDate    sql.NullTime
+	PayeeID          uuid.NullUUID
+	ReconciliationID uuid.NullUUID
+	Memo             string
+	AccountName      string
+	CategoryName     sql.NullString
+	PayeeName        sql.NullString
+}
+
+func (q *Queries) GetUserTransactions(ctx context.Context, arg GetUserTransactionsParams) ([]GetUserTransactionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getUserTransactions,
+		arg.UserID,
+		arg.StartDate,
+		arg.EndDate,
+		arg.MinAmount,
+		arg.MaxAmount,
+		pq.Array(arg.AccountIds),
+		pq.Array(arg.CategoryIds),
+		arg.GroupID,
+		arg.Posted,
+		arg.Search,
+		pq.Array(arg.TagIds),
+		arg.CursorDate,
+		arg.CursorID,
+		arg.RowLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -255,8 +763,128 @@ func (q *Queries) GetUserTransactions(ctx context.Context, userID uuid.UUID) ([]
 			&i.Posted,
 			&i.AccountID,
 			&i.CategoryID,
+			&i.TransferID,
+			&i.ScheduledID,
+			&i.ScheduledDate,
+			&i.PayeeID,
+			&i.ReconciliationID,
+			&i.Memo,
+			&i.AccountName,
+			&i.CategoryName,
+			&i.PayeeName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserTransactionsExportPage = `-- name: GetUserTransactionsExportPage :many
+SELECT transactions.id, transactions.amount, transactions.tx_description, transactions.tx_date, transactions.created_at, transactions.updated_at, transactions.posted, transactions.account_id, transactions.category_id, transactions.transfer_id, transactions.scheduled_id, transactions.scheduled_date, transactions.payee_id, transactions.reconciliation_id, transactions.memo,
+accounts.account_name,
+categories.category_name,
+payees.payee_name
+FROM transactions
+INNER JOIN accounts
+ON accounts.id = transactions.account_id
+LEFT JOIN categories
+ON categories.id = transactions.category_id
+LEFT JOIN payees
+ON payees.id = transactions.payee_id
+WHERE accounts.user_id = $1
+AND ($2::timestamp IS NULL OR transactions.tx_date >= $2)
+AND ($3::timestamp IS NULL OR transactions.tx_date < $3)
+AND ($4::uuid IS NULL OR transactions.account_id = $4)
+AND (
+    $5::uuid IS NULL
+    OR transactions.category_id = $5
+    OR EXISTS (
+        SELECT 1 FROM transaction_splits
+        WHERE transaction_splits.transaction_id = transactions.id
+        AND transaction_splits.category_id = $5
+    )
+)
+AND (transactions.tx_date, transactions.id) > ($6::timestamp, $7::uuid)
+ORDER BY transactions.tx_date, transactions.id
+LIMIT $8
+`
+
+type GetUserTransactionsExportPageParams struct {
+	UserID     uuid.UUID
+	StartDate  sql.NullTime
+	EndDate    sql.NullTime
+	AccountID  uuid.NullUUID
+	CategoryID uuid.NullUUID
+	AfterDate  time.Time
+	AfterID    uuid.UUID
+	RowLimit   int32
+}
+
+type GetUserTransactionsExportPageRow struct {
+	ID               uuid.UUID
+	Amount           decimal.Decimal
+	TxDescription    string
+	TxDate           time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	Posted           bool
+	AccountID        uuid.UUID
+	CategoryID       uuid.NullUUID
+	TransferID       uuid.NullUUID
+	ScheduledID      uuid.NullUUID
+	ScheduledDate    sql.NullTime
+	PayeeID          uuid.NullUUID
+	ReconciliationID uuid.NullUUID
+	Memo             string
+	AccountName      string
+	CategoryName     sql.NullString
+	PayeeName        sql.NullString
+}
+
+func (q *Queries) GetUserTransactionsExportPage(ctx context.Context, arg GetUserTransactionsExportPageParams) ([]GetUserTransactionsExportPageRow, error) {
+	rows, err := q.db.QueryContext(ctx, getUserTransactionsExportPage,
+		arg.UserID,
+		arg.StartDate,
+		arg.EndDate,
+		arg.AccountID,
+		arg.CategoryID,
+		arg.AfterDate,
+		arg.AfterID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserTransactionsExportPageRow
+	for rows.Next() {
+		var i GetUserTransactionsExportPageRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Amount,
+			&i.TxDescription,
+			&i.TxDate,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Posted,
+			&i.AccountID,
+			&i.CategoryID,
+			&i.TransferID,
+			&i.ScheduledID,
+			&i.ScheduledDate,
+			&i.PayeeID,
+			&i.ReconciliationID,
+			&i.Memo,
 			&i.AccountName,
 			&i.CategoryName,
+			&i.PayeeName,
 		); err != nil {
 			return nil, err
 		}
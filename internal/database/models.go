@@ -5,6 +5,7 @@
 package database
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,16 +19,62 @@ type Account struct {
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	UserID      uuid.UUID
+	Currency    string
 }
 
 type Category struct {
+	ID                   uuid.UUID
+	CategoryName         string
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+	Budget               decimal.Decimal
+	UserID               uuid.UUID
+	GroupID              uuid.NullUUID
+	RolloverOverspending bool
+	IsIncome             bool
+}
+
+type CategoryBudget struct {
+	CategoryID uuid.UUID
+	Month      time.Time
+	Assigned   decimal.Decimal
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type ConvertedTransactionLine struct {
+	TransactionID uuid.UUID
+	CategoryID    uuid.NullUUID
+	Amount        decimal.NullDecimal
+	TxDate        time.Time
+	AccountID     uuid.UUID
+	TransferID    uuid.NullUUID
+	Currency      string
+}
+
+type CsvImportMapping struct {
+	AccountID         uuid.UUID
+	SkipRows          int32
+	DateColumn        string
+	DateFormat        string
+	AmountColumn      string
+	DebitColumn       string
+	CreditColumn      string
+	DescriptionColumn string
+	InvertAmounts     bool
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+type ExchangeRate struct {
 	ID           uuid.UUID
-	CategoryName string
+	FromCurrency string
+	ToCurrency   string
+	Rate         decimal.Decimal
+	RateDate     time.Time
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
-	Budget       decimal.Decimal
 	UserID       uuid.UUID
-	GroupID      uuid.NullUUID
 }
 
 type Group struct {
@@ -38,22 +85,145 @@ type Group struct {
 	UserID    uuid.UUID
 }
 
-type Transaction struct {
+type ImportDuplicate struct {
+	ID                   uuid.UUID
+	Amount               decimal.Decimal
+	TxDescription        string
+	TxDate               time.Time
+	Fitid                sql.NullString
+	CreatedAt            time.Time
+	UserID               uuid.UUID
+	AccountID            uuid.UUID
+	MatchedTransactionID uuid.NullUUID
+}
+
+type Payee struct {
+	ID                uuid.UUID
+	PayeeName         string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	UserID            uuid.UUID
+	DefaultCategoryID uuid.NullUUID
+}
+
+type Reconciliation struct {
+	ID               uuid.UUID
+	StatementDate    time.Time
+	StatementBalance decimal.Decimal
+	TransactionCount int32
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	AccountID        uuid.UUID
+}
+
+type Rule struct {
+	ID                  uuid.UUID
+	RuleName            string
+	SortOrder           int32
+	DescriptionContains string
+	DescriptionRegex    string
+	MinAmount           decimal.NullDecimal
+	MaxAmount           decimal.NullDecimal
+	AccountID           uuid.NullUUID
+	SetCategoryID       uuid.NullUUID
+	SetDescription      string
+	SetPosted           sql.NullBool
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	UserID              uuid.UUID
+}
+
+type ScheduledTransaction struct {
+	ID              uuid.UUID
+	Amount          decimal.Decimal
+	TxDescription   string
+	Frequency       string
+	DayOfMonth      int32
+	StartDate       time.Time
+	EndDate         sql.NullTime
+	OccurrenceCount int32
+	LastDate        sql.NullTime
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	UserID          uuid.UUID
+	AccountID       uuid.UUID
+	CategoryID      uuid.NullUUID
+}
+
+type Tag struct {
+	ID        uuid.UUID
+	TagName   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	UserID    uuid.UUID
+}
+
+type TransactionAttachment struct {
 	ID            uuid.UUID
-	Amount        decimal.Decimal
-	TxDescription string
-	TxDate        time.Time
+	FileName      string
+	ContentType   string
+	SizeBytes     int64
+	Checksum      string
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
-	Posted        bool
+	TransactionID uuid.UUID
+}
+
+type TransactionImport struct {
+	TransactionID uuid.UUID
+	AccountID     uuid.UUID
+	Fitid         string
+	CreatedAt     time.Time
+}
+
+type TransactionLine struct {
+	TransactionID uuid.UUID
+	CategoryID    uuid.NullUUID
+	Amount        decimal.Decimal
+	TxDate        time.Time
 	AccountID     uuid.UUID
+	TransferID    uuid.NullUUID
+}
+
+type TransactionSplit struct {
+	ID            uuid.UUID
+	TransactionID uuid.UUID
 	CategoryID    uuid.NullUUID
+	Amount        decimal.Decimal
+	Memo          string
+	SortOrder     int32
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type TransactionTag struct {
+	TransactionID uuid.UUID
+	TagID         uuid.UUID
+}
+
+type Transaction struct {
+	ID               uuid.UUID
+	Amount           decimal.Decimal
+	TxDescription    string
+	TxDate           time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	Posted           bool
+	AccountID        uuid.UUID
+	CategoryID       uuid.NullUUID
+	TransferID       uuid.NullUUID
+	ScheduledID      uuid.NullUUID
+	ScheduledDate    sql.NullTime
+	PayeeID          uuid.NullUUID
+	ReconciliationID uuid.NullUUID
+	Memo             string
 }
 
 type User struct {
-	ID        uuid.UUID
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	Username  string
-	HashedPw  string
+	ID           uuid.UUID
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	Username     string
+	HashedPw     string
+	BaseCurrency string
 }
@@ -7,6 +7,7 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,7 +15,7 @@ import (
 )
 
 const addTransaction = `-- name: AddTransaction :one
-INSERT INTO transactions (id, amount, tx_description, tx_date, created_at, updated_at, posted, account_id, category_id)
+INSERT INTO transactions (id, amount, tx_description, tx_date, created_at, updated_at, posted, account_id, category_id, transfer_id, payee_id, memo)
 VALUES (
     $1,
     $2,
@@ -24,9 +25,12 @@ VALUES (
     $6,
     $7,
     $8,
-    $9
+    $9,
+    $10,
+    $11,
+    $12
 )
-RETURNING id, amount, tx_description, tx_date, created_at, updated_at, posted, account_id, category_id
+RETURNING id, amount, tx_description, tx_date, created_at, updated_at, posted, account_id, category_id, transfer_id, scheduled_id, scheduled_date, payee_id, reconciliation_id, memo
 `
 
 type AddTransactionParams struct {
@@ -39,6 +43,9 @@ type AddTransactionParams struct {
 	Posted        bool
 	AccountID     uuid.UUID
 	CategoryID    uuid.NullUUID
+	TransferID    uuid.NullUUID
+	PayeeID       uuid.NullUUID
+	Memo          string
 }
 
 func (q *Queries) AddTransaction(ctx context.Context, arg AddTransactionParams) (Transaction, error) {
@@ -52,6 +59,9 @@ func (q *Queries) AddTransaction(ctx context.Context, arg AddTransactionParams)
 		arg.Posted,
 		arg.AccountID,
 		arg.CategoryID,
+		arg.TransferID,
+		arg.PayeeID,
+		arg.Memo,
 	)
 	var i Transaction
 	err := row.Scan(
@@ -64,6 +74,12 @@ func (q *Queries) AddTransaction(ctx context.Context, arg AddTransactionParams)
 		&i.Posted,
 		&i.AccountID,
 		&i.CategoryID,
+		&i.TransferID,
+		&i.ScheduledID,
+		&i.ScheduledDate,
+		&i.PayeeID,
+		&i.ReconciliationID,
+		&i.Memo,
 	)
 	return i, err
 }
@@ -78,8 +94,57 @@ func (q *Queries) DeleteTransaction(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const deleteTransfer = `-- name: DeleteTransfer :exec
+DELETE FROM transactions
+WHERE transfer_id = $1
+`
+
+func (q *Queries) DeleteTransfer(ctx context.Context, transferID uuid.NullUUID) error {
+	_, err := q.db.ExecContext(ctx, deleteTransfer, transferID)
+	return err
+}
+
+const getAccountBalanceBefore = `-- name: GetAccountBalanceBefore :one
+SELECT COALESCE(SUM(amount), 0)::numeric AS balance
+FROM transactions
+WHERE account_id = $1
+AND tx_date < $2
+`
+
+type GetAccountBalanceBeforeParams struct {
+	AccountID  uuid.UUID
+	BeforeDate time.Time
+}
+
+func (q *Queries) GetAccountBalanceBefore(ctx context.Context, arg GetAccountBalanceBeforeParams) (decimal.Decimal, error) {
+	row := q.db.QueryRowContext(ctx, getAccountBalanceBefore, arg.AccountID, arg.BeforeDate)
+	var balance decimal.Decimal
+	err := row.Scan(&balance)
+	return balance, err
+}
+
+const getAccountClearedBalance = `-- name: GetAccountClearedBalance :one
+SELECT COALESCE(SUM(amount), 0)::numeric AS cleared_balance
+FROM transactions
+WHERE account_id = $1
+AND posted = true
+AND tx_date < $2
+`
+
+type GetAccountClearedBalanceParams struct {
+	AccountID uuid.UUID
+	EndDate   time.Time
+}
+
+func (q *Queries) GetAccountClearedBalance(ctx context.Context, arg GetAccountClearedBalanceParams) (decimal.Decimal, error) {
+	row := q.db.QueryRowContext(ctx, getAccountClearedBalance, arg.AccountID, arg.EndDate)
+	var cleared_balance decimal.Decimal
+	err := row.Scan(&cleared_balance)
+	return cleared_balance, err
+}
+
 const getTransactionByID = `-- name: GetTransactionByID :one
-SELECT id, amount, tx_description, tx_date, created_at, updated_at, posted, account_id, category_id FROM transactions
+SELECT id, amount, tx_description, tx_date, created_at, updated_at, posted, account_id, category_id, transfer_id, scheduled_id, scheduled_date, payee_id, reconciliation_id, memo FROM transactions
 WHERE id = $1
 `
 
@@ -96,18 +161,91 @@ func (q *Queries) GetTransactionByID(ctx context.Context, id uuid.UUID) (Transac
 		&i.Posted,
 		&i.AccountID,
 		&i.CategoryID,
+		&i.TransferID,
+		&i.ScheduledID,
+		&i.ScheduledDate,
+		&i.PayeeID,
+		&i.ReconciliationID,
+		&i.Memo,
 	)
 	return i, err
 }
 
 const getTransactionsByAccount = `-- name: GetTransactionsByAccount :many
-SELECT id, amount, tx_description, tx_date, created_at, updated_at, posted, account_id, category_id FROM transactions
+SELECT id, amount, tx_description, tx_date, created_at, updated_at, posted, account_id, category_id, transfer_id, scheduled_id, scheduled_date, payee_id, reconciliation_id, memo FROM transactions
+WHERE account_id = $1
+AND ($2::timestamp IS NULL OR (tx_date, id) < ($2, $3::uuid))
+ORDER BY tx_date DESC, id DESC
+LIMIT $4
+`
+
+type GetTransactionsByAccountParams struct {
+	AccountID  uuid.UUID
+	CursorDate sql.NullTime
+	CursorID   uuid.NullUUID
+	RowLimit   int32
+}
+
+func (q *Queries) GetTransactionsByAccount(ctx context.Context, arg GetTransactionsByAccountParams) ([]Transaction, error) {
+	rows, err := q.db.QueryContext(ctx, getTransactionsByAccount,
+		arg.AccountID,
+		arg.CursorDate,
+		arg.CursorID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Transaction
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.Amount,
+			&i.TxDescription,
+			&i.TxDate,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Posted,
+			&i.AccountID,
+			&i.CategoryID,
+			&i.TransferID,
+			&i.ScheduledID,
+			&i.ScheduledDate,
+			&i.PayeeID,
+			&i.ReconciliationID,
+			&i.Memo,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTransactionsByAccountBetween = `-- name: GetTransactionsByAccountBetween :many
+SELECT id, amount, tx_description, tx_date, created_at, updated_at, posted, account_id, category_id, transfer_id, scheduled_id, scheduled_date, payee_id, reconciliation_id, memo FROM transactions
 WHERE account_id = $1
-ORDER BY tx_date::date DESC, tx_date DESC
+AND tx_date >= $2
+AND tx_date < $3
+ORDER BY tx_date
 `
 
-func (q *Queries) GetTransactionsByAccount(ctx context.Context, accountID uuid.UUID) ([]Transaction, error) {
-	rows, err := q.db.QueryContext(ctx, getTransactionsByAccount, accountID)
+type GetTransactionsByAccountBetweenParams struct {
+	AccountID uuid.UUID
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+func (q *Queries) GetTransactionsByAccountBetween(ctx context.Context, arg GetTransactionsByAccountBetweenParams) ([]Transaction, error) {
+	rows, err := q.db.QueryContext(ctx, getTransactionsByAccountBetween, arg.AccountID, arg.StartDate, arg.EndDate)
 	if err != nil {
 		return nil, err
 	}
@@ -125,6 +263,12 @@ func (q *Queries) GetTransactionsByAccount(ctx context.Context, accountID uuid.U
 			&i.Posted,
 			&i.AccountID,
 			&i.CategoryID,
+			&i.TransferID,
+			&i.ScheduledID,
+			&i.ScheduledDate,
+			&i.PayeeID,
+			&i.ReconciliationID,
+			&i.Memo,
 		); err != nil {
 			return nil, err
 		}
@@ -140,13 +284,30 @@ func (q *Queries) GetTransactionsByAccount(ctx context.Context, accountID uuid.U
 }
 
 const getTransactionsByCategory = `-- name: GetTransactionsByCategory :many
-SELECT id, amount, tx_description, tx_date, created_at, updated_at, posted, account_id, category_id FROM transactions
-WHERE category_id = $1
-ORDER BY tx_date::date DESC, tx_date DESC
+SELECT id, amount, tx_description, tx_date, created_at, updated_at, posted, account_id, category_id, transfer_id, scheduled_id, scheduled_date, payee_id, reconciliation_id, memo FROM transactions
+WHERE id IN (
+    SELECT transaction_id FROM transaction_lines
+    WHERE transaction_lines.category_id = $1
+)
+AND ($2::timestamp IS NULL OR (tx_date, id) < ($2, $3::uuid))
+ORDER BY tx_date DESC, id DESC
+LIMIT $4
 `
 
-func (q *Queries) GetTransactionsByCategory(ctx context.Context, categoryID uuid.NullUUID) ([]Transaction, error) {
-	rows, err := q.db.QueryContext(ctx, getTransactionsByCategory, categoryID)
+type GetTransactionsByCategoryParams struct {
+	CategoryID uuid.NullUUID
+	CursorDate sql.NullTime
+	CursorID   uuid.NullUUID
+	RowLimit   int32
+}
+
+func (q *Queries) GetTransactionsByCategory(ctx context.Context, arg GetTransactionsByCategoryParams) ([]Transaction, error) {
+	rows, err := q.db.QueryContext(ctx, getTransactionsByCategory,
+		arg.CategoryID,
+		arg.CursorDate,
+		arg.CursorID,
+		arg.RowLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -164,6 +325,12 @@ func (q *Queries) GetTransactionsByCategory(ctx context.Context, categoryID uuid
 			&i.Posted,
 			&i.AccountID,
 			&i.CategoryID,
+			&i.TransferID,
+			&i.ScheduledID,
+			&i.ScheduledDate,
+			&i.PayeeID,
+			&i.ReconciliationID,
+			&i.Memo,
 		); err != nil {
 			return nil, err
 		}
@@ -178,6 +345,136 @@ func (q *Queries) GetTransactionsByCategory(ctx context.Context, categoryID uuid
 	return items, nil
 }
 
+const getTransferCounterpart = `-- name: GetTransferCounterpart :one
+SELECT id, amount, tx_description, tx_date, created_at, updated_at, posted, account_id, category_id, transfer_id, scheduled_id, scheduled_date, payee_id, reconciliation_id, memo FROM transactions
+WHERE transfer_id = $1
+AND id <> $2
+`
+
+type GetTransferCounterpartParams struct {
+	TransferID uuid.NullUUID
+	ID         uuid.UUID
+}
+
+func (q *Queries) GetTransferCounterpart(ctx context.Context, arg GetTransferCounterpartParams) (Transaction, error) {
+	row := q.db.QueryRowContext(ctx, getTransferCounterpart, arg.TransferID, arg.ID)
+	var i Transaction
+	err := row.Scan(
+		&i.ID,
+		&i.Amount,
+		&i.TxDescription,
+		&i.TxDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Posted,
+		&i.AccountID,
+		&i.CategoryID,
+		&i.TransferID,
+		&i.ScheduledID,
+		&i.ScheduledDate,
+		&i.PayeeID,
+		&i.ReconciliationID,
+		&i.Memo,
+	)
+	return i, err
+}
+
+const reconcileAccountTransactions = `-- name: ReconcileAccountTransactions :execrows
+UPDATE transactions
+SET reconciliation_id = $1,
+updated_at = NOW()
+WHERE account_id = $2
+AND posted = true
+AND reconciliation_id IS NULL
+AND tx_date < $3
+`
+
+type ReconcileAccountTransactionsParams struct {
+	ReconciliationID uuid.NullUUID
+	AccountID        uuid.UUID
+	EndDate          time.Time
+}
+
+func (q *Queries) ReconcileAccountTransactions(ctx context.Context, arg ReconcileAccountTransactionsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, reconcileAccountTransactions, arg.ReconciliationID, arg.AccountID, arg.EndDate)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreTransaction = `-- name: RestoreTransaction :exec
+INSERT INTO transactions (id, amount, tx_description, tx_date, created_at, updated_at, posted, account_id, category_id, transfer_id, scheduled_id, scheduled_date, payee_id, reconciliation_id, memo)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9,
+    $10,
+    $11,
+    $12,
+    $13,
+    $14,
+    $15
+)
+`
+
+type RestoreTransactionParams struct {
+	ID               uuid.UUID
+	Amount           decimal.Decimal
+	TxDescription    string
+	TxDate           time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	Posted           bool
+	AccountID        uuid.UUID
+	CategoryID       uuid.NullUUID
+	TransferID       uuid.NullUUID
+	ScheduledID      uuid.NullUUID
+	ScheduledDate    sql.NullTime
+	PayeeID          uuid.NullUUID
+	ReconciliationID uuid.NullUUID
+	Memo             string
+}
+
+func (q *Queries) RestoreTransaction(ctx context.Context, arg RestoreTransactionParams) error {
+	_, err := q.db.ExecContext(ctx, restoreTransaction,
+		arg.ID,
+		arg.Amount,
+		arg.TxDescription,
+		arg.TxDate,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+		arg.Posted,
+		arg.AccountID,
+		arg.CategoryID,
+		arg.TransferID,
+		arg.ScheduledID,
+		arg.ScheduledDate,
+		arg.PayeeID,
+		arg.ReconciliationID,
+		arg.Memo,
+	)
+	return err
+}
+
+const setTransactionPosted = `-- name: SetTransactionPosted :exec
+UPDATE transactions
+SET posted = true,
+updated_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) SetTransactionPosted(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, setTransactionPosted, id)
+	return err
+}
+
 const updateTransaction = `-- name: UpdateTransaction :one
 UPDATE transactions
 SET amount = $2,
@@ -186,9 +483,11 @@ tx_date = $4,
 updated_at = NOW(),
 posted = $5,
 account_id = $6,
-category_id = $7
+category_id = $7,
+payee_id = $8,
+memo = $9
 WHERE id = $1
-RETURNING id, amount, tx_description, tx_date, created_at, updated_at, posted, account_id, category_id
+RETURNING id, amount, tx_description, tx_date, created_at, updated_at, posted, account_id, category_id, transfer_id, scheduled_id, scheduled_date, payee_id, reconciliation_id, memo
 `
 
 type UpdateTransactionParams struct {
@@ -199,6 +498,8 @@ type UpdateTransactionParams struct {
 	Posted        bool
 	AccountID     uuid.UUID
 	CategoryID    uuid.NullUUID
+	PayeeID       uuid.NullUUID
+	Memo          string
 }
 
 func (q *Queries) UpdateTransaction(ctx context.Context, arg UpdateTransactionParams) (Transaction, error) {
@@ -210,6 +511,8 @@ func (q *Queries) UpdateTransaction(ctx context.Context, arg UpdateTransactionPa
 		arg.Posted,
 		arg.AccountID,
 		arg.CategoryID,
+		arg.PayeeID,
+		arg.Memo,
 	)
 	var i Transaction
 	err := row.Scan(
@@ -222,6 +525,48 @@ func (q *Queries) UpdateTransaction(ctx context.Context, arg UpdateTransactionPa
 		&i.Posted,
 		&i.AccountID,
 		&i.CategoryID,
+		&i.TransferID,
+		&i.ScheduledID,
+		&i.ScheduledDate,
+		&i.PayeeID,
+		&i.ReconciliationID,
+		&i.Memo,
+	)
+	return i, err
+}
+
+const updateTransactionPosted = `-- name: UpdateTransactionPosted :one
+UPDATE transactions
+SET posted = $2,
+updated_at = NOW()
+WHERE id = $1
+RETURNING id, amount, tx_description, tx_date, created_at, updated_at, posted, account_id, category_id, transfer_id, scheduled_id, scheduled_date, payee_id, reconciliation_id, memo
+`
+
+type UpdateTransactionPostedParams struct {
+	ID     uuid.UUID
+	Posted bool
+}
+
+func (q *Queries) UpdateTransactionPosted(ctx context.Context, arg UpdateTransactionPostedParams) (Transaction, error) {
+	row := q.db.QueryRowContext(ctx, updateTransactionPosted, arg.ID, arg.Posted)
+	var i Transaction
+	err := row.Scan(
+		&i.ID,
+		&i.Amount,
+		&i.TxDescription,
+		&i.TxDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Posted,
+		&i.AccountID,
+		&i.CategoryID,
+		&i.TransferID,
+		&i.ScheduledID,
+		&i.ScheduledDate,
+		&i.PayeeID,
+		&i.ReconciliationID,
+		&i.Memo,
 	)
 	return i, err
 }
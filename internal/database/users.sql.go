@@ -21,7 +21,7 @@ VALUES (
     $4,
     $5
 )
-RETURNING id, created_at, updated_at, username, hashed_pw
+RETURNING id, created_at, updated_at, username, hashed_pw, base_currency
 `
 
 type CreateUserParams struct {
@@ -47,12 +47,32 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.UpdatedAt,
 		&i.Username,
 		&i.HashedPw,
+		&i.BaseCurrency,
+	)
+	return i, err
+}
+
+const getUserByID = `-- name: GetUserByID :one
+SELECT id, created_at, updated_at, username, hashed_pw, base_currency FROM users
+WHERE id = $1
+`
+
+func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByID, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Username,
+		&i.HashedPw,
+		&i.BaseCurrency,
 	)
 	return i, err
 }
 
 const getUserByUsername = `-- name: GetUserByUsername :one
-SELECT id, created_at, updated_at, username, hashed_pw FROM users
+SELECT id, created_at, updated_at, username, hashed_pw, base_currency FROM users
 WHERE username = $1
 `
 
@@ -65,6 +85,34 @@ func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User,
 		&i.UpdatedAt,
 		&i.Username,
 		&i.HashedPw,
+		&i.BaseCurrency,
+	)
+	return i, err
+}
+
+const updateUserBaseCurrency = `-- name: UpdateUserBaseCurrency :one
+UPDATE users
+SET base_currency = $2,
+updated_at = NOW()
+WHERE id = $1
+RETURNING id, created_at, updated_at, username, hashed_pw, base_currency
+`
+
+type UpdateUserBaseCurrencyParams struct {
+	ID           uuid.UUID
+	BaseCurrency string
+}
+
+func (q *Queries) UpdateUserBaseCurrency(ctx context.Context, arg UpdateUserBaseCurrencyParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserBaseCurrency, arg.ID, arg.BaseCurrency)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Username,
+		&i.HashedPw,
+		&i.BaseCurrency,
 	)
 	return i, err
 }
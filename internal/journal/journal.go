@@ -0,0 +1,258 @@
+// Package journal writes plain-text accounting journals that ledger,
+// hledger and beancount can read.
+package journal
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jkk290/budget-tui/internal/accounttype"
+	"github.com/shopspring/decimal"
+)
+
+type Format string
+
+const (
+	FormatLedger    Format = "ledger"
+	FormatHledger   Format = "hledger"
+	FormatBeancount Format = "beancount"
+)
+
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case FormatLedger, FormatHledger, FormatBeancount:
+		return Format(s), nil
+	}
+	return "", fmt.Errorf("unknown journal format %q, expected ledger, hledger or beancount", s)
+}
+
+// Account is a journal account split into its components, so
+// {"Expenses", "Food", "Groceries"} is written as Expenses:Food:Groceries.
+type Account []string
+
+var (
+	OpeningBalances       = Account{"Equity", "Opening Balances"}
+	UnmatchedTransfers    = Account{"Equity", "Transfers"}
+	UncategorizedExpenses = Account{"Expenses", "Uncategorized"}
+	UncategorizedIncome   = Account{"Income", "Uncategorized"}
+)
+
+// AssetAccount names an account under Assets, or Liabilities for credit
+// cards, loans and other liability types.
+func AssetAccount(accountName string, accountType accounttype.Type) Account {
+	if accountType.IsLiability() {
+		return Account{"Liabilities", accountName}
+	}
+	return Account{"Assets", accountName}
+}
+
+// CategoryAccount names a category under Expenses, or Income for income
+// categories, with its group in between when it has one.
+func CategoryAccount(groupName, categoryName string, isIncome bool) Account {
+	root := "Expenses"
+	if isIncome {
+		root = "Income"
+	}
+	if groupName == "" {
+		return Account{root, categoryName}
+	}
+	return Account{root, groupName, categoryName}
+}
+
+// Posting moves Amount in or out of Account. An empty Currency means the
+// writer's currency.
+type Posting struct {
+	Account  Account
+	Amount   decimal.Decimal
+	Currency string
+}
+
+type Transaction struct {
+	ID          string
+	Date        time.Time
+	Cleared     bool
+	Payee       string
+	Description string
+	Postings    []Posting
+}
+
+// Writer writes a journal one entry at a time. The first error is kept
+// and returned from every later call.
+type Writer struct {
+	w        *bufio.Writer
+	format   Format
+	currency string
+	err      error
+}
+
+func NewWriter(w io.Writer, format Format, currency string) *Writer {
+	return &Writer{
+		w:        bufio.NewWriter(w),
+		format:   format,
+		currency: currency,
+	}
+}
+
+func (jw *Writer) printf(format string, args ...any) {
+	if jw.err != nil {
+		return
+	}
+	_, jw.err = fmt.Fprintf(jw.w, format, args...)
+}
+
+// Open declares every account the journal uses. Beancount needs each
+// account opened on or before its first posting, so date should be the
+// earliest transaction date.
+func (jw *Writer) Open(accounts []Account, date time.Time) error {
+	jw.printf("; Exported from BudgeTUI on %s\n\n", time.Now().Format(time.DateOnly))
+
+	if jw.format == FormatBeancount {
+		jw.printf("option \"operating_currency\" \"%s\"\n\n", jw.currency)
+		for _, account := range accounts {
+			jw.printf("%s open %s\n", date.Format(time.DateOnly), jw.accountName(account))
+		}
+	} else {
+		jw.printf("commodity %s\n\n", jw.currency)
+		for _, account := range accounts {
+			jw.printf("account %s\n", jw.accountName(account))
+		}
+	}
+	jw.printf("\n")
+
+	return jw.err
+}
+
+// Transaction writes one entry. Its postings must sum to zero in each
+// currency, except for an entry of two postings in different currencies,
+// such as a transfer between a dollar and a euro account, which is written
+// with the second amount as the first one's total price.
+func (jw *Writer) Transaction(tx Transaction) error {
+	flag := "!"
+	if tx.Cleared {
+		flag = "*"
+	}
+	payee := singleLine(tx.Payee)
+	description := singleLine(tx.Description)
+
+	if jw.format == FormatBeancount {
+		if payee != "" {
+			jw.printf("%s %s %s %s\n", tx.Date.Format(time.DateOnly), flag, quote(payee), quote(description))
+		} else {
+			jw.printf("%s %s %s\n", tx.Date.Format(time.DateOnly), flag, quote(description))
+		}
+		if tx.ID != "" {
+			jw.printf("  id: %s\n", quote(tx.ID))
+		}
+		for i, posting := range tx.Postings {
+			jw.printf("  %s  %s\n", jw.accountName(posting.Account), jw.amount(tx, i))
+		}
+	} else {
+		// hledger reads "payee | note"; ledger keeps it all as the payee.
+		if payee != "" {
+			description = payee + " | " + description
+		}
+		jw.printf("%s %s %s\n", tx.Date.Format(time.DateOnly), flag, description)
+		if tx.ID != "" {
+			jw.printf("    ; id: %s\n", tx.ID)
+		}
+		for i, posting := range tx.Postings {
+			jw.printf("    %s  %s\n", jw.accountName(posting.Account), jw.amount(tx, i))
+		}
+	}
+	jw.printf("\n")
+
+	return jw.err
+}
+
+// Flush writes any buffered output.
+func (jw *Writer) Flush() error {
+	if jw.err != nil {
+		return jw.err
+	}
+	jw.err = jw.w.Flush()
+	return jw.err
+}
+
+func (jw *Writer) postingCurrency(posting Posting) string {
+	if posting.Currency == "" {
+		return jw.currency
+	}
+	return posting.Currency
+}
+
+// amount formats the i'th posting of tx, with a total price when it is
+// the first of two postings in different currencies.
+func (jw *Writer) amount(tx Transaction, i int) string {
+	posting := tx.Postings[i]
+	amount := posting.Amount.StringFixed(2) + " " + jw.postingCurrency(posting)
+	if i == 0 && len(tx.Postings) == 2 {
+		other := tx.Postings[1]
+		if jw.postingCurrency(other) != jw.postingCurrency(posting) {
+			amount += " @@ " + other.Amount.Abs().StringFixed(2) + " " + jw.postingCurrency(other)
+		}
+	}
+	return amount
+}
+
+func (jw *Writer) accountName(account Account) string {
+	components := make([]string, len(account))
+	for i, component := range account {
+		if jw.format == FormatBeancount {
+			components[i] = beancountComponent(component)
+		} else {
+			components[i] = ledgerComponent(component)
+		}
+	}
+	return strings.Join(components, ":")
+}
+
+// ledgerComponent keeps names readable but drops colons, which separate
+// components, and runs of spaces, which end the account name.
+func ledgerComponent(name string) string {
+	name = strings.Join(strings.Fields(strings.ReplaceAll(name, ":", " ")), " ")
+	if name == "" {
+		return "Unnamed"
+	}
+	return name
+}
+
+// beancountComponent turns a name into a valid beancount account
+// component: letters, digits and dashes, starting with a capital letter
+// or digit.
+func beancountComponent(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteRune('-')
+			}
+			dash = false
+			b.WriteRune(r)
+			continue
+		}
+		dash = true
+	}
+
+	component := []rune(b.String())
+	if len(component) == 0 {
+		return "Unnamed"
+	}
+	component[0] = unicode.ToUpper(component[0])
+	if !unicode.IsUpper(component[0]) && !unicode.IsDigit(component[0]) {
+		return "X" + string(component)
+	}
+	return string(component)
+}
+
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
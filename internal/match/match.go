@@ -0,0 +1,120 @@
+package match
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const DefaultWindowDays = 3
+
+type Kind string
+
+const (
+	None Kind = ""
+	// Auto means the incoming transaction is the bank's copy of a single
+	// unposted transaction that was entered by hand or by a schedule.
+	Auto Kind = "auto"
+	// Duplicate means it looks like an existing transaction but the match
+	// is ambiguous, so the user has to confirm it.
+	Duplicate Kind = "duplicate"
+)
+
+type Candidate struct {
+	ID     uuid.UUID
+	Date   time.Time
+	Amount decimal.Decimal
+	Posted bool
+}
+
+type Result struct {
+	Kind          Kind
+	TransactionID uuid.UUID
+}
+
+// Matcher pairs incoming transactions with existing ones in the same
+// account. Each existing transaction is matched at most once, so two
+// identical purchases on a statement need two existing entries.
+type Matcher struct {
+	window     time.Duration
+	candidates []Candidate
+	claimed    map[uuid.UUID]bool
+}
+
+func New(candidates []Candidate, windowDays int) *Matcher {
+	return &Matcher{
+		window:     time.Duration(windowDays) * 24 * time.Hour,
+		candidates: candidates,
+		claimed:    map[uuid.UUID]bool{},
+	}
+}
+
+// Window returns the date range that can hold candidates for incoming
+// transactions between first and last.
+func Window(first, last time.Time, windowDays int) (time.Time, time.Time) {
+	start := day(first).AddDate(0, 0, -windowDays)
+	end := day(last).AddDate(0, 0, windowDays+1)
+	return start, end
+}
+
+func (m *Matcher) Match(date time.Time, amount decimal.Decimal) Result {
+	var closest, closestUnposted *Candidate
+	unposted := 0
+	for i := range m.candidates {
+		candidate := &m.candidates[i]
+		if m.claimed[candidate.ID] || !candidate.Amount.Equal(amount) {
+			continue
+		}
+		distance := absDuration(day(candidate.Date).Sub(day(date)))
+		if distance > m.window {
+			continue
+		}
+
+		if closest == nil || distance < absDuration(day(closest.Date).Sub(day(date))) {
+			closest = candidate
+		}
+		if !candidate.Posted {
+			unposted++
+			if closestUnposted == nil || distance < absDuration(day(closestUnposted.Date).Sub(day(date))) {
+				closestUnposted = candidate
+			}
+		}
+	}
+
+	if closest == nil {
+		return Result{Kind: None}
+	}
+	if unposted == 1 {
+		m.claimed[closestUnposted.ID] = true
+		return Result{Kind: Auto, TransactionID: closestUnposted.ID}
+	}
+	m.claimed[closest.ID] = true
+	return Result{Kind: Duplicate, TransactionID: closest.ID}
+}
+
+// Similar returns every unclaimed candidate that could be the same
+// transaction, without claiming any of them.
+func (m *Matcher) Similar(date time.Time, amount decimal.Decimal) []uuid.UUID {
+	similar := []uuid.UUID{}
+	for _, candidate := range m.candidates {
+		if m.claimed[candidate.ID] || !candidate.Amount.Equal(amount) {
+			continue
+		}
+		if absDuration(day(candidate.Date).Sub(day(date))) <= m.window {
+			similar = append(similar, candidate.ID)
+		}
+	}
+	return similar
+}
+
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
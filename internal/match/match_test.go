@@ -0,0 +1,176 @@
+package match
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func day15(offset int) time.Time {
+	return time.Date(2026, 1, 15+offset, 0, 0, 0, 0, time.UTC)
+}
+
+func amount(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func candidate(offset int, value string, posted bool) Candidate {
+	return Candidate{ID: uuid.New(), Date: day15(offset), Amount: amount(value), Posted: posted}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []Candidate
+		want       Kind
+		// wantIndex is the candidate the result should point at, or -1.
+		wantIndex int
+	}{
+		{
+			name:       "no candidates",
+			candidates: nil,
+			want:       None,
+			wantIndex:  -1,
+		},
+		{
+			name:       "one unposted candidate is matched automatically",
+			candidates: []Candidate{candidate(2, "-20.00", false)},
+			want:       Auto,
+			wantIndex:  0,
+		},
+		{
+			name:       "one posted candidate needs review",
+			candidates: []Candidate{candidate(0, "-20.00", true)},
+			want:       Duplicate,
+			wantIndex:  0,
+		},
+		{
+			name:       "two unposted candidates need review, pointing at the closer one",
+			candidates: []Candidate{candidate(-3, "-20.00", false), candidate(1, "-20.00", false)},
+			want:       Duplicate,
+			wantIndex:  1,
+		},
+		{
+			name:       "one unposted next to posted ones is still automatic",
+			candidates: []Candidate{candidate(0, "-20.00", true), candidate(3, "-20.00", false)},
+			want:       Auto,
+			wantIndex:  1,
+		},
+		{
+			name:       "different amount",
+			candidates: []Candidate{candidate(0, "-20.01", false)},
+			want:       None,
+			wantIndex:  -1,
+		},
+		{
+			name:       "same amount written differently",
+			candidates: []Candidate{candidate(0, "-20", false)},
+			want:       Auto,
+			wantIndex:  0,
+		},
+		{
+			name:       "on the edge of the window",
+			candidates: []Candidate{candidate(-3, "-20.00", false)},
+			want:       Auto,
+			wantIndex:  0,
+		},
+		{
+			name:       "outside the window",
+			candidates: []Candidate{candidate(4, "-20.00", false), candidate(-4, "-20.00", true)},
+			want:       None,
+			wantIndex:  -1,
+		},
+		{
+			name:       "unposted candidate outside the window doesn't count",
+			candidates: []Candidate{candidate(1, "-20.00", false), candidate(5, "-20.00", false)},
+			want:       Auto,
+			wantIndex:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(tt.candidates, DefaultWindowDays)
+			// Times of day on either side are ignored.
+			got := m.Match(day15(0).Add(20*time.Hour), amount("-20.00"))
+
+			if got.Kind != tt.want {
+				t.Fatalf("Kind = %q, want %q", got.Kind, tt.want)
+			}
+			wantID := uuid.Nil
+			if tt.wantIndex >= 0 {
+				wantID = tt.candidates[tt.wantIndex].ID
+			}
+			if got.TransactionID != wantID {
+				t.Errorf("TransactionID = %s, want candidate %d", got.TransactionID, tt.wantIndex)
+			}
+		})
+	}
+}
+
+func TestMatchClaimsEachCandidateOnce(t *testing.T) {
+	first := candidate(0, "-5.00", false)
+	second := candidate(1, "-5.00", true)
+	m := New([]Candidate{first, second}, DefaultWindowDays)
+
+	// Two identical purchases on a statement.
+	if got := m.Match(day15(0), amount("-5.00")); got.Kind != Auto || got.TransactionID != first.ID {
+		t.Fatalf("first match = %+v, want auto match of the unposted entry", got)
+	}
+	if got := m.Match(day15(0), amount("-5.00")); got.Kind != Duplicate || got.TransactionID != second.ID {
+		t.Fatalf("second match = %+v, want the posted entry as a duplicate", got)
+	}
+	if got := m.Match(day15(0), amount("-5.00")); got.Kind != None {
+		t.Fatalf("third match = %+v, want none left", got)
+	}
+}
+
+func TestMatchWindowSize(t *testing.T) {
+	c := candidate(1, "-9.99", false)
+
+	if got := New([]Candidate{c}, 0).Match(day15(0), amount("-9.99")); got.Kind != None {
+		t.Errorf("window 0: Kind = %q, want none", got.Kind)
+	}
+	if got := New([]Candidate{c}, 1).Match(day15(0), amount("-9.99")); got.Kind != Auto {
+		t.Errorf("window 1: Kind = %q, want auto", got.Kind)
+	}
+}
+
+func TestSimilar(t *testing.T) {
+	near := candidate(-1, "-12.00", true)
+	alsoNear := candidate(2, "-12.00", false)
+	far := candidate(10, "-12.00", false)
+	other := candidate(0, "-13.00", false)
+	m := New([]Candidate{near, alsoNear, far, other}, DefaultWindowDays)
+
+	got := m.Similar(day15(0), amount("-12.00"))
+	if len(got) != 2 || got[0] != near.ID || got[1] != alsoNear.ID {
+		t.Fatalf("Similar = %v, want the two nearby entries", got)
+	}
+
+	// Similar doesn't claim anything, but skips what Match has claimed.
+	if got := m.Similar(day15(0), amount("-12.00")); len(got) != 2 {
+		t.Fatalf("second Similar = %v, want the same two entries", got)
+	}
+	m.Match(day15(0), amount("-12.00"))
+	if got := m.Similar(day15(0), amount("-12.00")); len(got) != 1 || got[0] != near.ID {
+		t.Fatalf("Similar after Match = %v, want only the unclaimed entry", got)
+	}
+
+	if got := m.Similar(day15(0), amount("-99.00")); got == nil || len(got) != 0 {
+		t.Errorf("Similar with no matches = %#v, want an empty slice", got)
+	}
+}
+
+func TestWindow(t *testing.T) {
+	start, end := Window(time.Date(2026, 3, 1, 15, 0, 0, 0, time.UTC), time.Date(2026, 3, 31, 8, 0, 0, 0, time.UTC), 3)
+	if want := time.Date(2026, 2, 26, 0, 0, 0, 0, time.UTC); !start.Equal(want) {
+		t.Errorf("start = %s, want %s", start, want)
+	}
+	// The end is exclusive, so it's the day after the last one in range.
+	if want := time.Date(2026, 4, 4, 0, 0, 0, 0, time.UTC); !end.Equal(want) {
+		t.Errorf("end = %s, want %s", end, want)
+	}
+}
@@ -0,0 +1,174 @@
+package ofx
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jkk290/budget-tui/internal/database"
+	"github.com/shopspring/decimal"
+)
+
+// Transaction is a single STMTTRN entry from a bank or credit card
+// statement.
+type Transaction struct {
+	FITID       string          `json:"fitid"`
+	Date        time.Time       `json:"date"`
+	Amount      decimal.Decimal `json:"amount"`
+	Name        string          `json:"name"`
+	Memo        string          `json:"memo"`
+	Description string          `json:"description"`
+}
+
+// AddTransactionParams maps the entry onto a new posted, uncategorized
+// transaction in the given account.
+func (t Transaction) AddTransactionParams(accountID uuid.UUID) database.AddTransactionParams {
+	return database.AddTransactionParams{
+		ID:            uuid.New(),
+		Amount:        t.Amount,
+		TxDescription: t.Description,
+		TxDate:        t.Date,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+		Posted:        true,
+		AccountID:     accountID,
+	}
+}
+
+// Parse reads the STMTTRN entries of an OFX 1.x (SGML) or 2.x (XML) file.
+// Both versions are read with the same tag scanner: SGML leaf elements have
+// no closing tag, so a leaf's value is simply the text that follows it.
+func Parse(r io.Reader) ([]Transaction, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	body := string(data)
+	start := strings.Index(strings.ToUpper(body), "<OFX>")
+	if start < 0 {
+		return nil, errors.New("not an OFX file: missing <OFX> element")
+	}
+	body = body[start:]
+
+	var transactions []Transaction
+	var current map[string]string
+	for len(body) > 0 {
+		open := strings.IndexByte(body, '<')
+		if open < 0 {
+			break
+		}
+		end := strings.IndexByte(body[open:], '>')
+		if end < 0 {
+			return nil, errors.New("malformed OFX: unterminated tag")
+		}
+		tag := strings.ToUpper(strings.TrimSpace(body[open+1 : open+end]))
+		body = body[open+end+1:]
+
+		switch {
+		case tag == "STMTTRN":
+			current = map[string]string{}
+		case tag == "/STMTTRN":
+			if current == nil {
+				continue
+			}
+			transaction, err := newTransaction(current)
+			if err != nil {
+				return nil, err
+			}
+			transactions = append(transactions, transaction)
+			current = nil
+		case current != nil && !strings.HasPrefix(tag, "/") && !strings.HasPrefix(tag, "?") && !strings.HasPrefix(tag, "!"):
+			next := strings.IndexByte(body, '<')
+			if next < 0 {
+				next = len(body)
+			}
+			current[tag] = unescape(strings.TrimSpace(body[:next]))
+		}
+	}
+
+	if current != nil {
+		return nil, errors.New("malformed OFX: unterminated STMTTRN")
+	}
+	return transactions, nil
+}
+
+func newTransaction(fields map[string]string) (Transaction, error) {
+	fitID := fields["FITID"]
+	if fitID == "" {
+		return Transaction{}, errors.New("STMTTRN is missing FITID")
+	}
+
+	date, err := parseDate(fields["DTPOSTED"])
+	if err != nil {
+		return Transaction{}, fmt.Errorf("transaction %s: %w", fitID, err)
+	}
+
+	amount, err := parseAmount(fields["TRNAMT"])
+	if err != nil {
+		return Transaction{}, fmt.Errorf("transaction %s: invalid TRNAMT %q", fitID, fields["TRNAMT"])
+	}
+
+	transaction := Transaction{
+		FITID:       fitID,
+		Date:        date,
+		Amount:      amount,
+		Name:        fields["NAME"],
+		Memo:        fields["MEMO"],
+		Description: fields["NAME"],
+	}
+	if transaction.Description == "" {
+		transaction.Description = transaction.Memo
+	}
+	if transaction.Description == "" {
+		transaction.Description = fields["TRNTYPE"]
+	}
+	return transaction, nil
+}
+
+// parseAmount reads a TRNAMT. Some banks write a decimal comma, as in
+// "-12,50"; a comma is only read that way when it's the only separator.
+// Otherwise commas are thousands separators, as in "1,234.56". A comma
+// after the point, as in "1.234,56", is rejected rather than misread.
+func parseAmount(s string) (decimal.Decimal, error) {
+	s = strings.TrimSpace(s)
+	point := strings.LastIndex(s, ".")
+	if point >= 0 && strings.LastIndex(s, ",") > point {
+		return decimal.Decimal{}, fmt.Errorf("invalid amount %q", s)
+	}
+	if point < 0 && strings.Count(s, ",") == 1 {
+		s = strings.Replace(s, ",", ".", 1)
+	} else {
+		s = strings.ReplaceAll(s, ",", "")
+	}
+	return decimal.NewFromString(s)
+}
+
+// parseDate keeps only the calendar date of an OFX datetime such as
+// 20251205120000.000[-5:EST]; the bank's posting day is what matters here.
+func parseDate(s string) (time.Time, error) {
+	if len(s) < 8 {
+		return time.Time{}, fmt.Errorf("invalid DTPOSTED %q", s)
+	}
+	date, err := time.Parse("20060102", s[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid DTPOSTED %q", s)
+	}
+	return date, nil
+}
+
+var entityReplacer = strings.NewReplacer(
+	"&lt;", "<",
+	"&gt;", ">",
+	"&quot;", `"`,
+	"&apos;", "'",
+	"&nbsp;", " ",
+	"&amp;", "&",
+)
+
+func unescape(s string) string {
+	return entityReplacer.Replace(s)
+}
@@ -0,0 +1,219 @@
+package ofx
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const sgmlStatement = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+SECURITY:NONE
+ENCODING:USASCII
+CHARSET:1252
+COMPRESSION:NONE
+OLDFILEUID:NONE
+NEWFILEUID:NONE
+
+<OFX>
+<SIGNONMSGSRSV1>
+<SONRS>
+<STATUS><CODE>0<SEVERITY>INFO</STATUS>
+<DTSERVER>20260201120000
+</SONRS>
+</SIGNONMSGSRSV1>
+<BANKMSGSRSV1>
+<STMTTRNRS>
+<STMTRS>
+<CURDEF>USD
+<BANKTRANLIST>
+<DTSTART>20260101
+<DTEND>20260131
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20260105120000.000[-5:EST]
+<TRNAMT>-4.50
+<FITID>202601050001
+<NAME>COFFEE &amp; CO
+<MEMO>POS PURCHASE
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20260115
+<TRNAMT>1,234.56
+<FITID>202601150002
+<MEMO>PAYROLL
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>FEE
+<DTPOSTED>20260131
+<TRNAMT>-12,50
+<FITID>202601310003
+</STMTTRN>
+</BANKTRANLIST>
+</STMTRS>
+</STMTTRNRS>
+</BANKMSGSRSV1>
+</OFX>
+`
+
+const xmlStatement = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+  <CREDITCARDMSGSRSV1>
+    <CCSTMTTRNRS>
+      <CCSTMTRS>
+        <CURDEF>USD</CURDEF>
+        <BANKTRANLIST>
+          <DTSTART>20260101</DTSTART>
+          <DTEND>20260131</DTEND>
+          <stmttrn>
+            <TRNTYPE>DEBIT</TRNTYPE>
+            <DTPOSTED>20260110</DTPOSTED>
+            <TRNAMT>-2,500.00</TRNAMT>
+            <FITID>CC-1</FITID>
+            <NAME>Airline &lt;Intl&gt;</NAME>
+          </stmttrn>
+          <STMTTRN>
+            <TRNTYPE>PAYMENT</TRNTYPE>
+            <DTPOSTED>20260120000000</DTPOSTED>
+            <TRNAMT>300</TRNAMT>
+            <FITID>CC-2</FITID>
+            <MEMO>Thank you</MEMO>
+          </STMTTRN>
+        </BANKTRANLIST>
+      </CCSTMTRS>
+    </CCSTMTTRNRS>
+  </CREDITCARDMSGSRSV1>
+</OFX>
+`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []Transaction
+	}{
+		{
+			name: "SGML",
+			body: sgmlStatement,
+			want: []Transaction{
+				{FITID: "202601050001", Date: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), Amount: decimal.RequireFromString("-4.50"), Name: "COFFEE & CO", Memo: "POS PURCHASE", Description: "COFFEE & CO"},
+				{FITID: "202601150002", Date: time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC), Amount: decimal.RequireFromString("1234.56"), Memo: "PAYROLL", Description: "PAYROLL"},
+				{FITID: "202601310003", Date: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), Amount: decimal.RequireFromString("-12.50"), Description: "FEE"},
+			},
+		},
+		{
+			name: "XML",
+			body: xmlStatement,
+			want: []Transaction{
+				{FITID: "CC-1", Date: time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC), Amount: decimal.RequireFromString("-2500"), Name: "Airline <Intl>", Description: "Airline <Intl>"},
+				{FITID: "CC-2", Date: time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC), Amount: decimal.RequireFromString("300"), Memo: "Thank you", Description: "Thank you"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d transactions, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i, want := range tt.want {
+				g := got[i]
+				if g.FITID != want.FITID || !g.Date.Equal(want.Date) || !g.Amount.Equal(want.Amount) ||
+					g.Name != want.Name || g.Memo != want.Memo || g.Description != want.Description {
+					t.Errorf("transaction %d = %+v, want %+v", i, g, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{
+			name:    "not OFX",
+			body:    "Date,Description,Amount\n",
+			wantErr: "not an OFX file: missing <OFX> element",
+		},
+		{
+			name:    "missing FITID in SGML",
+			body:    "<OFX><STMTTRN>\n<TRNTYPE>DEBIT\n<DTPOSTED>20260105\n<TRNAMT>-4.50\n<NAME>Coffee\n</STMTTRN></OFX>",
+			wantErr: "STMTTRN is missing FITID",
+		},
+		{
+			name:    "empty FITID in XML",
+			body:    "<OFX><STMTTRN><DTPOSTED>20260105</DTPOSTED><TRNAMT>-4.50</TRNAMT><FITID></FITID></STMTTRN></OFX>",
+			wantErr: "STMTTRN is missing FITID",
+		},
+		{
+			name:    "bad date",
+			body:    "<OFX><STMTTRN><DTPOSTED>2026</DTPOSTED><TRNAMT>1</TRNAMT><FITID>A</FITID></STMTTRN></OFX>",
+			wantErr: `transaction A: invalid DTPOSTED "2026"`,
+		},
+		{
+			name:    "bad amount",
+			body:    "<OFX><STMTTRN><DTPOSTED>20260105</DTPOSTED><TRNAMT>1.234,56</TRNAMT><FITID>A</FITID></STMTTRN></OFX>",
+			wantErr: `transaction A: invalid TRNAMT "1.234,56"`,
+		},
+		{
+			name:    "unterminated tag",
+			body:    "<OFX><STMTTRN",
+			wantErr: "malformed OFX: unterminated tag",
+		},
+		{
+			name:    "unterminated transaction",
+			body:    "<OFX><STMTTRN><FITID>A",
+			wantErr: "malformed OFX: unterminated STMTTRN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.body))
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Parse error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"-4.50", "-4.50", false},
+		{"12", "12", false},
+		{"-12,50", "-12.50", false},
+		{"1,234.56", "1234.56", false},
+		{"-1,234,567.89", "-1234567.89", false},
+		{"1,234,567", "1234567", false},
+		{" +7.25 ", "7.25", false},
+		{"", "", true},
+		{"1.234,56", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseAmount(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseAmount(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !got.Equal(decimal.RequireFromString(tt.want)) {
+			t.Errorf("parseAmount(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
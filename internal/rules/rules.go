@@ -0,0 +1,137 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Rule pairs conditions with actions. Every condition that is set must
+// hold for the rule to match; a nil UUID or empty string means "any".
+type Rule struct {
+	DescriptionContains string
+	DescriptionRegex    string
+	MinAmount           *decimal.Decimal
+	MaxAmount           *decimal.Decimal
+	AccountID           uuid.UUID
+
+	SetCategoryID  uuid.UUID
+	SetDescription string
+	SetPosted      *bool
+}
+
+func (r Rule) hasCondition() bool {
+	return r.DescriptionContains != "" || r.DescriptionRegex != "" || r.MinAmount != nil || r.MaxAmount != nil || r.AccountID != uuid.Nil
+}
+
+func (r Rule) hasAction() bool {
+	return r.SetCategoryID != uuid.Nil || r.SetDescription != "" || r.SetPosted != nil
+}
+
+func (r Rule) Validate() error {
+	if !r.hasCondition() {
+		return errors.New("rule needs at least one condition")
+	}
+	if !r.hasAction() {
+		return errors.New("rule needs at least one action")
+	}
+	if r.DescriptionRegex != "" {
+		if _, err := regexp.Compile(r.DescriptionRegex); err != nil {
+			return fmt.Errorf("invalid description regex: %w", err)
+		}
+	}
+	if r.MinAmount != nil && r.MaxAmount != nil && r.MinAmount.GreaterThan(*r.MaxAmount) {
+		return errors.New("minimum amount can't be greater than maximum amount")
+	}
+	return nil
+}
+
+// Transaction is the part of a transaction that rules look at and change.
+// A nil CategoryID means uncategorized.
+type Transaction struct {
+	Description string
+	Amount      decimal.Decimal
+	AccountID   uuid.UUID
+	CategoryID  uuid.UUID
+	Posted      bool
+}
+
+type compiledRule struct {
+	Rule
+	contains string
+	regex    *regexp.Regexp
+}
+
+// Engine applies an ordered list of rules.
+type Engine struct {
+	rules []compiledRule
+}
+
+func Compile(rules []Rule) (*Engine, error) {
+	engine := &Engine{}
+	for i, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+		compiled := compiledRule{
+			Rule:     rule,
+			contains: strings.ToLower(rule.DescriptionContains),
+		}
+		if rule.DescriptionRegex != "" {
+			compiled.regex = regexp.MustCompile(rule.DescriptionRegex)
+		}
+		engine.rules = append(engine.rules, compiled)
+	}
+	return engine, nil
+}
+
+func (r compiledRule) matches(t Transaction) bool {
+	if r.contains != "" && !strings.Contains(strings.ToLower(t.Description), r.contains) {
+		return false
+	}
+	if r.regex != nil && !r.regex.MatchString(t.Description) {
+		return false
+	}
+	if r.MinAmount != nil && t.Amount.LessThan(*r.MinAmount) {
+		return false
+	}
+	if r.MaxAmount != nil && t.Amount.GreaterThan(*r.MaxAmount) {
+		return false
+	}
+	if r.AccountID != uuid.Nil && t.AccountID != r.AccountID {
+		return false
+	}
+	return true
+}
+
+// Apply runs every rule in order, so later rules see earlier renames and
+// override earlier actions. A category is only set when the transaction
+// was uncategorized to begin with; a category the user picked always wins.
+// It reports whether anything changed.
+func (e *Engine) Apply(t *Transaction) bool {
+	if e == nil {
+		return false
+	}
+
+	original := *t
+	for _, rule := range e.rules {
+		if !rule.matches(*t) {
+			continue
+		}
+		if rule.SetCategoryID != uuid.Nil && original.CategoryID == uuid.Nil {
+			t.CategoryID = rule.SetCategoryID
+		}
+		if rule.SetDescription != "" {
+			t.Description = rule.SetDescription
+		}
+		if rule.SetPosted != nil {
+			t.Posted = *rule.SetPosted
+		}
+	}
+
+	return t.CategoryID != original.CategoryID || t.Description != original.Description || t.Posted != original.Posted
+}
@@ -0,0 +1,148 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func decimalPtr(s string) *decimal.Decimal {
+	d := decimal.RequireFromString(s)
+	return &d
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestValidate(t *testing.T) {
+	category := uuid.New()
+	tests := []struct {
+		name    string
+		rule    Rule
+		wantErr bool
+	}{
+		{"contains and category", Rule{DescriptionContains: "coffee", SetCategoryID: category}, false},
+		{"account and posted", Rule{AccountID: uuid.New(), SetPosted: boolPtr(true)}, false},
+		{"no condition", Rule{SetCategoryID: category}, true},
+		{"no action", Rule{DescriptionContains: "coffee"}, true},
+		{"bad regex", Rule{DescriptionRegex: "(", SetDescription: "x"}, true},
+		{"min above max", Rule{MinAmount: decimalPtr("10"), MaxAmount: decimalPtr("5"), SetCategoryID: category}, true},
+		{"min equal to max", Rule{MinAmount: decimalPtr("5"), MaxAmount: decimalPtr("5"), SetCategoryID: category}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rule.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCompileRejectsInvalidRules(t *testing.T) {
+	_, err := Compile([]Rule{
+		{DescriptionContains: "coffee", SetDescription: "Coffee"},
+		{DescriptionContains: "tea"},
+	})
+	if err == nil || err.Error() != "rule 2: rule needs at least one action" {
+		t.Errorf("Compile error = %v, want rule 2 to be named", err)
+	}
+}
+
+func TestApplyConditions(t *testing.T) {
+	category := uuid.New()
+	account := uuid.New()
+	tx := Transaction{Description: "STARBUCKS #1234 Seattle", Amount: decimal.RequireFromString("-6.75"), AccountID: account}
+
+	tests := []struct {
+		name string
+		rule Rule
+		want bool
+	}{
+		{"contains ignores case", Rule{DescriptionContains: "starbucks"}, true},
+		{"contains misses", Rule{DescriptionContains: "peets"}, false},
+		{"regex", Rule{DescriptionRegex: `^STARBUCKS #\d+`}, true},
+		{"regex is case sensitive", Rule{DescriptionRegex: `^starbucks`}, false},
+		{"min amount is inclusive", Rule{MinAmount: decimalPtr("-6.75")}, true},
+		{"below min amount", Rule{MinAmount: decimalPtr("-5")}, false},
+		{"max amount is inclusive", Rule{MaxAmount: decimalPtr("-6.75")}, true},
+		{"above max amount", Rule{MaxAmount: decimalPtr("-10")}, false},
+		{"same account", Rule{AccountID: account}, true},
+		{"other account", Rule{AccountID: uuid.New()}, false},
+		{"every condition must hold", Rule{DescriptionContains: "starbucks", AccountID: uuid.New()}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.rule.SetCategoryID = category
+			engine, err := Compile([]Rule{tt.rule})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := tx
+			changed := engine.Apply(&got)
+			if changed != tt.want || (got.CategoryID == category) != tt.want {
+				t.Errorf("Apply changed = %v, category set = %v, want %v", changed, got.CategoryID == category, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyOrder(t *testing.T) {
+	groceries := uuid.New()
+	dining := uuid.New()
+
+	engine, err := Compile([]Rule{
+		{DescriptionContains: "TRADER JOE", SetDescription: "Trader Joe's"},
+		// Sees the rename from the rule before it.
+		{DescriptionContains: "trader joe's", SetCategoryID: groceries, SetPosted: boolPtr(true)},
+		// Overrides the earlier category.
+		{DescriptionRegex: `Joe's$`, SetCategoryID: dining},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := Transaction{Description: "TRADER JOE #552", Amount: decimal.RequireFromString("-40")}
+	if !engine.Apply(&tx) {
+		t.Fatal("Apply reported no change")
+	}
+	if tx.Description != "Trader Joe's" || tx.CategoryID != dining || !tx.Posted {
+		t.Errorf("Apply = %+v, want renamed, posted and in the last rule's category", tx)
+	}
+}
+
+func TestApplyKeepsChosenCategory(t *testing.T) {
+	chosen := uuid.New()
+	engine, err := Compile([]Rule{{DescriptionContains: "rent", SetCategoryID: uuid.New()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := Transaction{Description: "Rent", CategoryID: chosen}
+	if engine.Apply(&tx) {
+		t.Error("Apply reported a change")
+	}
+	if tx.CategoryID != chosen {
+		t.Errorf("CategoryID = %s, want the category the user picked", tx.CategoryID)
+	}
+}
+
+func TestApplyNoChange(t *testing.T) {
+	engine, err := Compile([]Rule{{DescriptionContains: "rent", SetPosted: boolPtr(true)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := Transaction{Description: "Rent", Posted: true}
+	if engine.Apply(&tx) {
+		t.Error("Apply reported a change when the transaction was already posted")
+	}
+
+	var nilEngine *Engine
+	if nilEngine.Apply(&tx) {
+		t.Error("nil engine reported a change")
+	}
+}
@@ -0,0 +1,137 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type Frequency string
+
+const (
+	Daily    Frequency = "daily"
+	Weekly   Frequency = "weekly"
+	Biweekly Frequency = "biweekly"
+	Monthly  Frequency = "monthly"
+	Yearly   Frequency = "yearly"
+)
+
+var ErrInvalidFrequency = errors.New("frequency must be daily, weekly, biweekly, monthly or yearly")
+
+func ParseFrequency(s string) (Frequency, error) {
+	switch f := Frequency(s); f {
+	case Daily, Weekly, Biweekly, Monthly, Yearly:
+		return f, nil
+	}
+	return "", ErrInvalidFrequency
+}
+
+type Rule struct {
+	Frequency  Frequency
+	DayOfMonth int
+	StartDate  time.Time
+	EndDate    time.Time
+	Count      int
+}
+
+func (r Rule) Validate() error {
+	if _, err := ParseFrequency(string(r.Frequency)); err != nil {
+		return err
+	}
+	if r.StartDate.IsZero() {
+		return errors.New("start date is required")
+	}
+	if r.DayOfMonth < 0 || r.DayOfMonth > 31 {
+		return fmt.Errorf("day of month must be between 1 and 31, got %d", r.DayOfMonth)
+	}
+	if r.DayOfMonth != 0 && r.Frequency != Monthly {
+		return errors.New("day of month only applies to monthly schedules")
+	}
+	if !r.EndDate.IsZero() && r.EndDate.Before(Day(r.StartDate)) {
+		return errors.New("end date can't be before the start date")
+	}
+	if r.Count < 0 {
+		return errors.New("count can't be negative")
+	}
+	return nil
+}
+
+func Day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Occurrence returns the date of the nth (zero-based) occurrence, and false
+// once the rule's end date or count has been reached.
+func (r Rule) Occurrence(n int) (time.Time, bool) {
+	if n < 0 || (r.Count > 0 && n >= r.Count) {
+		return time.Time{}, false
+	}
+
+	start := Day(r.StartDate)
+	var date time.Time
+	switch r.Frequency {
+	case Daily:
+		date = start.AddDate(0, 0, n)
+	case Weekly:
+		date = start.AddDate(0, 0, 7*n)
+	case Biweekly:
+		date = start.AddDate(0, 0, 14*n)
+	case Monthly:
+		day := r.DayOfMonth
+		if day == 0 {
+			day = start.Day()
+		}
+		offset := n
+		if day < start.Day() {
+			offset++
+		}
+		date = dateInMonth(start.Year(), start.Month()+time.Month(offset), day)
+	case Yearly:
+		date = dateInMonth(start.Year()+n, start.Month(), start.Day())
+	default:
+		return time.Time{}, false
+	}
+
+	if !r.EndDate.IsZero() && date.After(Day(r.EndDate)) {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// Next returns the first occurrence strictly after the given day. A zero
+// after returns the first occurrence of the rule.
+func (r Rule) Next(after time.Time) (time.Time, bool) {
+	for n := 0; ; n++ {
+		date, ok := r.Occurrence(n)
+		if !ok {
+			return time.Time{}, false
+		}
+		if after.IsZero() || date.After(Day(after)) {
+			return date, true
+		}
+	}
+}
+
+// Between returns every occurrence after the after day up to and including
+// the through day.
+func (r Rule) Between(after, through time.Time) []time.Time {
+	var dates []time.Time
+	for n := 0; ; n++ {
+		date, ok := r.Occurrence(n)
+		if !ok || date.After(Day(through)) {
+			return dates
+		}
+		if after.IsZero() || date.After(Day(after)) {
+			dates = append(dates, date)
+		}
+	}
+}
+
+func dateInMonth(year int, month time.Month, day int) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	lastDay := first.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,262 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func formatDates(dates []time.Time) []string {
+	formatted := make([]string, len(dates))
+	for i, d := range dates {
+		formatted[i] = d.Format(time.DateOnly)
+	}
+	return formatted
+}
+
+func TestOccurrence(t *testing.T) {
+	tests := []struct {
+		name string
+		rule Rule
+		want []string
+	}{
+		{
+			name: "daily",
+			rule: Rule{Frequency: Daily, StartDate: date(2026, 12, 30)},
+			want: []string{"2026-12-30", "2026-12-31", "2027-01-01"},
+		},
+		{
+			name: "weekly",
+			rule: Rule{Frequency: Weekly, StartDate: date(2026, 1, 5)},
+			want: []string{"2026-01-05", "2026-01-12", "2026-01-19"},
+		},
+		{
+			name: "biweekly",
+			rule: Rule{Frequency: Biweekly, StartDate: date(2026, 1, 5)},
+			want: []string{"2026-01-05", "2026-01-19", "2026-02-02"},
+		},
+		{
+			name: "monthly on the start day",
+			rule: Rule{Frequency: Monthly, StartDate: date(2026, 11, 15)},
+			want: []string{"2026-11-15", "2026-12-15", "2027-01-15"},
+		},
+		{
+			name: "monthly on a later day of month",
+			rule: Rule{Frequency: Monthly, DayOfMonth: 20, StartDate: date(2026, 1, 15)},
+			want: []string{"2026-01-20", "2026-02-20", "2026-03-20"},
+		},
+		{
+			name: "monthly on an earlier day of month starts next month",
+			rule: Rule{Frequency: Monthly, DayOfMonth: 1, StartDate: date(2026, 1, 15)},
+			want: []string{"2026-02-01", "2026-03-01", "2026-04-01"},
+		},
+		{
+			name: "monthly on the 31st clamps to short months without drifting",
+			rule: Rule{Frequency: Monthly, DayOfMonth: 31, StartDate: date(2026, 1, 1)},
+			want: []string{"2026-01-31", "2026-02-28", "2026-03-31", "2026-04-30", "2026-05-31"},
+		},
+		{
+			name: "monthly on the 31st in a leap year",
+			rule: Rule{Frequency: Monthly, StartDate: date(2028, 1, 31)},
+			want: []string{"2028-01-31", "2028-02-29", "2028-03-31"},
+		},
+		{
+			name: "yearly from February 29th",
+			rule: Rule{Frequency: Yearly, StartDate: date(2024, 2, 29)},
+			want: []string{"2024-02-29", "2025-02-28", "2026-02-28", "2027-02-28", "2028-02-29"},
+		},
+		{
+			name: "start time of day is ignored",
+			rule: Rule{Frequency: Daily, StartDate: time.Date(2026, 3, 1, 18, 30, 0, 0, time.UTC)},
+			want: []string{"2026-03-01", "2026-03-02"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for n, want := range tt.want {
+				got, ok := tt.rule.Occurrence(n)
+				if !ok {
+					t.Fatalf("Occurrence(%d) ended early", n)
+				}
+				if got.Format(time.DateOnly) != want {
+					t.Errorf("Occurrence(%d) = %s, want %s", n, got.Format(time.DateOnly), want)
+				}
+			}
+		})
+	}
+}
+
+func TestOccurrenceLimits(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   Rule
+		n      int
+		wantOK bool
+	}{
+		{"negative n", Rule{Frequency: Daily, StartDate: date(2026, 1, 1)}, -1, false},
+		{"last counted occurrence", Rule{Frequency: Daily, StartDate: date(2026, 1, 1), Count: 3}, 2, true},
+		{"past the count", Rule{Frequency: Daily, StartDate: date(2026, 1, 1), Count: 3}, 3, false},
+		{"on the end date", Rule{Frequency: Weekly, StartDate: date(2026, 1, 1), EndDate: date(2026, 1, 15)}, 2, true},
+		{"past the end date", Rule{Frequency: Weekly, StartDate: date(2026, 1, 1), EndDate: date(2026, 1, 15)}, 3, false},
+		{"end date time of day is ignored", Rule{Frequency: Daily, StartDate: date(2026, 1, 1), EndDate: time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)}, 1, true},
+		{"unknown frequency", Rule{Frequency: "hourly", StartDate: date(2026, 1, 1)}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := tt.rule.Occurrence(tt.n); ok != tt.wantOK {
+				t.Errorf("Occurrence(%d) ok = %v, want %v", tt.n, ok, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	monthly := Rule{Frequency: Monthly, DayOfMonth: 31, StartDate: date(2026, 1, 1)}
+
+	tests := []struct {
+		name   string
+		rule   Rule
+		after  time.Time
+		want   string
+		wantOK bool
+	}{
+		{"zero after gives the first occurrence", monthly, time.Time{}, "2026-01-31", true},
+		{"strictly after the given day", monthly, date(2026, 1, 31), "2026-02-28", true},
+		{"between occurrences", monthly, date(2026, 2, 10), "2026-02-28", true},
+		{"time of day on after is ignored", monthly, time.Date(2026, 2, 28, 23, 0, 0, 0, time.UTC), "2026-03-31", true},
+		{"before the start", monthly, date(2025, 6, 1), "2026-01-31", true},
+		{"none left after the count", Rule{Frequency: Weekly, StartDate: date(2026, 1, 1), Count: 2}, date(2026, 1, 8), "", false},
+		{"none left after the end date", Rule{Frequency: Daily, StartDate: date(2026, 1, 1), EndDate: date(2026, 1, 3)}, date(2026, 1, 3), "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.rule.Next(tt.after)
+			if ok != tt.wantOK {
+				t.Fatalf("Next ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && got.Format(time.DateOnly) != tt.want {
+				t.Errorf("Next = %s, want %s", got.Format(time.DateOnly), tt.want)
+			}
+		})
+	}
+}
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    Rule
+		after   time.Time
+		through time.Time
+		want    []string
+	}{
+		{
+			name:    "nothing entered yet includes the first occurrence",
+			rule:    Rule{Frequency: Weekly, StartDate: date(2026, 1, 1)},
+			through: date(2026, 1, 15),
+			want:    []string{"2026-01-01", "2026-01-08", "2026-01-15"},
+		},
+		{
+			name:    "after is exclusive and through is inclusive",
+			rule:    Rule{Frequency: Weekly, StartDate: date(2026, 1, 1)},
+			after:   date(2026, 1, 8),
+			through: date(2026, 1, 22),
+			want:    []string{"2026-01-15", "2026-01-22"},
+		},
+		{
+			name:    "nothing due yet",
+			rule:    Rule{Frequency: Monthly, StartDate: date(2026, 1, 1)},
+			after:   date(2026, 1, 1),
+			through: date(2026, 1, 31),
+			want:    []string{},
+		},
+		{
+			name:    "catches up every missed month after a long gap",
+			rule:    Rule{Frequency: Monthly, DayOfMonth: 31, StartDate: date(2026, 1, 1)},
+			after:   date(2026, 1, 31),
+			through: date(2026, 7, 4),
+			want:    []string{"2026-02-28", "2026-03-31", "2026-04-30", "2026-05-31", "2026-06-30"},
+		},
+		{
+			name:    "catch up stops at the count",
+			rule:    Rule{Frequency: Daily, StartDate: date(2026, 1, 1), Count: 5},
+			after:   date(2026, 1, 2),
+			through: date(2026, 12, 31),
+			want:    []string{"2026-01-03", "2026-01-04", "2026-01-05"},
+		},
+		{
+			name:    "catch up stops at the end date",
+			rule:    Rule{Frequency: Biweekly, StartDate: date(2026, 1, 1), EndDate: date(2026, 2, 1)},
+			through: date(2026, 12, 31),
+			want:    []string{"2026-01-01", "2026-01-15", "2026-01-29"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatDates(tt.rule.Between(tt.after, tt.through))
+			if len(got) != len(tt.want) {
+				t.Fatalf("Between = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Between = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestDateInMonth(t *testing.T) {
+	tests := []struct {
+		year  int
+		month time.Month
+		day   int
+		want  string
+	}{
+		{2026, time.January, 31, "2026-01-31"},
+		{2026, time.February, 31, "2026-02-28"},
+		{2028, time.February, 30, "2028-02-29"},
+		{2026, time.April, 31, "2026-04-30"},
+		{2026, 13, 15, "2027-01-15"},
+		{2026, 14, 31, "2027-02-28"},
+	}
+
+	for _, tt := range tests {
+		got := dateInMonth(tt.year, tt.month, tt.day)
+		if got.Format(time.DateOnly) != tt.want {
+			t.Errorf("dateInMonth(%d, %d, %d) = %s, want %s", tt.year, tt.month, tt.day, got.Format(time.DateOnly), tt.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	start := date(2026, 1, 1)
+	tests := []struct {
+		name    string
+		rule    Rule
+		wantErr bool
+	}{
+		{"valid monthly", Rule{Frequency: Monthly, DayOfMonth: 31, StartDate: start}, false},
+		{"unknown frequency", Rule{Frequency: "hourly", StartDate: start}, true},
+		{"missing start", Rule{Frequency: Daily}, true},
+		{"day of month too large", Rule{Frequency: Monthly, DayOfMonth: 32, StartDate: start}, true},
+		{"day of month on a weekly rule", Rule{Frequency: Weekly, DayOfMonth: 5, StartDate: start}, true},
+		{"end before start", Rule{Frequency: Daily, StartDate: start, EndDate: date(2025, 12, 31)}, true},
+		{"end on start", Rule{Frequency: Daily, StartDate: start, EndDate: start}, false},
+		{"negative count", Rule{Frequency: Daily, StartDate: start, Count: -1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rule.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,12 +1,13 @@
 -- name: AddAccount :one
-INSERT INTO accounts (id, account_name, account_type, created_at, updated_at, user_id)
+INSERT INTO accounts (id, account_name, account_type, created_at, updated_at, user_id, currency)
 VALUES (
     $1,
     $2,
     $3,
     $4,
     $5,
-    $6
+    $6,
+    $7
 )
 RETURNING *;
 
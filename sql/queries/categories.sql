@@ -1,5 +1,5 @@
 -- name: CreateCategory :one
-INSERT INTO categories (id, category_name, created_at, updated_at, budget, user_id, group_id)
+INSERT INTO categories (id, category_name, created_at, updated_at, budget, user_id, group_id, rollover_overspending, is_income)
 VALUES (
     $1,
     $2,
@@ -7,7 +7,9 @@ VALUES (
     $4,
     $5,
     $6,
-    $7
+    $7,
+    $8,
+    $9
 )
 RETURNING *;
 
@@ -24,6 +26,8 @@ UPDATE categories
 SET category_name = $2,
 budget = $3,
 group_id = $4,
+rollover_overspending = $5,
+is_income = $6,
 updated_at = NOW()
 WHERE id = $1
 RETURNING *;
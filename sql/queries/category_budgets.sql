@@ -0,0 +1,43 @@
+-- name: SetCategoryBudget :one
+INSERT INTO category_budgets (category_id, month, assigned, created_at, updated_at)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5
+)
+ON CONFLICT (category_id, month) DO UPDATE
+SET assigned = EXCLUDED.assigned,
+updated_at = NOW()
+RETURNING *;
+
+-- name: GetCategoryBudgetForMonth :one
+SELECT * FROM category_budgets
+WHERE category_id = $1
+AND month = $2;
+
+-- name: GetCategoryBudgets :many
+SELECT * FROM category_budgets
+WHERE category_id = $1
+ORDER BY month;
+
+-- name: GetUserCategoryBudgets :many
+SELECT category_budgets.* FROM category_budgets
+INNER JOIN categories
+ON categories.id = category_budgets.category_id
+WHERE categories.user_id = $1
+ORDER BY category_budgets.month;
+
+-- name: GetUserCategoryBudgetsBefore :many
+SELECT category_budgets.* FROM category_budgets
+INNER JOIN categories
+ON categories.id = category_budgets.category_id
+WHERE categories.user_id = $1
+AND category_budgets.month < $2
+ORDER BY category_budgets.month;
+
+-- name: DeleteCategoryBudget :exec
+DELETE FROM category_budgets
+WHERE category_id = $1
+AND month = $2;
@@ -0,0 +1,30 @@
+-- name: SaveCSVImportMapping :one
+INSERT INTO csv_import_mappings (account_id, skip_rows, date_column, date_format, amount_column, debit_column, credit_column, description_column, invert_amounts, created_at, updated_at)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9,
+    $10,
+    $11
+)
+ON CONFLICT (account_id) DO UPDATE
+SET skip_rows = EXCLUDED.skip_rows,
+date_column = EXCLUDED.date_column,
+date_format = EXCLUDED.date_format,
+amount_column = EXCLUDED.amount_column,
+debit_column = EXCLUDED.debit_column,
+credit_column = EXCLUDED.credit_column,
+description_column = EXCLUDED.description_column,
+invert_amounts = EXCLUDED.invert_amounts,
+updated_at = NOW()
+RETURNING *;
+
+-- name: GetCSVImportMapping :one
+SELECT * FROM csv_import_mappings
+WHERE account_id = $1;
@@ -0,0 +1,48 @@
+-- name: SaveExchangeRate :one
+INSERT INTO exchange_rates (id, from_currency, to_currency, rate, rate_date, created_at, updated_at, user_id)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8
+)
+ON CONFLICT (user_id, from_currency, to_currency, rate_date) DO UPDATE
+SET rate = EXCLUDED.rate,
+updated_at = NOW()
+RETURNING *;
+
+-- name: GetExchangeRateByID :one
+SELECT * FROM exchange_rates
+WHERE id = $1;
+
+-- name: GetExchangeRatesByUser :many
+SELECT * FROM exchange_rates
+WHERE user_id = $1
+ORDER BY from_currency, to_currency, rate_date DESC;
+
+-- name: GetLatestExchangeRates :many
+SELECT DISTINCT ON (from_currency) *
+FROM exchange_rates
+WHERE user_id = sqlc.arg(user_id)
+AND to_currency = sqlc.arg(to_currency)
+AND rate_date <= sqlc.arg(as_of)
+ORDER BY from_currency, rate_date DESC;
+
+-- name: GetUnconvertedCurrencies :many
+SELECT DISTINCT converted_transaction_lines.currency
+FROM converted_transaction_lines
+INNER JOIN accounts
+ON accounts.id = converted_transaction_lines.account_id
+WHERE accounts.user_id = sqlc.arg(user_id)
+AND converted_transaction_lines.amount IS NULL
+AND converted_transaction_lines.tx_date < sqlc.arg(end_date)
+AND converted_transaction_lines.transfer_id IS NULL
+ORDER BY converted_transaction_lines.currency;
+
+-- name: DeleteExchangeRate :exec
+DELETE FROM exchange_rates
+WHERE id = $1;
@@ -0,0 +1,35 @@
+-- name: AddImportDuplicate :exec
+INSERT INTO import_duplicates (id, amount, tx_description, tx_date, fitid, created_at, user_id, account_id, matched_transaction_id)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9
+);
+
+-- name: GetImportDuplicateByID :one
+SELECT * FROM import_duplicates
+WHERE id = $1;
+
+-- name: GetUserImportDuplicates :many
+SELECT import_duplicates.*,
+accounts.account_name,
+transactions.tx_description AS matched_description,
+transactions.tx_date AS matched_date,
+transactions.posted AS matched_posted
+FROM import_duplicates
+INNER JOIN accounts
+ON accounts.id = import_duplicates.account_id
+LEFT JOIN transactions
+ON transactions.id = import_duplicates.matched_transaction_id
+WHERE import_duplicates.user_id = $1
+ORDER BY import_duplicates.tx_date, import_duplicates.created_at;
+
+-- name: DeleteImportDuplicate :exec
+DELETE FROM import_duplicates
+WHERE id = $1;
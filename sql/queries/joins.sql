@@ -5,13 +5,30 @@ INNER JOIN transactions
 ON transactions.account_id = accounts.id
 WHERE accounts.id = $1;
 
+-- name: GetAccountPeriodTotals :many
+SELECT transactions.account_id,
+date_trunc(sqlc.arg(interval)::text, transactions.tx_date)::timestamp AS period_start,
+SUM(transactions.amount)::numeric AS amount
+FROM transactions
+INNER JOIN accounts
+ON accounts.id = transactions.account_id
+WHERE accounts.user_id = sqlc.arg(user_id)
+AND transactions.tx_date >= sqlc.arg(start_date)
+AND transactions.tx_date < sqlc.arg(end_date)
+GROUP BY transactions.account_id, period_start
+ORDER BY period_start;
+
 -- name: GetUserAccountsBalances :many
-SELECT accounts.*, (SUM(transactions.amount * 100))::bigint AS account_balance_cents
+SELECT accounts.*,
+COALESCE(SUM(transactions.amount) FILTER (WHERE transactions.posted AND transactions.tx_date < sqlc.arg(as_of)), 0)::numeric AS cleared_balance,
+COALESCE(SUM(transactions.amount) FILTER (WHERE NOT transactions.posted AND transactions.tx_date < sqlc.arg(as_of)), 0)::numeric AS uncleared_balance,
+COALESCE(SUM(transactions.amount) FILTER (WHERE transactions.tx_date >= sqlc.arg(as_of)), 0)::numeric AS future_amount
 FROM accounts
-INNER JOIN transactions
+LEFT JOIN transactions
 ON transactions.account_id = accounts.id
-WHERE accounts.user_id = $1
-GROUP BY accounts.id;
+WHERE accounts.user_id = sqlc.arg(user_id)
+GROUP BY accounts.id
+ORDER BY accounts.created_at;
 
 -- name: GetTransactionUserID :one
 SELECT accounts.user_id AS user_id FROM transactions
@@ -22,14 +39,69 @@ WHERE transactions.id = $1;
 -- name: GetUserTransactions :many
 SELECT transactions.*,
 accounts.account_name,
-categories.category_name
+categories.category_name,
+payees.payee_name
 FROM transactions
 INNER JOIN accounts
 ON accounts.id = transactions.account_id
-INNER JOIN categories
+LEFT JOIN categories
 ON categories.id = transactions.category_id
-WHERE accounts.user_id = $1
-ORDER BY transactions.tx_date DESC;
+LEFT JOIN payees
+ON payees.id = transactions.payee_id
+WHERE accounts.user_id = sqlc.arg(user_id)
+AND (sqlc.narg(start_date)::timestamp IS NULL OR transactions.tx_date >= sqlc.narg(start_date))
+AND (sqlc.narg(end_date)::timestamp IS NULL OR transactions.tx_date < sqlc.narg(end_date))
+AND (sqlc.narg(min_amount)::numeric IS NULL OR transactions.amount >= sqlc.narg(min_amount))
+AND (sqlc.narg(max_amount)::numeric IS NULL OR transactions.amount <= sqlc.narg(max_amount))
+AND (sqlc.narg(account_ids)::uuid[] IS NULL OR transactions.account_id = ANY(sqlc.narg(account_ids)::uuid[]))
+AND (
+    sqlc.narg(category_ids)::uuid[] IS NULL
+    OR transactions.category_id = ANY(sqlc.narg(category_ids)::uuid[])
+    OR EXISTS (
+        SELECT 1 FROM transaction_splits
+        WHERE transaction_splits.transaction_id = transactions.id
+        AND transaction_splits.category_id = ANY(sqlc.narg(category_ids)::uuid[])
+    )
+)
+AND (
+    sqlc.narg(group_id)::uuid IS NULL
+    OR categories.group_id = sqlc.narg(group_id)
+    OR EXISTS (
+        SELECT 1 FROM transaction_splits
+        INNER JOIN categories AS split_categories
+        ON split_categories.id = transaction_splits.category_id
+        WHERE transaction_splits.transaction_id = transactions.id
+        AND split_categories.group_id = sqlc.narg(group_id)
+    )
+)
+AND (sqlc.narg(posted)::boolean IS NULL OR transactions.posted = sqlc.narg(posted))
+AND (
+    sqlc.narg(search)::text IS NULL
+    OR transactions.tx_description ILIKE '%' || sqlc.narg(search) || '%'
+    OR payees.payee_name ILIKE '%' || sqlc.narg(search) || '%'
+)
+AND (
+    sqlc.narg(tag_ids)::uuid[] IS NULL
+    OR EXISTS (
+        SELECT 1 FROM transaction_tags
+        WHERE transaction_tags.transaction_id = transactions.id
+        AND transaction_tags.tag_id = ANY(sqlc.narg(tag_ids)::uuid[])
+    )
+)
+AND (
+    sqlc.narg(cursor_date)::timestamp IS NULL
+    OR (transactions.tx_date, transactions.id) < (sqlc.narg(cursor_date), sqlc.narg(cursor_id)::uuid)
+)
+ORDER BY transactions.tx_date DESC, transactions.id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: GetUserTransactionIDs :many
+SELECT transactions.id
+FROM transactions
+INNER JOIN accounts
+ON accounts.id = transactions.account_id
+WHERE accounts.user_id = sqlc.arg(user_id)
+AND transactions.id = ANY(sqlc.arg(transaction_ids)::uuid[]);
 
 -- name: GetUserCategoriesDetailed :many
 SELECT categories.*,
@@ -43,16 +115,127 @@ WHERE categories.user_id = $1;
 SELECT categories.id AS category_id,
 categories.category_name,
 categories.budget,
+categories.rollover_overspending,
+categories.created_at,
 categories.group_id,
 groups.group_name,
-COALESCE(SUM(-transactions.amount), 0)::numeric AS total_spent
+COALESCE(SUM(-converted_transaction_lines.amount), 0)::numeric AS total_spent
 FROM categories
 LEFT JOIN groups ON groups.id = categories.group_id
-LEFT JOIN transactions
-ON transactions.category_id = categories.id
-AND transactions.tx_date >= $2
-AND transactions.tx_date < $3
-WHERE categories.user_id = $1
+LEFT JOIN converted_transaction_lines
+ON converted_transaction_lines.category_id = categories.id
+AND converted_transaction_lines.tx_date >= sqlc.arg(start_date)
+AND converted_transaction_lines.tx_date < sqlc.arg(end_date)
+AND converted_transaction_lines.transfer_id IS NULL
+WHERE categories.user_id = sqlc.arg(user_id)
+AND categories.is_income = false
 GROUP BY categories.id, categories.category_name, categories.budget,
+categories.rollover_overspending, categories.created_at,
 categories.group_id, groups.group_name
 ORDER BY groups.group_name NULLS LAST, categories.category_name;
+
+-- name: GetUserCategoryMonthlyActivity :many
+SELECT categories.id AS category_id,
+date_trunc('month', converted_transaction_lines.tx_date)::date AS month,
+COALESCE(SUM(converted_transaction_lines.amount), 0)::numeric AS activity
+FROM converted_transaction_lines
+INNER JOIN categories
+ON categories.id = converted_transaction_lines.category_id
+WHERE categories.user_id = sqlc.arg(user_id)
+AND converted_transaction_lines.tx_date < sqlc.arg(end_date)
+AND converted_transaction_lines.transfer_id IS NULL
+AND categories.is_income = false
+GROUP BY categories.id, date_trunc('month', converted_transaction_lines.tx_date)
+ORDER BY month;
+
+-- name: GetUserTotalIncome :one
+SELECT COALESCE(SUM(converted_transaction_lines.amount), 0)::numeric AS total_income
+FROM converted_transaction_lines
+INNER JOIN categories
+ON categories.id = converted_transaction_lines.category_id
+WHERE categories.user_id = sqlc.arg(user_id)
+AND categories.is_income = true
+AND converted_transaction_lines.tx_date < sqlc.arg(end_date)
+AND converted_transaction_lines.transfer_id IS NULL;
+
+-- name: GetUserTransactionSplits :many
+SELECT transaction_splits.*,
+categories.category_name
+FROM transaction_splits
+INNER JOIN transactions
+ON transactions.id = transaction_splits.transaction_id
+INNER JOIN accounts
+ON accounts.id = transactions.account_id
+LEFT JOIN categories
+ON categories.id = transaction_splits.category_id
+WHERE accounts.user_id = $1
+ORDER BY transaction_splits.transaction_id, transaction_splits.sort_order;
+
+-- name: GetUserTransactionsExportPage :many
+SELECT transactions.*,
+accounts.account_name,
+categories.category_name,
+payees.payee_name
+FROM transactions
+INNER JOIN accounts
+ON accounts.id = transactions.account_id
+LEFT JOIN categories
+ON categories.id = transactions.category_id
+LEFT JOIN payees
+ON payees.id = transactions.payee_id
+WHERE accounts.user_id = sqlc.arg(user_id)
+AND (sqlc.narg(start_date)::timestamp IS NULL OR transactions.tx_date >= sqlc.narg(start_date))
+AND (sqlc.narg(end_date)::timestamp IS NULL OR transactions.tx_date < sqlc.narg(end_date))
+AND (sqlc.narg(account_id)::uuid IS NULL OR transactions.account_id = sqlc.narg(account_id))
+AND (
+    sqlc.narg(category_id)::uuid IS NULL
+    OR transactions.category_id = sqlc.narg(category_id)
+    OR EXISTS (
+        SELECT 1 FROM transaction_splits
+        WHERE transaction_splits.transaction_id = transactions.id
+        AND transaction_splits.category_id = sqlc.narg(category_id)
+    )
+)
+AND (transactions.tx_date, transactions.id) > (sqlc.arg(after_date)::timestamp, sqlc.arg(after_id)::uuid)
+ORDER BY transactions.tx_date, transactions.id
+LIMIT sqlc.arg(row_limit);
+
+-- name: GetTransactionSplitsForTransactions :many
+SELECT transaction_splits.*,
+categories.category_name
+FROM transaction_splits
+LEFT JOIN categories
+ON categories.id = transaction_splits.category_id
+WHERE transaction_splits.transaction_id = ANY(sqlc.arg(transaction_ids)::uuid[])
+ORDER BY transaction_splits.transaction_id, transaction_splits.sort_order;
+
+-- name: GetUserFirstTransactionDate :one
+SELECT COALESCE(MIN(transactions.tx_date), NOW())::timestamp AS first_tx_date
+FROM transactions
+INNER JOIN accounts
+ON accounts.id = transactions.account_id
+WHERE accounts.user_id = $1;
+
+-- name: GetAccountRegister :many
+SELECT transactions.*,
+categories.category_name,
+payees.payee_name,
+transfer_accounts.account_name AS transfer_account_name,
+EXISTS (
+    SELECT 1 FROM transaction_splits
+    WHERE transaction_splits.transaction_id = transactions.id
+) AS is_split
+FROM transactions
+LEFT JOIN categories
+ON categories.id = transactions.category_id
+LEFT JOIN payees
+ON payees.id = transactions.payee_id
+LEFT JOIN transactions AS transfer_legs
+ON transfer_legs.transfer_id = transactions.transfer_id
+AND transfer_legs.id <> transactions.id
+LEFT JOIN accounts AS transfer_accounts
+ON transfer_accounts.id = transfer_legs.account_id
+WHERE transactions.account_id = sqlc.arg(account_id)
+AND (sqlc.narg(start_date)::timestamp IS NULL OR transactions.tx_date >= sqlc.narg(start_date))
+AND (sqlc.narg(end_date)::timestamp IS NULL OR transactions.tx_date < sqlc.narg(end_date))
+ORDER BY transactions.tx_date, transactions.created_at, transactions.id;
@@ -0,0 +1,46 @@
+-- name: CreatePayee :one
+INSERT INTO payees (id, payee_name, created_at, updated_at, user_id, default_category_id)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6
+)
+RETURNING *;
+
+-- name: GetPayeeByID :one
+SELECT * FROM payees
+WHERE id = $1;
+
+-- name: GetPayeeByName :one
+SELECT * FROM payees
+WHERE user_id = $1
+AND LOWER(payee_name) = LOWER(sqlc.arg(payee_name));
+
+-- name: GetUserPayees :many
+SELECT payees.*,
+categories.category_name AS default_category_name
+FROM payees
+LEFT JOIN categories
+ON categories.id = payees.default_category_id
+WHERE payees.user_id = $1
+ORDER BY payees.payee_name;
+
+-- name: UpdatePayee :one
+UPDATE payees
+SET payee_name = $2,
+default_category_id = $3,
+updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeletePayee :exec
+DELETE FROM payees
+WHERE id = $1;
+
+-- name: GetTransactionsByPayee :many
+SELECT * FROM transactions
+WHERE payee_id = $1
+ORDER BY tx_date::date DESC, tx_date DESC;
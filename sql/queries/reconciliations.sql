@@ -0,0 +1,45 @@
+-- name: CreateReconciliation :one
+INSERT INTO reconciliations (id, statement_date, statement_balance, transaction_count, created_at, updated_at, account_id)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7
+)
+RETURNING *;
+
+-- name: GetReconciliationByID :one
+SELECT * FROM reconciliations
+WHERE id = $1;
+
+-- name: GetReconciliationsByAccount :many
+SELECT * FROM reconciliations
+WHERE account_id = $1
+ORDER BY statement_date DESC, created_at DESC;
+
+-- name: GetLatestReconciliation :one
+SELECT * FROM reconciliations
+WHERE account_id = $1
+ORDER BY statement_date DESC, created_at DESC
+LIMIT 1;
+
+-- name: DeleteReconciliation :exec
+DELETE FROM reconciliations
+WHERE id = $1;
+
+-- name: SetReconciliationTransactionCount :one
+UPDATE reconciliations
+SET transaction_count = $2,
+updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetUserReconciliations :many
+SELECT reconciliations.* FROM reconciliations
+INNER JOIN accounts
+ON accounts.id = reconciliations.account_id
+WHERE accounts.user_id = $1
+ORDER BY reconciliations.statement_date;
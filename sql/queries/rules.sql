@@ -0,0 +1,75 @@
+-- name: CreateRule :one
+INSERT INTO rules (id, rule_name, sort_order, description_contains, description_regex, min_amount, max_amount, account_id, set_category_id, set_description, set_posted, created_at, updated_at, user_id)
+VALUES (
+    $1,
+    $2,
+    (SELECT COALESCE(MAX(sort_order), 0) + 1 FROM rules WHERE rules.user_id = $13),
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9,
+    $10,
+    $11,
+    $12,
+    $13
+)
+RETURNING *;
+
+-- name: GetRuleByID :one
+SELECT * FROM rules
+WHERE id = $1;
+
+-- name: GetUserRules :many
+SELECT rules.*,
+accounts.account_name,
+categories.category_name AS set_category_name
+FROM rules
+LEFT JOIN accounts
+ON accounts.id = rules.account_id
+LEFT JOIN categories
+ON categories.id = rules.set_category_id
+WHERE rules.user_id = $1
+ORDER BY rules.sort_order, rules.created_at;
+
+-- name: UpdateRule :one
+UPDATE rules
+SET rule_name = $2,
+description_contains = $3,
+description_regex = $4,
+min_amount = $5,
+max_amount = $6,
+account_id = $7,
+set_category_id = $8,
+set_description = $9,
+set_posted = $10,
+updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: SetRuleSortOrder :exec
+UPDATE rules
+SET sort_order = $2,
+updated_at = NOW()
+WHERE id = $1;
+
+-- name: DeleteRule :exec
+DELETE FROM rules
+WHERE id = $1;
+
+-- name: GetRuleTargetTransactions :many
+SELECT transactions.* FROM transactions
+INNER JOIN accounts
+ON accounts.id = transactions.account_id
+WHERE accounts.user_id = $1
+AND transactions.tx_date >= sqlc.arg(start_date)
+AND transactions.tx_date < sqlc.arg(end_date)
+AND transactions.transfer_id IS NULL
+AND transactions.reconciliation_id IS NULL
+AND NOT EXISTS (
+    SELECT 1 FROM transaction_splits
+    WHERE transaction_splits.transaction_id = transactions.id
+)
+ORDER BY transactions.tx_date;
@@ -0,0 +1,72 @@
+-- name: CreateScheduledTransaction :one
+INSERT INTO scheduled_transactions (id, amount, tx_description, frequency, day_of_month, start_date, end_date, occurrence_count, created_at, updated_at, user_id, account_id, category_id)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9,
+    $10,
+    $11,
+    $12,
+    $13
+)
+RETURNING *;
+
+-- name: GetScheduledTransactionByID :one
+SELECT * FROM scheduled_transactions
+WHERE id = $1;
+
+-- name: GetScheduledTransactionsByUser :many
+SELECT * FROM scheduled_transactions
+WHERE user_id = $1
+ORDER BY start_date, tx_description;
+
+-- name: GetAllScheduledTransactions :many
+SELECT * FROM scheduled_transactions;
+
+-- name: UpdateScheduledTransaction :one
+UPDATE scheduled_transactions
+SET amount = $2,
+tx_description = $3,
+frequency = $4,
+day_of_month = $5,
+start_date = $6,
+end_date = $7,
+occurrence_count = $8,
+account_id = $9,
+category_id = $10,
+updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: SetScheduledTransactionLastDate :execrows
+UPDATE scheduled_transactions
+SET last_date = $2,
+updated_at = NOW()
+WHERE id = $1 AND (last_date IS NULL OR last_date < $2);
+
+-- name: DeleteScheduledTransaction :exec
+DELETE FROM scheduled_transactions
+WHERE id = $1;
+
+-- name: AddScheduledOccurrence :exec
+INSERT INTO transactions (id, amount, tx_description, tx_date, created_at, updated_at, posted, account_id, category_id, scheduled_id, scheduled_date)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    false,
+    $7,
+    $8,
+    $9,
+    $10
+)
+ON CONFLICT (scheduled_id, scheduled_date) DO NOTHING;
@@ -0,0 +1,109 @@
+-- name: CreateTag :one
+INSERT INTO tags (id, tag_name, created_at, updated_at, user_id)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5
+)
+RETURNING *;
+
+-- name: GetTagByID :one
+SELECT * FROM tags
+WHERE id = $1;
+
+-- name: GetTagByName :one
+SELECT * FROM tags
+WHERE user_id = $1
+AND LOWER(tag_name) = LOWER(sqlc.arg(tag_name));
+
+-- name: GetUserTags :many
+SELECT tags.*,
+COUNT(transaction_tags.transaction_id) AS transaction_count
+FROM tags
+LEFT JOIN transaction_tags
+ON transaction_tags.tag_id = tags.id
+WHERE tags.user_id = $1
+GROUP BY tags.id
+ORDER BY tags.tag_name;
+
+-- name: GetUserTagsByIDs :many
+SELECT * FROM tags
+WHERE user_id = sqlc.arg(user_id)
+AND id = ANY(sqlc.arg(tag_ids)::uuid[]);
+
+-- name: UpdateTag :one
+UPDATE tags
+SET tag_name = $2,
+updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteTag :exec
+DELETE FROM tags
+WHERE id = $1;
+
+-- name: MoveTagTransactions :exec
+INSERT INTO transaction_tags (transaction_id, tag_id)
+SELECT transaction_id, sqlc.arg(target_id)::uuid
+FROM transaction_tags
+WHERE tag_id = sqlc.arg(source_id)
+ON CONFLICT DO NOTHING;
+
+-- name: AddTransactionTag :exec
+INSERT INTO transaction_tags (transaction_id, tag_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: AddTransactionTags :execrows
+INSERT INTO transaction_tags (transaction_id, tag_id)
+SELECT transaction_ids.id, tag_ids.id
+FROM unnest(sqlc.arg(transaction_ids)::uuid[]) AS transaction_ids(id)
+CROSS JOIN unnest(sqlc.arg(tag_ids)::uuid[]) AS tag_ids(id)
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveTransactionTags :execrows
+DELETE FROM transaction_tags
+WHERE transaction_id = ANY(sqlc.arg(transaction_ids)::uuid[])
+AND tag_id = ANY(sqlc.arg(tag_ids)::uuid[]);
+
+-- name: DeleteTransactionTags :exec
+DELETE FROM transaction_tags
+WHERE transaction_id = $1;
+
+-- name: GetTagsForTransactions :many
+SELECT transaction_tags.transaction_id,
+tags.id,
+tags.tag_name
+FROM transaction_tags
+INNER JOIN tags
+ON tags.id = transaction_tags.tag_id
+WHERE transaction_tags.transaction_id = ANY(sqlc.arg(transaction_ids)::uuid[])
+ORDER BY tags.tag_name;
+
+-- name: GetUserTransactionTags :many
+SELECT transaction_tags.*
+FROM transaction_tags
+INNER JOIN tags
+ON tags.id = transaction_tags.tag_id
+WHERE tags.user_id = $1;
+
+-- name: GetTagSpendingReport :many
+SELECT tags.id AS tag_id,
+tags.tag_name,
+COUNT(transactions.id) AS transaction_count,
+COALESCE(SUM(-transactions.amount) FILTER (WHERE transactions.amount < 0), 0)::numeric AS spent,
+COALESCE(SUM(transactions.amount) FILTER (WHERE transactions.amount > 0), 0)::numeric AS income,
+COALESCE(SUM(transactions.amount), 0)::numeric AS net
+FROM tags
+LEFT JOIN transaction_tags
+ON transaction_tags.tag_id = tags.id
+LEFT JOIN transactions
+ON transactions.id = transaction_tags.transaction_id
+AND transactions.transfer_id IS NULL
+AND (sqlc.narg(start_date)::timestamp IS NULL OR transactions.tx_date >= sqlc.narg(start_date))
+AND (sqlc.narg(end_date)::timestamp IS NULL OR transactions.tx_date < sqlc.narg(end_date))
+WHERE tags.user_id = sqlc.arg(user_id)
+GROUP BY tags.id
+ORDER BY spent DESC, tags.tag_name;
@@ -0,0 +1,42 @@
+-- name: CreateTransactionAttachment :one
+INSERT INTO transaction_attachments (id, file_name, content_type, size_bytes, checksum, created_at, updated_at, transaction_id)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8
+)
+RETURNING *;
+
+-- name: GetTransactionAttachmentByID :one
+SELECT * FROM transaction_attachments
+WHERE id = $1;
+
+-- name: GetTransactionAttachments :many
+SELECT * FROM transaction_attachments
+WHERE transaction_id = $1
+ORDER BY created_at, id;
+
+-- name: GetAttachmentCountsForTransactions :many
+SELECT transaction_id, COUNT(*) AS attachment_count
+FROM transaction_attachments
+WHERE transaction_id = ANY(sqlc.arg(transaction_ids)::uuid[])
+GROUP BY transaction_id;
+
+-- name: GetTransferAttachmentIDs :many
+SELECT transaction_attachments.id FROM transaction_attachments
+JOIN transactions ON transactions.id = transaction_attachments.transaction_id
+WHERE transactions.transfer_id = $1;
+
+-- name: GetAccountAttachmentIDs :many
+SELECT transaction_attachments.id FROM transaction_attachments
+JOIN transactions ON transactions.id = transaction_attachments.transaction_id
+WHERE transactions.account_id = $1;
+
+-- name: DeleteTransactionAttachment :exec
+DELETE FROM transaction_attachments
+WHERE id = $1;
@@ -0,0 +1,17 @@
+-- name: AddTransactionImport :exec
+INSERT INTO transaction_imports (transaction_id, account_id, fitid, created_at)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4
+)
+ON CONFLICT DO NOTHING;
+
+-- name: GetAccountImportedFITIDs :many
+SELECT fitid FROM transaction_imports
+WHERE account_id = $1
+UNION
+SELECT fitid FROM import_duplicates
+WHERE account_id = $1
+AND fitid IS NOT NULL;
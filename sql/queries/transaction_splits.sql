@@ -0,0 +1,22 @@
+-- name: AddTransactionSplit :one
+INSERT INTO transaction_splits (id, transaction_id, category_id, amount, memo, sort_order, created_at, updated_at)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8
+)
+RETURNING *;
+
+-- name: GetTransactionSplits :many
+SELECT * FROM transaction_splits
+WHERE transaction_id = $1
+ORDER BY sort_order;
+
+-- name: DeleteTransactionSplits :exec
+DELETE FROM transaction_splits
+WHERE transaction_id = $1;
@@ -1,5 +1,5 @@
 -- name: AddTransaction :one
-INSERT INTO transactions (id, amount, tx_description, tx_date, created_at, updated_at, posted, account_id, category_id)
+INSERT INTO transactions (id, amount, tx_description, tx_date, created_at, updated_at, posted, account_id, category_id, transfer_id, payee_id, memo)
 VALUES (
     $1,
     $2,
@@ -9,7 +9,10 @@ VALUES (
     $6,
     $7,
     $8,
-    $9
+    $9,
+    $10,
+    $11,
+    $12
 )
 RETURNING *;
 
@@ -17,15 +20,34 @@ RETURNING *;
 SELECT * FROM transactions
 WHERE id = $1;
 
+-- name: GetTransferCounterpart :one
+SELECT * FROM transactions
+WHERE transfer_id = $1
+AND id <> $2;
+
 -- name: GetTransactionsByAccount :many
 SELECT * FROM transactions
-WHERE account_id = $1
-ORDER BY tx_date::date DESC, tx_date DESC;
+WHERE account_id = sqlc.arg(account_id)
+AND (sqlc.narg(cursor_date)::timestamp IS NULL OR (tx_date, id) < (sqlc.narg(cursor_date), sqlc.narg(cursor_id)::uuid))
+ORDER BY tx_date DESC, id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: GetTransactionsByAccountBetween :many
+SELECT * FROM transactions
+WHERE account_id = sqlc.arg(account_id)
+AND tx_date >= sqlc.arg(start_date)
+AND tx_date < sqlc.arg(end_date)
+ORDER BY tx_date;
 
 -- name: GetTransactionsByCategory :many
 SELECT * FROM transactions
-WHERE category_id = $1
-ORDER BY tx_date::date DESC, tx_date DESC;
+WHERE id IN (
+    SELECT transaction_id FROM transaction_lines
+    WHERE transaction_lines.category_id = sqlc.arg(category_id)
+)
+AND (sqlc.narg(cursor_date)::timestamp IS NULL OR (tx_date, id) < (sqlc.narg(cursor_date), sqlc.narg(cursor_id)::uuid))
+ORDER BY tx_date DESC, id DESC
+LIMIT sqlc.arg(row_limit);
 
 -- name: UpdateTransaction :one
 UPDATE transactions
@@ -35,10 +57,71 @@ tx_date = $4,
 updated_at = NOW(),
 posted = $5,
 account_id = $6,
-category_id = $7
+category_id = $7,
+payee_id = $8,
+memo = $9
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateTransactionPosted :one
+UPDATE transactions
+SET posted = $2,
+updated_at = NOW()
 WHERE id = $1
 RETURNING *;
 
+-- name: SetTransactionPosted :exec
+UPDATE transactions
+SET posted = true,
+updated_at = NOW()
+WHERE id = $1;
+
+-- name: GetAccountBalanceBefore :one
+SELECT COALESCE(SUM(amount), 0)::numeric AS balance
+FROM transactions
+WHERE account_id = sqlc.arg(account_id)
+AND tx_date < sqlc.arg(before_date);
+
+-- name: GetAccountClearedBalance :one
+SELECT COALESCE(SUM(amount), 0)::numeric AS cleared_balance
+FROM transactions
+WHERE account_id = sqlc.arg(account_id)
+AND posted = true
+AND tx_date < sqlc.arg(end_date);
+
+-- name: ReconcileAccountTransactions :execrows
+UPDATE transactions
+SET reconciliation_id = sqlc.arg(reconciliation_id),
+updated_at = NOW()
+WHERE account_id = sqlc.arg(account_id)
+AND posted = true
+AND reconciliation_id IS NULL
+AND tx_date < sqlc.arg(end_date);
+
 -- name: DeleteTransaction :exec
 DELETE FROM transactions
 WHERE id = $1;
+
+-- name: DeleteTransfer :exec
+DELETE FROM transactions
+WHERE transfer_id = $1;
+
+-- name: RestoreTransaction :exec
+INSERT INTO transactions (id, amount, tx_description, tx_date, created_at, updated_at, posted, account_id, category_id, transfer_id, scheduled_id, scheduled_date, payee_id, reconciliation_id, memo)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9,
+    $10,
+    $11,
+    $12,
+    $13,
+    $14,
+    $15
+);
@@ -11,4 +11,15 @@ RETURNING *;
 
 -- name: GetUserByUsername :one
 SELECT * FROM users
-WHERE username = $1;
\ No newline at end of file
+WHERE username = $1;
+
+-- name: GetUserByID :one
+SELECT * FROM users
+WHERE id = $1;
+
+-- name: UpdateUserBaseCurrency :one
+UPDATE users
+SET base_currency = $2,
+updated_at = NOW()
+WHERE id = $1
+RETURNING *;
\ No newline at end of file
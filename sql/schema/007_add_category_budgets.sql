@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE category_budgets (
+    category_id UUID NOT NULL,
+    month DATE NOT NULL,
+    assigned NUMERIC(12, 2) NOT NULL DEFAULT (0.00),
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (category_id, month),
+    CONSTRAINT fk_category_id
+    FOREIGN KEY (category_id)
+    REFERENCES categories(id)
+    ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE category_budgets;
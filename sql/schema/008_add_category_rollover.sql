@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE categories
+ADD COLUMN rollover_overspending BOOLEAN NOT NULL DEFAULT (true);
+
+-- +goose Down
+ALTER TABLE categories
+DROP COLUMN rollover_overspending;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE categories
+ADD COLUMN is_income BOOLEAN NOT NULL DEFAULT (false);
+
+-- +goose Down
+ALTER TABLE categories
+DROP COLUMN is_income;
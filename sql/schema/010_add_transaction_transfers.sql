@@ -0,0 +1,12 @@
+-- +goose Up
+ALTER TABLE transactions
+ADD COLUMN transfer_id UUID;
+
+CREATE INDEX idx_transactions_transfer_id
+ON transactions(transfer_id);
+
+-- +goose Down
+DROP INDEX idx_transactions_transfer_id;
+
+ALTER TABLE transactions
+DROP COLUMN transfer_id;
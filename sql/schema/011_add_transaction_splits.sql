@@ -0,0 +1,49 @@
+-- +goose Up
+CREATE TABLE transaction_splits (
+    id UUID PRIMARY KEY,
+    transaction_id UUID NOT NULL,
+    category_id UUID,
+    amount NUMERIC(12, 2) NOT NULL,
+    memo TEXT NOT NULL DEFAULT (''),
+    sort_order INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    CONSTRAINT fk_transaction_id
+    FOREIGN KEY (transaction_id)
+    REFERENCES transactions(id)
+    ON DELETE CASCADE,
+    CONSTRAINT fk_category_id
+    FOREIGN KEY (category_id)
+    REFERENCES categories(id)
+    ON DELETE SET NULL
+);
+
+CREATE INDEX idx_transaction_splits_transaction_id
+ON transaction_splits(transaction_id);
+
+CREATE VIEW transaction_lines AS
+SELECT transactions.id AS transaction_id,
+transactions.category_id,
+transactions.amount,
+transactions.tx_date,
+transactions.account_id,
+transactions.transfer_id
+FROM transactions
+WHERE NOT EXISTS (
+    SELECT 1 FROM transaction_splits
+    WHERE transaction_splits.transaction_id = transactions.id
+)
+UNION ALL
+SELECT transaction_splits.transaction_id,
+transaction_splits.category_id,
+transaction_splits.amount,
+transactions.tx_date,
+transactions.account_id,
+transactions.transfer_id
+FROM transaction_splits
+INNER JOIN transactions
+ON transactions.id = transaction_splits.transaction_id;
+
+-- +goose Down
+DROP VIEW transaction_lines;
+DROP TABLE transaction_splits;
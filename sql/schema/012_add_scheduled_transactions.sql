@@ -0,0 +1,49 @@
+-- +goose Up
+CREATE TABLE scheduled_transactions (
+    id UUID PRIMARY KEY,
+    amount NUMERIC(12, 2) NOT NULL,
+    tx_description TEXT NOT NULL,
+    frequency TEXT NOT NULL,
+    day_of_month INTEGER NOT NULL DEFAULT (0),
+    start_date DATE NOT NULL,
+    end_date DATE,
+    occurrence_count INTEGER NOT NULL DEFAULT (0),
+    last_date DATE,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    user_id UUID NOT NULL,
+    account_id UUID NOT NULL,
+    category_id UUID,
+    CONSTRAINT fk_user_id
+    FOREIGN KEY (user_id)
+    REFERENCES users(id)
+    ON DELETE CASCADE,
+    CONSTRAINT fk_account_id
+    FOREIGN KEY (account_id)
+    REFERENCES accounts(id)
+    ON DELETE CASCADE,
+    CONSTRAINT fk_category_id
+    FOREIGN KEY (category_id)
+    REFERENCES categories(id)
+    ON DELETE SET NULL
+);
+
+ALTER TABLE transactions
+ADD COLUMN scheduled_id UUID,
+ADD COLUMN scheduled_date DATE,
+ADD CONSTRAINT fk_scheduled_id
+FOREIGN KEY (scheduled_id)
+REFERENCES scheduled_transactions(id)
+ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX idx_transactions_scheduled_occurrence
+ON transactions(scheduled_id, scheduled_date);
+
+-- +goose Down
+DROP INDEX idx_transactions_scheduled_occurrence;
+
+ALTER TABLE transactions
+DROP COLUMN scheduled_date,
+DROP COLUMN scheduled_id;
+
+DROP TABLE scheduled_transactions;
@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE csv_import_mappings (
+    account_id UUID PRIMARY KEY,
+    skip_rows INTEGER NOT NULL DEFAULT (0),
+    date_column TEXT NOT NULL,
+    date_format TEXT NOT NULL,
+    amount_column TEXT NOT NULL DEFAULT (''),
+    debit_column TEXT NOT NULL DEFAULT (''),
+    credit_column TEXT NOT NULL DEFAULT (''),
+    description_column TEXT NOT NULL,
+    invert_amounts BOOLEAN NOT NULL DEFAULT (false),
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    CONSTRAINT fk_account_id
+    FOREIGN KEY (account_id)
+    REFERENCES accounts(id)
+    ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE csv_import_mappings;
@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE transaction_imports (
+    transaction_id UUID PRIMARY KEY,
+    account_id UUID NOT NULL,
+    fitid TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    UNIQUE (account_id, fitid),
+    CONSTRAINT fk_transaction_id
+    FOREIGN KEY (transaction_id)
+    REFERENCES transactions(id)
+    ON DELETE CASCADE,
+    CONSTRAINT fk_account_id
+    FOREIGN KEY (account_id)
+    REFERENCES accounts(id)
+    ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE transaction_imports;
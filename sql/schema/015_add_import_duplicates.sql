@@ -0,0 +1,27 @@
+-- +goose Up
+CREATE TABLE import_duplicates (
+    id UUID PRIMARY KEY,
+    amount NUMERIC(12, 2) NOT NULL,
+    tx_description TEXT NOT NULL,
+    tx_date TIMESTAMP NOT NULL,
+    fitid TEXT,
+    created_at TIMESTAMP NOT NULL,
+    user_id UUID NOT NULL,
+    account_id UUID NOT NULL,
+    matched_transaction_id UUID,
+    CONSTRAINT fk_user_id
+    FOREIGN KEY (user_id)
+    REFERENCES users(id)
+    ON DELETE CASCADE,
+    CONSTRAINT fk_account_id
+    FOREIGN KEY (account_id)
+    REFERENCES accounts(id)
+    ON DELETE CASCADE,
+    CONSTRAINT fk_matched_transaction_id
+    FOREIGN KEY (matched_transaction_id)
+    REFERENCES transactions(id)
+    ON DELETE SET NULL
+);
+
+-- +goose Down
+DROP TABLE import_duplicates;
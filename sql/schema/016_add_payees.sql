@@ -0,0 +1,31 @@
+-- +goose Up
+CREATE TABLE payees (
+    id UUID PRIMARY KEY,
+    payee_name TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    user_id UUID NOT NULL,
+    default_category_id UUID,
+    UNIQUE (user_id, payee_name),
+    CONSTRAINT fk_user_id
+    FOREIGN KEY (user_id)
+    REFERENCES users(id)
+    ON DELETE CASCADE,
+    CONSTRAINT fk_default_category_id
+    FOREIGN KEY (default_category_id)
+    REFERENCES categories(id)
+    ON DELETE SET NULL
+);
+
+ALTER TABLE transactions
+ADD COLUMN payee_id UUID,
+ADD CONSTRAINT fk_payee_id
+FOREIGN KEY (payee_id)
+REFERENCES payees(id)
+ON DELETE SET NULL;
+
+-- +goose Down
+ALTER TABLE transactions
+DROP COLUMN payee_id;
+
+DROP TABLE payees;
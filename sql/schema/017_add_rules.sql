@@ -0,0 +1,35 @@
+-- +goose Up
+CREATE TABLE rules (
+    id UUID PRIMARY KEY,
+    rule_name TEXT NOT NULL,
+    sort_order INTEGER NOT NULL,
+    description_contains TEXT NOT NULL DEFAULT (''),
+    description_regex TEXT NOT NULL DEFAULT (''),
+    min_amount NUMERIC(12, 2),
+    max_amount NUMERIC(12, 2),
+    account_id UUID,
+    set_category_id UUID,
+    set_description TEXT NOT NULL DEFAULT (''),
+    set_posted BOOLEAN,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    user_id UUID NOT NULL,
+    CONSTRAINT fk_user_id
+    FOREIGN KEY (user_id)
+    REFERENCES users(id)
+    ON DELETE CASCADE,
+    CONSTRAINT fk_account_id
+    FOREIGN KEY (account_id)
+    REFERENCES accounts(id)
+    ON DELETE CASCADE,
+    CONSTRAINT fk_set_category_id
+    FOREIGN KEY (set_category_id)
+    REFERENCES categories(id)
+    ON DELETE SET NULL
+);
+
+CREATE INDEX idx_rules_user_id
+ON rules(user_id, sort_order);
+
+-- +goose Down
+DROP TABLE rules;
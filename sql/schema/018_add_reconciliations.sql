@@ -0,0 +1,30 @@
+-- +goose Up
+CREATE TABLE reconciliations (
+    id UUID PRIMARY KEY,
+    statement_date TIMESTAMP NOT NULL,
+    statement_balance NUMERIC(12, 2) NOT NULL,
+    transaction_count INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    account_id UUID NOT NULL,
+    CONSTRAINT fk_account_id
+    FOREIGN KEY (account_id)
+    REFERENCES accounts(id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX idx_reconciliations_account_id
+ON reconciliations(account_id, statement_date);
+
+ALTER TABLE transactions
+ADD COLUMN reconciliation_id UUID,
+ADD CONSTRAINT fk_reconciliation_id
+FOREIGN KEY (reconciliation_id)
+REFERENCES reconciliations(id)
+ON DELETE SET NULL;
+
+-- +goose Down
+ALTER TABLE transactions
+DROP COLUMN reconciliation_id;
+
+DROP TABLE reconciliations;
@@ -0,0 +1,6 @@
+-- +goose Up
+CREATE INDEX idx_transactions_account_date
+ON transactions(account_id, tx_date, id);
+
+-- +goose Down
+DROP INDEX idx_transactions_account_date;
@@ -0,0 +1,34 @@
+-- +goose Up
+CREATE TABLE tags (
+    id UUID PRIMARY KEY,
+    tag_name TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    user_id UUID NOT NULL,
+    UNIQUE (user_id, tag_name),
+    CONSTRAINT fk_user_id
+    FOREIGN KEY (user_id)
+    REFERENCES users(id)
+    ON DELETE CASCADE
+);
+
+CREATE TABLE transaction_tags (
+    transaction_id UUID NOT NULL,
+    tag_id UUID NOT NULL,
+    PRIMARY KEY (transaction_id, tag_id),
+    CONSTRAINT fk_transaction_id
+    FOREIGN KEY (transaction_id)
+    REFERENCES transactions(id)
+    ON DELETE CASCADE,
+    CONSTRAINT fk_tag_id
+    FOREIGN KEY (tag_id)
+    REFERENCES tags(id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX idx_transaction_tags_tag_id
+ON transaction_tags(tag_id);
+
+-- +goose Down
+DROP TABLE transaction_tags;
+DROP TABLE tags;
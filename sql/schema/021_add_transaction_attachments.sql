@@ -0,0 +1,27 @@
+-- +goose Up
+ALTER TABLE transactions
+ADD COLUMN memo TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE transaction_attachments (
+    id UUID PRIMARY KEY,
+    file_name TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    checksum TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    transaction_id UUID NOT NULL,
+    CONSTRAINT fk_transaction_id
+    FOREIGN KEY (transaction_id)
+    REFERENCES transactions(id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX idx_transaction_attachments_transaction_id
+ON transaction_attachments(transaction_id);
+
+-- +goose Down
+DROP TABLE transaction_attachments;
+
+ALTER TABLE transactions
+DROP COLUMN memo;
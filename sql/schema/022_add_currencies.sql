@@ -0,0 +1,61 @@
+-- +goose Up
+ALTER TABLE users
+ADD COLUMN base_currency TEXT NOT NULL DEFAULT 'USD';
+
+ALTER TABLE accounts
+ADD COLUMN currency TEXT NOT NULL DEFAULT 'USD';
+
+CREATE TABLE exchange_rates (
+    id UUID PRIMARY KEY,
+    from_currency TEXT NOT NULL,
+    to_currency TEXT NOT NULL,
+    rate NUMERIC(18, 8) NOT NULL,
+    rate_date DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    user_id UUID NOT NULL,
+    UNIQUE (user_id, from_currency, to_currency, rate_date),
+    CONSTRAINT fk_user_id
+    FOREIGN KEY (user_id)
+    REFERENCES users(id)
+    ON DELETE CASCADE
+);
+
+-- Each line converted into its owner's base currency at the latest rate on
+-- or before the transaction date. amount is NULL when no such rate exists.
+CREATE VIEW converted_transaction_lines AS
+SELECT transaction_lines.transaction_id,
+transaction_lines.category_id,
+CASE
+    WHEN accounts.currency = users.base_currency THEN transaction_lines.amount
+    ELSE ROUND(transaction_lines.amount * rates.rate, 2)
+END::numeric AS amount,
+transaction_lines.tx_date,
+transaction_lines.account_id,
+transaction_lines.transfer_id,
+accounts.currency
+FROM transaction_lines
+INNER JOIN accounts
+ON accounts.id = transaction_lines.account_id
+INNER JOIN users
+ON users.id = accounts.user_id
+LEFT JOIN LATERAL (
+    SELECT exchange_rates.rate
+    FROM exchange_rates
+    WHERE exchange_rates.user_id = users.id
+    AND exchange_rates.from_currency = accounts.currency
+    AND exchange_rates.to_currency = users.base_currency
+    AND exchange_rates.rate_date <= transaction_lines.tx_date
+    ORDER BY exchange_rates.rate_date DESC
+    LIMIT 1
+) AS rates ON true;
+
+-- +goose Down
+DROP VIEW converted_transaction_lines;
+DROP TABLE exchange_rates;
+
+ALTER TABLE accounts
+DROP COLUMN currency;
+
+ALTER TABLE users
+DROP COLUMN base_currency;
@@ -0,0 +1,113 @@
+-- +goose Up
+-- Account types used to be free text. Fold the old spellings into the
+-- fixed set before checking. These are the same names accounttype.Parse
+-- accepts.
+UPDATE accounts
+SET account_type = replace(replace(lower(trim(account_type)), ' ', '_'), '-', '_');
+
+UPDATE accounts
+SET account_type = CASE account_type
+    WHEN 'chequing' THEN 'checking'
+    WHEN 'saving' THEN 'savings'
+    WHEN 'wallet' THEN 'cash'
+    WHEN 'investing' THEN 'investment'
+    WHEN 'investments' THEN 'investment'
+    WHEN 'brokerage' THEN 'investment'
+    WHEN 'retirement' THEN 'investment'
+    WHEN 'asset' THEN 'other_asset'
+    WHEN 'credit' THEN 'credit_card'
+    WHEN 'cc' THEN 'credit_card'
+    WHEN 'card' THEN 'credit_card'
+    WHEN 'creditcard' THEN 'credit_card'
+    WHEN 'heloc' THEN 'line_of_credit'
+    WHEN 'loc' THEN 'line_of_credit'
+    WHEN 'credit_line' THEN 'line_of_credit'
+    WHEN 'auto_loan' THEN 'loan'
+    WHEN 'car_loan' THEN 'loan'
+    WHEN 'student_loan' THEN 'loan'
+    WHEN 'personal_loan' THEN 'loan'
+    WHEN 'home_loan' THEN 'mortgage'
+    WHEN 'liability' THEN 'other_liability'
+    WHEN 'debt' THEN 'other_liability'
+    ELSE account_type
+END;
+
+-- Guessing wrong would count a debt as an asset, so stop and name the
+-- types that are left. Fix them by hand and run the migration again.
+-- +goose StatementBegin
+DO $$
+DECLARE
+    unknown TEXT;
+BEGIN
+    SELECT string_agg(DISTINCT quote_literal(account_type), ', ')
+    INTO unknown
+    FROM accounts
+    WHERE account_type NOT IN (
+        'checking',
+        'savings',
+        'cash',
+        'credit_card',
+        'line_of_credit',
+        'loan',
+        'mortgage',
+        'investment',
+        'other_asset',
+        'other_liability'
+    );
+
+    IF unknown IS NOT NULL THEN
+        RAISE EXCEPTION 'unknown account types: %', unknown;
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+-- Liability starting balances used to be saved as entered. New ones are
+-- what's owed, so flip the positive ones to match, and move later
+-- statement balances by the same amount.
+WITH flipped AS (
+    UPDATE transactions
+    SET amount = -transactions.amount,
+    updated_at = NOW()
+    FROM accounts
+    WHERE accounts.id = transactions.account_id
+    AND accounts.account_type IN ('credit_card', 'line_of_credit', 'loan', 'mortgage', 'other_liability')
+    AND transactions.tx_description = 'Initial balance'
+    AND transactions.category_id IS NULL
+    AND transactions.transfer_id IS NULL
+    AND transactions.amount > 0
+    RETURNING transactions.account_id, transactions.tx_date, transactions.amount
+)
+UPDATE reconciliations
+SET statement_balance = reconciliations.statement_balance + (
+    SELECT SUM(2 * flipped.amount)
+    FROM flipped
+    WHERE flipped.account_id = reconciliations.account_id
+    AND flipped.tx_date <= reconciliations.statement_date
+),
+updated_at = NOW()
+WHERE EXISTS (
+    SELECT 1
+    FROM flipped
+    WHERE flipped.account_id = reconciliations.account_id
+    AND flipped.tx_date <= reconciliations.statement_date
+);
+
+ALTER TABLE accounts
+ADD CONSTRAINT accounts_account_type_check CHECK (account_type IN (
+    'checking',
+    'savings',
+    'cash',
+    'credit_card',
+    'line_of_credit',
+    'loan',
+    'mortgage',
+    'investment',
+    'other_asset',
+    'other_liability'
+));
+
+-- +goose Down
+-- Types and starting balances stay as they were moved; only the check goes.
+ALTER TABLE accounts
+DROP CONSTRAINT accounts_account_type_check;